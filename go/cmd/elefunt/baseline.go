@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+
+	"golefunt/elefunt"
+	"golefunt/oracle"
+	"golefunt/random"
+	"golefunt/suite"
+)
+
+// baselineFile is the content of a file written by elefunt baseline save:
+// the reports of a run and what is needed to repeat it.
+type baselineFile struct {
+	Version   string            `json:"version"`
+	GitSHA    string            `json:"git_sha"`
+	GoVersion string            `json:"go_version"`
+	N         int               `json:"n"`
+	Seed      int               `json:"seed,omitempty"`
+	Dynamic   bool              `json:"dynamic,omitempty"`
+	Oracle    bool              `json:"oracle,omitempty"`
+	Prec      uint              `json:"prec,omitempty"`
+	Shards    int               `json:"shards,omitempty"`
+	Config    *suite.Config     `json:"config,omitempty"` // Settings from -config and -interval
+	Reports   []*elefunt.Report `json:"reports"`
+}
+
+// options returns the options the named test program was run with.
+func (b *baselineFile) options(name string) suite.Options {
+	opts := suite.Options{N: b.N, Seed: b.Seed, Dynamic: b.Dynamic, Oracle: b.Oracle, Prec: b.Prec, Shards: b.Shards}
+	if b.Config != nil {
+		opts = b.Config.Options(name, opts)
+	}
+	return opts
+}
+
+func baselineCmd(args []string) {
+	if len(args) > 0 {
+		switch args[0] {
+		case "save":
+			baselineSave(args[1:])
+			return
+		case "diff":
+			baselineDiff(args[1:])
+			return
+		}
+	}
+	fmt.Fprintln(os.Stderr, "usage: elefunt baseline save [flags] test... | -all")
+	fmt.Fprintln(os.Stderr, "       elefunt baseline diff [flags] file")
+	os.Exit(2)
+}
+
+func baselineSave(args []string) {
+	fs := flag.NewFlagSet("baseline save", flag.ExitOnError)
+	precision := fs.String("precision", elefunt.PrecisionDouble, "floating-point precision (single or double)")
+	all := fs.Bool("all", false, "run every test")
+	n := fs.Int("n", suite.DefaultN, "number of random arguments per interval")
+	dynamic := fs.Bool("dynamic", false, "run with parameters determined by MACHAR instead of the static table")
+	seed := fs.Int("seed", 0, "seed of the random number generator (0 for the ELEFUNT default)")
+	useOracle := fs.Bool("oracle", false, "also measure the error in ULPs against a math/big reference")
+	prec := fs.Uint("prec", oracle.DefaultPrec, "precision of the reference in bits")
+	shards := fs.Int("shards", 0, "split each random argument test into this many shards run in parallel (0 to run it sequentially)")
+	config := fs.String("config", "", "JSON `file` of sample counts, seeds and intervals per test")
+	var intervals intervalsFlag
+	fs.Var(&intervals, "interval", intervalUsage)
+	output := fs.String("o", "-", "`file` to write the baseline to, or - for standard output")
+	fs.Usage = func() {
+		fs.Output().Write([]byte("usage: elefunt baseline save [flags] test... | -all\n"))
+		fs.PrintDefaults()
+	}
+	names := parseArgs(fs, args)
+	if !elefunt.ValidPrecision(*precision) {
+		fatalf(2, "unknown precision %q", *precision)
+	}
+	if *n <= 0 {
+		fatalf(2, "invalid sample count %d", *n)
+	}
+	if *seed != 0 && !random.ValidSeed(*seed) {
+		fatalf(2, "invalid seed %d, want 1 to %d", *seed, random.MaxSeed)
+	}
+	if *shards < 0 {
+		fatalf(2, "invalid shard count %d", *shards)
+	}
+	tests := selectTests(fs, *all, names)
+
+	b := baselineFile{
+		Version:   Version,
+		GitSHA:    GitSHA,
+		GoVersion: runtime.Version(),
+		N:         *n,
+		Seed:      *seed,
+		Dynamic:   *dynamic,
+		Oracle:    *useOracle,
+		Prec:      *prec,
+		Shards:    *shards,
+	}
+	if *config != "" || len(intervals) > 0 {
+		b.Config = testConfig(fs, *config, *n, *seed, intervals, tests)
+	}
+	for _, t := range tests {
+		rep := elefunt.NewReport(t.Name, Version, GitSHA)
+		t.Run(rep, *precision, b.options(t.Name))
+		b.Reports = append(b.Reports, rep)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(b); err != nil {
+		fatalf(1, "%v", err)
+	}
+	var err error
+	if *output == "-" {
+		_, err = buf.WriteTo(os.Stdout)
+	} else {
+		err = os.WriteFile(*output, buf.Bytes(), 0o644)
+	}
+	if err != nil {
+		fatalf(1, "%v", err)
+	}
+}
+
+func baselineDiff(args []string) {
+	fs := flag.NewFlagSet("baseline diff", flag.ExitOnError)
+	format := fs.String("format", elefunt.FormatText, "output format (text or json)")
+	tolerance := fs.Float64("tolerance", 0, "digits of loss a random argument test may gain before it is reported")
+	ulpTolerance := fs.Float64("ulp-tolerance", 0, "ULPs the errors against the reference may gain before they are reported")
+	fs.Usage = func() {
+		fs.Output().Write([]byte("usage: elefunt baseline diff [flags] file\n"))
+		fs.PrintDefaults()
+	}
+	pos := parseArgs(fs, args)
+	if !elefunt.ValidFormat(*format) {
+		fatalf(2, "unknown output format %q", *format)
+	}
+	if len(pos) != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	b, err := loadBaseline(pos[0])
+	if err != nil {
+		fatalf(1, "%v", err)
+	}
+
+	// Repeat the run recorded in the baseline
+	tol := elefunt.Tolerance{Loss: *tolerance, ULP: *ulpTolerance}
+	var rs []elefunt.Regression
+	for _, base := range b.Reports {
+		t, ok := suite.Lookup(base.Function)
+		if !ok {
+			fatalf(1, "%s: unknown test %q", pos[0], base.Function)
+		}
+		rep := elefunt.NewReport(t.Name, Version, GitSHA)
+		t.Run(rep, base.Precision, b.options(t.Name))
+		rs = append(rs, elefunt.Diff(base, rep, tol)...)
+	}
+
+	if *format == elefunt.FormatJSON {
+		err = writeJSON(struct {
+			BaselineGoVersion string               `json:"baseline_go_version"`
+			GoVersion         string               `json:"go_version"`
+			Regressions       []elefunt.Regression `json:"regressions"`
+		}{b.GoVersion, runtime.Version(), rs})
+		if err != nil {
+			fatalf(1, "%v", err)
+		}
+	} else {
+		fmt.Printf("\n BASELINE %s (ELEFUNT %s %s) VERSUS %s\n\n", b.GoVersion, b.Version, b.GitSHA, runtime.Version())
+		for _, r := range rs {
+			fmt.Printf(" %s\n", r)
+		}
+		if len(rs) > 0 {
+			fmt.Println()
+		}
+		fmt.Printf(" %d REGRESSION(S) IN %d REPORT(S)\n", len(rs), len(b.Reports))
+	}
+	if len(rs) > 0 {
+		os.Exit(1)
+	}
+}
+
+// loadBaseline reads a baseline written by elefunt baseline save.
+func loadBaseline(path string) (*baselineFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var b baselineFile
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if len(b.Reports) == 0 {
+		return nil, fmt.Errorf("%s: no reports in baseline", path)
+	}
+	return &b, nil
+}
@@ -0,0 +1,471 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"golefunt/elefunt"
+	"golefunt/parser"
+	"golefunt/suite"
+)
+
+// Marks of the differences found by compare.
+const (
+	markDrift   = "DRIFT"   // The Go port tests a different identity, interval or expression
+	markWorse   = "WORSE"   // The Go port loses more digits than the tolerance allows
+	markDiffers = "DIFFERS" // A special argument result differs
+)
+
+// row is one line of a comparison: a value printed by both programs.
+type row struct {
+	Name    string        `json:"name"`
+	Fortran elefunt.Float `json:"fortran"`
+	Go      elefunt.Float `json:"go"`
+	Mark    string        `json:"mark,omitempty"`
+}
+
+// testDiff compares one random argument test.
+type testDiff struct {
+	Test    int    `json:"test"`    // Number of the test in the Fortran program, or 0 if it has none
+	GoTest  int    `json:"go_test"` // Number of the test in the Go port, or 0 if it has none
+	Fortran string `json:"fortran"` // Identity tested by the Fortran program
+	Go      string `json:"go"`      // Identity tested by the Go port
+	Mark    string `json:"mark,omitempty"`
+	Rows    []row  `json:"rows"`
+}
+
+// specialDiff compares one identity check, special argument or error return.
+type specialDiff struct {
+	Kind    string `json:"kind"` // "check", "special" or "error"
+	Fortran string `json:"fortran"`
+	Go      string `json:"go"`
+	Value   row    `json:"value"`
+}
+
+// comparison is the difference between the Fortran and Go runs of one test program.
+type comparison struct {
+	Function  string        `json:"function"`
+	Precision string        `json:"precision,omitempty"`
+	Tests     []testDiff    `json:"tests"`
+	Specials  []specialDiff `json:"specials,omitempty"`
+	Flags     int           `json:"flags"` // Number of marked differences
+}
+
+func compareCmd(args []string) {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	var out outputFlags
+	out.register(fs)
+	all := fs.Bool("all", false, "compare every test")
+	dir := fs.String("fortran", "", "`directory` holding the Fortran test programs (default: the fortran directory of the enclosing source tree)")
+	files := fs.Bool("files", false, "compare the saved Fortran and Go output files named as arguments")
+	tol := fs.Float64("tolerance", 1, "digits the Go port may lose beyond the Fortran program before it is marked WORSE")
+	fs.Usage = func() {
+		fs.Output().Write([]byte("usage: elefunt compare [flags] test... | -all\n" +
+			"       elefunt compare [flags] -files fortran.txt go.txt\n"))
+		fs.PrintDefaults()
+	}
+	names := parseArgs(fs, args)
+	out.check()
+
+	var pairs [][2]*elefunt.Report
+	if *files {
+		if *all || len(names) != 2 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		pairs = pairFiles(names[0], names[1])
+	} else {
+		tests := selectTests(fs, *all, names)
+		*dir = fortranDir(*dir)
+		for _, t := range tests {
+			if fortranProgram(t.Name, out.precision) == "" {
+				if *all {
+					continue
+				}
+				fatalf(2, "test %q has no Fortran program", t.Name)
+			}
+			pairs = append(pairs, [2]*elefunt.Report{runFortran(*dir, t.Name, out.precision), runGo(t, out.precision)})
+		}
+	}
+
+	var cs []comparison
+	flags := 0
+	for _, p := range pairs {
+		c := compareReports(p[0], p[1], *tol)
+		flags += c.Flags
+		cs = append(cs, c)
+	}
+	if out.format == elefunt.FormatJSON {
+		if err := writeJSON(cs); err != nil {
+			fatalf(1, "%v", err)
+		}
+	} else {
+		for _, c := range cs {
+			writeComparison(c)
+		}
+		fmt.Printf("\n %d DIFFERENCE(S) MARKED\n", flags)
+	}
+	if flags > 0 {
+		os.Exit(1)
+	}
+}
+
+// fortranProgram returns the name of the Fortran program for the named test,
+// or "" for the tests written for the Go port, which have none.
+func fortranProgram(name, precision string) string {
+	switch name {
+	case "expm1", "cbrt", "asinh", "gamma", "erf":
+		return ""
+	case "sincos":
+		name = "sin"
+	case "log":
+		if precision == elefunt.PrecisionSingle {
+			return "alog"
+		}
+	}
+	if precision == elefunt.PrecisionSingle {
+		return name
+	}
+	return "d" + name
+}
+
+// fortranDir returns dir after checking that it is a directory, or if dir is
+// empty, the fortran directory of the first enclosing directory of the
+// working directory that has one, so that compare works from anywhere in
+// the source tree.
+func fortranDir(dir string) string {
+	if dir != "" {
+		if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+			fatalf(2, "-fortran %s: no such directory", dir)
+		}
+		return dir
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		fatalf(1, "%v", err)
+	}
+	for d := wd; ; d = filepath.Dir(d) {
+		if _, err := os.Stat(filepath.Join(d, "fortran", "Makefile")); err == nil {
+			return filepath.Join(d, "fortran")
+		}
+		if filepath.Dir(d) == d {
+			break
+		}
+	}
+	fatalf(2, "no fortran directory found above %s; name it with -fortran", wd)
+	return ""
+}
+
+// runFortran runs the Fortran program for the named test and parses its output.
+func runFortran(dir, name, precision string) *elefunt.Report {
+	path := filepath.Join(dir, fortranProgram(name, precision))
+	cmd := exec.Command(path)
+	cmd.Stderr = os.Stderr
+	b, err := cmd.Output()
+	if _, ok := err.(*exec.ExitError); err != nil && !ok {
+		fatalf(1, "%s: %v (build the programs with make -C %s)", path, err, dir)
+	} else if err != nil {
+		fatalf(1, "%s: %v", path, err)
+	}
+	return parseOne(path, b)
+}
+
+// runGo runs the Go port of t with the sample count of the Fortran programs
+// and parses its printed output, so that both sides are read to the same
+// printed precision.
+func runGo(t suite.Test, precision string) *elefunt.Report {
+	rep := elefunt.NewReport(t.Name, Version, GitSHA)
+	t.Run(rep, precision, suite.Options{N: suite.DefaultN})
+	var buf bytes.Buffer
+	if err := rep.Encode(&buf, elefunt.FormatText); err != nil {
+		fatalf(1, "%v", err)
+	}
+	return parseOne(t.Name, buf.Bytes())
+}
+
+func parseOne(name string, b []byte) *elefunt.Report {
+	reps, err := parser.Parse(bytes.NewReader(b))
+	if err != nil {
+		fatalf(1, "%s: %v", name, err)
+	}
+	return reps[0]
+}
+
+// pairFiles parses the two output files and pairs their reports by function.
+func pairFiles(fortran, golang string) [][2]*elefunt.Report {
+	parse := func(path string) []*elefunt.Report {
+		f, err := os.Open(path)
+		if err != nil {
+			fatalf(1, "%v", err)
+		}
+		defer f.Close()
+		reps, err := parser.Parse(f)
+		if err != nil {
+			fatalf(1, "%s: %v", path, err)
+		}
+		return reps
+	}
+	frs, grs := parse(fortran), parse(golang)
+	var pairs [][2]*elefunt.Report
+	for _, fr := range frs {
+		for _, gr := range grs {
+			if gr.Function == fr.Function && gr.Precision == fr.Precision {
+				pairs = append(pairs, [2]*elefunt.Report{fr, gr})
+				break
+			}
+		}
+	}
+	if len(pairs) == 0 {
+		fatalf(1, "no test program appears in both %s and %s", fortran, golang)
+	}
+	return pairs
+}
+
+// near reports whether two printed values agree to the four significant
+// digits of the E15.4 fields used by the Fortran programs.
+func near(a, b float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	return a == b || math.Abs(a-b) <= 1e-3*math.Max(math.Abs(a), math.Abs(b))
+}
+
+// compareReports compares the Fortran report f with the Go report g.
+func compareReports(f, g *elefunt.Report, tol float64) comparison {
+	c := comparison{Function: f.Function, Precision: f.Precision}
+	mark := func(m string) string {
+		if m != "" {
+			c.Flags++
+		}
+		return m
+	}
+	values := func(name string, fv, gv float64, m string) row {
+		return row{name, elefunt.Float(fv), elefunt.Float(gv), mark(m)}
+	}
+	drift := func(ok bool) string {
+		if ok {
+			return ""
+		}
+		return markDrift
+	}
+	worse := func(fl, gl float64) string {
+		if gl > fl+tol {
+			return markWorse
+		}
+		return ""
+	}
+	differs := func(fv, gv float64) string {
+		if near(fv, gv) {
+			return ""
+		}
+		return markDiffers
+	}
+
+	// Tests are paired by identity, in order, since a port may add, drop or
+	// reorder them.
+	fk, gk := make([]string, len(f.Tests)), make([]string, len(g.Tests))
+	for i, r := range f.Tests {
+		fk[i] = parser.Normalize(r.Identity)
+	}
+	for j, r := range g.Tests {
+		gk[j] = parser.Normalize(r.Identity)
+	}
+	js, matched := pair(fk, gk)
+	for i, fr := range f.Tests {
+		d := testDiff{Test: i + 1, Fortran: fr.Identity}
+		j := js[i]
+		if j < 0 {
+			d.Mark = mark(markDrift)
+			c.Tests = append(c.Tests, d)
+			continue
+		}
+		gr := g.Tests[j]
+		d.GoTest, d.Go = j+1, gr.Identity
+		d.Rows = []row{
+			values("A", fr.A, gr.A, drift(near(fr.A, gr.A))),
+			values("B", fr.B, gr.B, drift(near(fr.B, gr.B))),
+			values("N", float64(fr.N), float64(gr.N), drift(fr.N == gr.N)),
+			values("MAX ERROR", fr.MaxError, gr.MaxError, ""),
+			values("MAX LOSS", fr.MaxErrorLoss(), gr.MaxErrorLoss(), worse(fr.MaxErrorLoss(), gr.MaxErrorLoss())),
+			values("MAX X", fr.MaxX, gr.MaxX, ""),
+		}
+		if fr.Bivariate || gr.Bivariate {
+			d.Rows = append(d.Rows, values("MAX Y", fr.MaxY, gr.MaxY, ""))
+		}
+		d.Rows = append(d.Rows,
+			values("RMS ERROR", fr.RMSError, gr.RMSError, ""),
+			values("RMS LOSS", fr.RMSErrorLoss(), gr.RMSErrorLoss(), worse(fr.RMSErrorLoss(), gr.RMSErrorLoss())))
+		c.Tests = append(c.Tests, d)
+	}
+	for j, gr := range g.Tests {
+		if !matched[j] {
+			c.Tests = append(c.Tests, testDiff{GoTest: j + 1, Go: gr.Identity, Mark: mark(markDrift)})
+		}
+	}
+
+	// Identity checks, special arguments and error returns are paired the
+	// same way, by expression and for error returns the expected result, and
+	// one found in a single program is marked as drift. Identity checks are
+	// compared by their largest deviation.
+	largest := func(ch elefunt.Check) float64 {
+		m := 0.0
+		for _, s := range ch.Samples {
+			m = math.Max(m, math.Abs(float64(s.Value)))
+		}
+		return m
+	}
+	fk, gk = make([]string, len(f.Checks)), make([]string, len(g.Checks))
+	for i, ch := range f.Checks {
+		fk[i] = parser.Normalize(ch.Identity)
+	}
+	for j, ch := range g.Checks {
+		gk[j] = parser.Normalize(ch.Identity)
+	}
+	js, matched = pair(fk, gk)
+	for i, fc := range f.Checks {
+		if j := js[i]; j >= 0 {
+			gc := g.Checks[j]
+			c.Specials = append(c.Specials, specialDiff{"check", fc.Identity, gc.Identity,
+				values("LARGEST", largest(fc), largest(gc), differs(largest(fc), largest(gc)))})
+			continue
+		}
+		c.Specials = append(c.Specials, specialDiff{"check", fc.Identity, "",
+			values("LARGEST", largest(fc), math.NaN(), markDrift)})
+	}
+	for j, gc := range g.Checks {
+		if !matched[j] {
+			c.Specials = append(c.Specials, specialDiff{"check", "", gc.Identity,
+				values("LARGEST", math.NaN(), largest(gc), markDrift)})
+		}
+	}
+	for _, k := range []struct {
+		kind string
+		f, g []elefunt.Special
+	}{{"special", f.Specials, g.Specials}, {"error", f.Errors, g.Errors}} {
+		key := func(s elefunt.Special) string {
+			return parser.Normalize(s.Expr) + "\x00" + s.Expect
+		}
+		fk, gk := make([]string, len(k.f)), make([]string, len(k.g))
+		for i, s := range k.f {
+			fk[i] = key(s)
+		}
+		for j, s := range k.g {
+			gk[j] = key(s)
+		}
+		js, matched := pair(fk, gk)
+		for i, fs := range k.f {
+			if j := js[i]; j >= 0 {
+				gs := k.g[j]
+				c.Specials = append(c.Specials, specialDiff{k.kind, fs.Expr, gs.Expr,
+					values("VALUE", float64(fs.Value), float64(gs.Value), differs(float64(fs.Value), float64(gs.Value)))})
+				continue
+			}
+			c.Specials = append(c.Specials, specialDiff{k.kind, fs.Expr, "",
+				values("VALUE", float64(fs.Value), math.NaN(), markDrift)})
+		}
+		for j, gs := range k.g {
+			if !matched[j] {
+				c.Specials = append(c.Specials, specialDiff{k.kind, "", gs.Expr,
+					values("VALUE", math.NaN(), float64(gs.Value), markDrift)})
+			}
+		}
+	}
+	return c
+}
+
+// pair pairs the entries of two reports with the keys fk and gk: it returns
+// for each key of fk the index of the first unpaired equal key of gk, or -1
+// if there is none, and which keys of gk were paired.
+func pair(fk, gk []string) (js []int, matched []bool) {
+	js, matched = make([]int, len(fk)), make([]bool, len(gk))
+	for i, k := range fk {
+		js[i] = -1
+		for j := range gk {
+			if !matched[j] && gk[j] == k {
+				js[i], matched[j] = j, true
+				break
+			}
+		}
+	}
+	return js, matched
+}
+
+// writeComparison prints c as a table of the Fortran and Go values.
+func writeComparison(c comparison) {
+	fmt.Printf("\n COMPARISON OF FORTRAN AND GO %s TESTS IN %s PRECISION\n", c.Function, c.Precision)
+	for _, d := range c.Tests {
+		fmt.Printf("\n %s%s\n", testLabel(d), suffix(d.Mark))
+		fmt.Printf("   FORTRAN  %s\n", orMissing(d.Fortran))
+		fmt.Printf("   GO       %s\n", orMissing(d.Go))
+		if len(d.Rows) == 0 {
+			continue
+		}
+		fmt.Printf("   %-10s   %13s   %13s\n", "", "FORTRAN", "GO")
+		for _, r := range d.Rows {
+			fmt.Printf("   %-10s   %13s   %13s%s\n", r.Name, formatValue(r.Name, float64(r.Fortran)), formatValue(r.Name, float64(r.Go)), suffix(r.Mark))
+		}
+	}
+	if len(c.Specials) == 0 {
+		return
+	}
+	fmt.Println("\n SPECIAL TESTS")
+	for _, s := range c.Specials {
+		fmt.Printf("\n   %-7s  FORTRAN  %-40s %13s%s\n", s.Kind, orMissing(s.Fortran), formatSpecial(s.Fortran, s.Value.Fortran), suffix(s.Value.Mark))
+		fmt.Printf("   %-7s  GO       %-40s %13s\n", "", orMissing(s.Go), formatSpecial(s.Go, s.Value.Go))
+	}
+}
+
+// testLabel returns the heading of d, numbered as in the Fortran program
+// and, where that differs, in the Go port.
+func testLabel(d testDiff) string {
+	switch {
+	case d.Test == 0:
+		return fmt.Sprintf("GO TEST %d", d.GoTest)
+	case d.GoTest == 0 || d.GoTest == d.Test:
+		return fmt.Sprintf("TEST %d", d.Test)
+	}
+	return fmt.Sprintf("TEST %d (GO TEST %d)", d.Test, d.GoTest)
+}
+
+// formatValue formats v in the style of the row named name.
+func formatValue(name string, v float64) string {
+	switch name {
+	case "N":
+		return fmt.Sprintf("%d", int(v))
+	case "MAX LOSS", "RMS LOSS":
+		return fmt.Sprintf("%.2f", v)
+	}
+	return formatFloat(v)
+}
+
+func formatFloat(v float64) string {
+	return fmt.Sprintf("%.4E", v)
+}
+
+// formatSpecial formats the value of the special test expr, which is empty
+// when the program does not have the test.
+func formatSpecial(expr string, v elefunt.Float) string {
+	if expr == "" {
+		return "-"
+	}
+	return formatFloat(float64(v))
+}
+
+func orMissing(s string) string {
+	if s == "" {
+		return "(MISSING)"
+	}
+	return s
+}
+
+func suffix(mark string) string {
+	if mark == "" {
+		return ""
+	}
+	return "   " + mark
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golefunt/elefunt"
+	"golefunt/oracle"
+	"golefunt/plot"
+	"golefunt/sweep"
+)
+
+// defaultRects are the rectangles of arguments swept by default: for pow,
+// bases around 1 with exponents large enough to magnify the error of the
+// logarithm of the base.
+var defaultRects = map[string][2]rangeFlag{
+	"pow":   {{0.5, 1.5}, {-1000, 1000}},
+	"atan2": {{-4, 4}, {-4, 4}},
+}
+
+// rangeFlag is an interval of arguments given as a,b.
+type rangeFlag [2]float64
+
+func (r *rangeFlag) String() string {
+	return fmt.Sprintf("%v,%v", r[0], r[1])
+}
+
+func (r *rangeFlag) Set(s string) error {
+	as, bs, ok := strings.Cut(s, ",")
+	a, err1 := strconv.ParseFloat(strings.TrimSpace(as), 64)
+	b, err2 := strconv.ParseFloat(strings.TrimSpace(bs), 64)
+	if !ok || err1 != nil || err2 != nil || !(a < b) || math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return fmt.Errorf("invalid range %q, want a,b with a < b", s)
+	}
+	*r = rangeFlag{a, b}
+	return nil
+}
+
+// heatmapResult is the outcome of a grid sweep of one function.
+type heatmapResult struct {
+	*sweep.Grid
+	Output  string         `json:"output"` // File the heatmap was written to
+	N       int            `json:"n"`
+	MaxULP  elefunt.Float  `json:"max_ulp"`
+	MeanULP elefunt.Float  `json:"mean_ulp"`
+	Worst   []sweep.Region `json:"worst"`
+}
+
+func heatmapCmd(args []string) {
+	fs := flag.NewFlagSet("heatmap", flag.ExitOnError)
+	var out outputFlags
+	out.register(fs)
+	var xr, yr rangeFlag
+	fs.Var(&xr, "x", "range of the first argument, as `a,b` (default depends on the function)")
+	fs.Var(&yr, "y", "range of the second argument, as `a,b` (default depends on the function)")
+	nx := fs.Int("nx", 64, "number of cells along the first argument")
+	ny := fs.Int("ny", 64, "number of cells along the second argument")
+	samples := fs.Int("samples", 1, "arguments per cell: 1 for the center, more for random arguments within the cell")
+	seed := fs.Int("seed", 0, "seed of the random arguments (0 for the ELEFUNT default)")
+	workers := fs.Int("workers", 0, "number of goroutines (0 for one per CPU)")
+	prec := fs.Uint("prec", oracle.DefaultPrec, "precision of the reference in bits")
+	mean := fs.Bool("mean", false, "color the cells by their mean error instead of their maximum error")
+	worst := fs.Int("worst", sweep.DefaultWorst, "number of worst cells to print")
+	output := fs.String("o", "", "`file` to write the heatmap to, SVG or PNG by its extension (default function-precision.svg)")
+	fs.Usage = func() {
+		fs.Output().Write([]byte("usage: elefunt heatmap [flags] function\n"))
+		fs.PrintDefaults()
+	}
+	names := parseArgs(fs, args)
+	out.check()
+	if len(names) != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	name := names[0]
+	exact, ok := oracle.Lookup2(name)
+	if !ok {
+		fatalf(2, "no reference for function of two arguments %q", name)
+	}
+	if *nx <= 0 || *ny <= 0 || *samples <= 0 || *worst <= 0 {
+		fatalf(2, "-nx, -ny, -samples and -worst must be positive")
+	}
+	rect, ok := defaultRects[name]
+	if !ok {
+		rect = [2]rangeFlag{{-1, 1}, {-1, 1}}
+	}
+	if xr == (rangeFlag{}) {
+		xr = rect[0]
+	}
+	if yr == (rangeFlag{}) {
+		yr = rect[1]
+	}
+	path := *output
+	if path == "" {
+		path = name + "-" + out.precision + ".svg"
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".svg" && ext != ".png" {
+		fatalf(2, "unknown heatmap format %q, want .svg or .png", ext)
+	}
+
+	opts := sweep.GridOptions{
+		XA:      xr[0],
+		XB:      xr[1],
+		YA:      yr[0],
+		YB:      yr[1],
+		NX:      *nx,
+		NY:      *ny,
+		Samples: *samples,
+		Seed:    *seed,
+		Workers: *workers,
+		Prec:    *prec,
+	}
+	var g *sweep.Grid
+	if out.precision == elefunt.PrecisionSingle {
+		g = sweep.Grid2(elefunt.MathFuncs[float32]().Func2(name), exact, opts)
+	} else {
+		g = sweep.Grid2(elefunt.MathFuncs[float64]().Func2(name), exact, opts)
+	}
+	g.Function = name
+
+	kind := "MAXIMUM"
+	if *mean {
+		kind = "MEAN"
+	}
+	h := plot.Heatmap{
+		Title:  fmt.Sprintf("%s ERROR OF %s IN %s PRECISION", kind, strings.ToUpper(name), strings.ToUpper(out.precision)),
+		XLabel: "X",
+		YLabel: "Y",
+		Label:  kind + " ERROR IN ULPS",
+		XA:     xr[0],
+		XB:     xr[1],
+		YA:     yr[0],
+		YB:     yr[1],
+		NX:     *nx,
+		NY:     *ny,
+		Values: make([]float64, len(g.Cells)),
+		Log:    true,
+	}
+	for i, c := range g.Cells {
+		h.Values[i] = float64(c.MaxULP)
+		if *mean {
+			h.Values[i] = float64(c.MeanULP)
+		}
+	}
+	var buf bytes.Buffer
+	write := plot.WriteHeatmapSVG
+	if ext == ".png" {
+		write = plot.WriteHeatmapPNG
+	}
+	if err := write(&buf, h); err != nil {
+		fatalf(1, "%v", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		fatalf(1, "%v", err)
+	}
+
+	n, maxULP, meanULP := g.Summary()
+	r := heatmapResult{
+		Grid:    g,
+		Output:  path,
+		N:       n,
+		MaxULP:  elefunt.Float(maxULP),
+		MeanULP: elefunt.Float(meanULP),
+		Worst:   g.Worst(*worst),
+	}
+	if out.format == elefunt.FormatJSON {
+		if err := writeJSON(r); err != nil {
+			fatalf(1, "%v", err)
+		}
+		return
+	}
+	writeHeatmap(r)
+}
+
+// writeHeatmap prints the summary of a grid sweep and its worst cells in
+// the style of the test programs.
+func writeHeatmap(r heatmapResult) {
+	fmt.Printf("\n TEST OF %s OVER A %d BY %d GRID IN %s PRECISION\n\n",
+		strings.ToUpper(r.Function), r.NX, r.NY, strings.ToUpper(r.Precision))
+	fmt.Printf(" %10d ARGUMENTS WERE TESTED FROM THE RECTANGLE\n", r.N)
+	fmt.Printf("      X IN (%.4E, %.4E), Y IN (%.4E, %.4E)\n\n", r.XA, r.XB, r.YA, r.YB)
+	fmt.Printf(" THE MAXIMUM ERROR WAS %.4f ULPS\n", r.MaxULP)
+	fmt.Printf(" THE MEAN ERROR WAS    %.4f ULPS\n", r.MeanULP)
+	fmt.Printf(" THE HEATMAP WAS WRITTEN TO %s\n\n", r.Output)
+	fmt.Println(" THE WORST CELLS WERE")
+	fmt.Println()
+	fmt.Println("        X FROM          X TO          Y FROM          Y TO        MAX ULPS   MEAN ULPS           AT X            AT Y")
+	for _, c := range r.Worst {
+		fmt.Printf("  %14.7E %14.7E %14.7E %14.7E %11.4f %11.4f %14.7E %14.7E\n",
+			c.XA, c.XB, c.YA, c.YB, c.MaxULP, c.MeanULP, c.X, c.Y)
+	}
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"golefunt/suite"
+)
+
+func listCmd(args []string) {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	fs.Parse(args)
+	for _, t := range suite.Tests() {
+		fmt.Printf("%-8s %s\n", t.Name, t.Description)
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"golefunt/elefunt"
+	"golefunt/machar"
+)
+
+func macharCmd(args []string) {
+	fs := flag.NewFlagSet("machar", flag.ExitOnError)
+	var out outputFlags
+	out.register(fs)
+	dynamic := fs.Bool("dynamic", false, "determine the parameters with MACHAR instead of the static table")
+	fs.Usage = func() {
+		fs.Output().Write([]byte("usage: elefunt machar [flags] [verify]\n"))
+		fs.PrintDefaults()
+	}
+	pos := parseArgs(fs, args)
+	out.check()
+	switch {
+	case len(pos) == 1 && pos[0] == "verify":
+		macharVerify(out)
+		return
+	case len(pos) > 0:
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	mp := staticParams(out.precision)
+	if *dynamic {
+		mp = dynamicParams(out.precision)
+	}
+	if out.format == elefunt.FormatJSON {
+		if err := writeJSON(mp); err != nil {
+			fatalf(1, "%v", err)
+		}
+		return
+	}
+	writeParams(mp, out.precision)
+}
+
+func staticParams(precision string) machar.Params {
+	if precision == elefunt.PrecisionSingle {
+		return machar.Float32()
+	}
+	return machar.Float64()
+}
+
+func dynamicParams(precision string) machar.Params {
+	if precision == elefunt.PrecisionSingle {
+		return machar.MacharOf[float32]()
+	}
+	return machar.MacharOf[float64]()
+}
+
+func writeJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// writeParams prints mp in the style of the MACHAR driver output.
+func writeParams(mp machar.Params, precision string) {
+	fmt.Printf("\n MACHINE PARAMETERS FOR %s PRECISION\n\n", strings.ToUpper(precision))
+	for _, f := range mp.Fields() {
+		fmt.Printf(" %-6s = %s\n", f.Name, formatField(f))
+	}
+}
+
+func formatField(f machar.Field) string {
+	if f.Int {
+		return fmt.Sprintf("%6d", int(f.Value))
+	}
+	return fmt.Sprintf("%.6E", f.Value)
+}
+
+// macharVerify compares the parameters determined by MACHAR with the static
+// table and exits with status 1 if they differ.
+func macharVerify(out outputFlags) {
+	got := dynamicParams(out.precision)
+	want := staticParams(out.precision)
+	ms := machar.Diff(got, want)
+	differs := make(map[string]bool)
+	for _, m := range ms {
+		differs[m.Name] = true
+	}
+
+	if out.format == elefunt.FormatJSON {
+		type field struct {
+			Name   string        `json:"name"`
+			Machar elefunt.Float `json:"machar"`
+			Static elefunt.Float `json:"static"`
+			Match  bool          `json:"match"`
+		}
+		var fields []field
+		wf := want.Fields()
+		for i, f := range got.Fields() {
+			fields = append(fields, field{f.Name, elefunt.Float(f.Value), elefunt.Float(wf[i].Value), !differs[f.Name]})
+		}
+		if err := writeJSON(fields); err != nil {
+			fatalf(1, "%v", err)
+		}
+	} else {
+		fmt.Printf("\n MACHAR VERSUS STATIC PARAMETERS FOR %s PRECISION\n\n", strings.ToUpper(out.precision))
+		fmt.Printf(" %-6s   %13s   %13s\n", "", "MACHAR", "STATIC")
+		wf := want.Fields()
+		for i, f := range got.Fields() {
+			mark := ""
+			if differs[f.Name] {
+				mark = "   MISMATCH"
+			}
+			fmt.Printf(" %-6s   %13s   %13s%s\n", f.Name, formatField(f), formatField(wf[i]), mark)
+		}
+		fmt.Printf("\n %d PARAMETER(S) DIFFER\n", len(ms))
+	}
+	if len(ms) > 0 {
+		os.Exit(1)
+	}
+}
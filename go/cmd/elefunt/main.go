@@ -0,0 +1,108 @@
+// Command elefunt runs the ELEFUNT elementary function tests.
+// Port of the elefunt test package by W.J. Cody
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"golefunt/elefunt"
+)
+
+var (
+	Version = "dev"
+	GitSHA  = "unknown"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string)
+}
+
+var commands = []command{
+	{"run", "run the named tests, or every test with -all", runCmd},
+	{"list", "list the available tests", listCmd},
+	{"machar", "print the machine parameters", macharCmd},
+	{"compare", "compare the Fortran test programs with the Go ports", compareCmd},
+	{"parse", "read printed test output into structured reports", parseCmd},
+	{"baseline", "save a run as a baseline, or diff a run against one", baselineCmd},
+	{"sweep", "test float32 functions at every argument in an interval", sweepCmd},
+	{"search", "search for the arguments with the largest errors", searchCmd},
+	{"plot", "chart the errors of the random argument tests as SVG", plotCmd},
+	{"heatmap", "map the errors of a function of two arguments over a rectangle", heatmapCmd},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: elefunt <command> [flags] [arguments]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", c.name, c.usage)
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, `Run "elefunt <command> -h" for the flags of a command.`)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	name := os.Args[1]
+	if name == "-h" || name == "-help" || name == "--help" || name == "help" {
+		usage()
+		return
+	}
+	for _, c := range commands {
+		if c.name == name {
+			c.run(os.Args[2:])
+			return
+		}
+	}
+	fmt.Fprintf(os.Stderr, "elefunt: unknown command %q\n", name)
+	usage()
+	os.Exit(2)
+}
+
+// parseArgs parses flags that may be interspersed with positional arguments
+// and returns the positional arguments.
+func parseArgs(fs *flag.FlagSet, args []string) []string {
+	var pos []string
+	for {
+		fs.Parse(args)
+		args = fs.Args()
+		if len(args) == 0 {
+			return pos
+		}
+		pos = append(pos, args[0])
+		args = args[1:]
+	}
+}
+
+// outputFlags are the flags shared by the commands that print results.
+type outputFlags struct {
+	format    string
+	precision string
+}
+
+func (o *outputFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.format, "format", elefunt.FormatText, "output format (text or json)")
+	fs.StringVar(&o.precision, "precision", elefunt.PrecisionDouble, "floating-point precision (single or double)")
+}
+
+// check exits with a usage error if a flag value is invalid.
+func (o *outputFlags) check() {
+	if !elefunt.ValidFormat(o.format) {
+		fatalf(2, "unknown output format %q", o.format)
+	}
+	if !elefunt.ValidPrecision(o.precision) {
+		fatalf(2, "unknown precision %q", o.precision)
+	}
+}
+
+func fatalf(code int, format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "elefunt: "+format+"\n", args...)
+	os.Exit(code)
+}
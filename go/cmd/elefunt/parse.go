@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+
+	"golefunt/elefunt"
+	"golefunt/parser"
+)
+
+func parseCmd(args []string) {
+	fs := flag.NewFlagSet("parse", flag.ExitOnError)
+	format := fs.String("format", elefunt.FormatJSON, "output format (json or text)")
+	function := fs.String("function", "", "test program of the runs, when it cannot be told from their identities")
+	fs.Usage = func() {
+		fs.Output().Write([]byte("usage: elefunt parse [flags] [file...]\n"))
+		fs.PrintDefaults()
+	}
+	files := parseArgs(fs, args)
+	if !elefunt.ValidFormat(*format) {
+		fatalf(2, "unknown output format %q", *format)
+	}
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	var reps []*elefunt.Report
+	for _, name := range files {
+		rs, err := parseFile(name)
+		if err != nil {
+			fatalf(1, "%s: %v", name, err)
+		}
+		reps = append(reps, rs...)
+	}
+	for _, rep := range reps {
+		if *function != "" {
+			parser.SetFunction(rep, *function)
+		}
+	}
+	if err := writeReports(reps, *format); err != nil {
+		fatalf(1, "%v", err)
+	}
+}
+
+// parseFile parses the printout in the named file, or standard input if name is "-".
+func parseFile(name string) ([]*elefunt.Report, error) {
+	var r io.Reader = os.Stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	return parser.Parse(r)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golefunt/elefunt"
+	"golefunt/oracle"
+	"golefunt/plot"
+	"golefunt/random"
+	"golefunt/suite"
+)
+
+// trace collects the errors of the random argument tests of a run, by test.
+type trace struct {
+	mu  sync.Mutex
+	ulp bool // Collect the errors in ULPs instead of the relative errors
+	x   map[int][]float64
+	e   map[int][]float64
+}
+
+func (t *trace) add(test int, x, y, e float64, ulp bool) {
+	if ulp != t.ulp {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.x[test] = append(t.x[test], x)
+	t.e[test] = append(t.e[test], e)
+}
+
+func plotCmd(args []string) {
+	fs := flag.NewFlagSet("plot", flag.ExitOnError)
+	precision := fs.String("precision", elefunt.PrecisionDouble, "floating-point precision (single or double)")
+	all := fs.Bool("all", false, "plot every test")
+	n := fs.Int("n", suite.DefaultN, "number of random arguments per interval")
+	seed := fs.Int("seed", 0, "seed of the random number generator (0 for the ELEFUNT default)")
+	ulp := fs.Bool("ulp", false, "plot the error in ULPs against the math/big reference instead of the relative error")
+	prec := fs.Uint("prec", oracle.DefaultPrec, "precision of the reference in bits")
+	dir := fs.String("o", ".", "`directory` to write the charts to")
+	var intervals intervalsFlag
+	fs.Var(&intervals, "interval", intervalUsage)
+	fs.Usage = func() {
+		fs.Output().Write([]byte("usage: elefunt plot [flags] test... | -all\n"))
+		fs.PrintDefaults()
+	}
+	names := parseArgs(fs, args)
+	if !elefunt.ValidPrecision(*precision) {
+		fatalf(2, "unknown precision %q", *precision)
+	}
+	if *n <= 0 {
+		fatalf(2, "invalid sample count %d", *n)
+	}
+	if *seed != 0 && !random.ValidSeed(*seed) {
+		fatalf(2, "invalid seed %d, want 1 to %d", *seed, random.MaxSeed)
+	}
+	tests := selectTests(fs, *all, names)
+	cfg := &suite.Config{}
+	intervals.apply(cfg, tests)
+
+	for _, t := range tests {
+		tr := &trace{ulp: *ulp, x: make(map[int][]float64), e: make(map[int][]float64)}
+		opts := cfg.Options(t.Name, suite.Options{N: *n, Seed: *seed, Oracle: *ulp, Prec: *prec, Trace: tr.add})
+		rep := elefunt.NewReport(t.Name, Version, GitSHA)
+		t.Run(rep, *precision, opts)
+
+		var panels []plot.Panel
+		for i, res := range rep.Tests {
+			p := plot.Panel{
+				Title:  fmt.Sprintf("TEST %d: %s, %d ARGUMENTS FROM (%.4E, %.4E)", i+1, res.Identity, res.N, res.A, res.B),
+				XLabel: "X",
+				A:      res.A,
+				B:      res.B,
+				X:      tr.x[i+1],
+				Y:      tr.e[i+1],
+			}
+			if *ulp {
+				if res.ULPN == 0 {
+					continue
+				}
+				p.YLabel = "ERROR IN ULPS"
+				p.Guides = []float64{0.5, 1}
+			} else {
+				eps := math.Pow(float64(res.IBeta), float64(1-res.IT))
+				p.YLabel = "RELATIVE ERROR"
+				p.Guides = []float64{-eps, eps}
+			}
+			panels = append(panels, p)
+		}
+
+		kind, suffix := "RELATIVE ERROR", ""
+		if *ulp {
+			kind, suffix = "ERROR IN ULPS", "-ulp"
+		}
+		title := fmt.Sprintf("%s IN %s PRECISION, %s", strings.ToUpper(t.Description), strings.ToUpper(*precision), kind)
+		var buf bytes.Buffer
+		if err := plot.WriteSVG(&buf, title, panels); err != nil {
+			fatalf(1, "%v", err)
+		}
+		path := filepath.Join(*dir, t.Name+"-"+*precision+suffix+".svg")
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			fatalf(1, "%v", err)
+		}
+		fmt.Println(path)
+	}
+}
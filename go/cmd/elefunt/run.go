@@ -0,0 +1,226 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"golefunt/elefunt"
+	"golefunt/oracle"
+	"golefunt/random"
+	"golefunt/suite"
+)
+
+func runCmd(args []string) {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	var out outputFlags
+	out.register(fs)
+	all := fs.Bool("all", false, "run every test")
+	n := fs.Int("n", suite.DefaultN, "number of random arguments per interval")
+	dynamic := fs.Bool("dynamic", false, "run with parameters determined by MACHAR instead of the static table")
+	seed := fs.Int("seed", 0, "seed of the random number generator (0 for the ELEFUNT default)")
+	useOracle := fs.Bool("oracle", false, "also measure the error in ULPs against a math/big reference")
+	prec := fs.Uint("prec", oracle.DefaultPrec, "precision of the reference in bits")
+	shards := fs.Int("shards", 0, "split each random argument test into this many shards run in parallel (0 to run it sequentially)")
+	workers := fs.Int("workers", 0, "number of goroutines running the shards (0 for GOMAXPROCS)")
+	histograms := fs.Bool("histogram", false, "report histograms and percentiles of the errors of each random argument test")
+	thresholds := fs.String("thresholds", "", "JSON `file` of pass/fail thresholds")
+	maxLoss := fs.Float64("max-loss", -1, "largest allowed digit loss for the maximum error (negative for no limit)")
+	rmsLoss := fs.Float64("rms-loss", -1, "largest allowed digit loss for the RMS error (negative for no limit)")
+	config := fs.String("config", "", "JSON `file` of sample counts, seeds and intervals per test")
+	var intervals intervalsFlag
+	fs.Var(&intervals, "interval", intervalUsage)
+	fs.Usage = func() {
+		fs.Output().Write([]byte("usage: elefunt run [flags] test... | -all\n"))
+		fs.PrintDefaults()
+	}
+	names := parseArgs(fs, args)
+	out.check()
+	if *n <= 0 {
+		fatalf(2, "invalid sample count %d", *n)
+	}
+	if *seed != 0 && !random.ValidSeed(*seed) {
+		fatalf(2, "invalid seed %d, want 1 to %d", *seed, random.MaxSeed)
+	}
+	if *shards < 0 {
+		fatalf(2, "invalid shard count %d", *shards)
+	}
+
+	tests := selectTests(fs, *all, names)
+
+	th := &elefunt.Thresholds{}
+	if *thresholds != "" {
+		var err error
+		if th, err = elefunt.LoadThresholds(*thresholds); err != nil {
+			fatalf(2, "%v", err)
+		}
+	}
+	if *maxLoss >= 0 || *rmsLoss >= 0 {
+		var l elefunt.Limit
+		if *maxLoss >= 0 {
+			v := elefunt.Float(*maxLoss)
+			l.MaxErrorLoss = &v
+		}
+		if *rmsLoss >= 0 {
+			v := elefunt.Float(*rmsLoss)
+			l.RMSErrorLoss = &v
+		}
+		th.Limits = append(th.Limits, l)
+	}
+
+	cfg := testConfig(fs, *config, *n, *seed, intervals, tests)
+
+	reps := make([]*elefunt.Report, len(tests))
+	for i, t := range tests {
+		opts := cfg.Options(t.Name, suite.Options{
+			N: *n, Seed: *seed, Dynamic: *dynamic, Oracle: *useOracle, Prec: *prec,
+			Shards: *shards, Workers: *workers, Histograms: *histograms,
+		})
+		reps[i] = elefunt.NewReport(t.Name, Version, GitSHA)
+		t.Run(reps[i], out.precision, opts)
+		reps[i].Violations = th.Check(reps[i])
+	}
+	if err := writeReports(reps, out.format); err != nil {
+		fatalf(1, "%v", err)
+	}
+
+	var vs []elefunt.Violation
+	for _, rep := range reps {
+		vs = append(vs, rep.Violations...)
+	}
+	if len(vs) > 0 {
+		fmt.Fprintf(os.Stderr, "elefunt: %d threshold(s) violated:\n", len(vs))
+		for _, v := range vs {
+			fmt.Fprintf(os.Stderr, "  %s\n", v)
+		}
+		os.Exit(1)
+	}
+}
+
+const intervalUsage = "replace the interval of a random argument test, as `[name:]test=a,b` (repeatable; the name may be left out when one test is selected)"
+
+// intervalFlag is an interval given by an -interval flag.
+type intervalFlag struct {
+	name string // Test program, or "" for the only one selected
+	test int
+	iv   suite.Interval
+}
+
+// intervalsFlag collects the intervals given by -interval flags.
+type intervalsFlag []intervalFlag
+
+func (f *intervalsFlag) String() string {
+	var s []string
+	for _, iv := range *f {
+		prefix := ""
+		if iv.name != "" {
+			prefix = iv.name + ":"
+		}
+		s = append(s, fmt.Sprintf("%s%d=%v,%v", prefix, iv.test, iv.iv.A, iv.iv.B))
+	}
+	return strings.Join(s, " ")
+}
+
+func (f *intervalsFlag) Set(s string) error {
+	name, j, iv, err := suite.ParseInterval(s)
+	if err != nil {
+		return err
+	}
+	*f = append(*f, intervalFlag{name, j, iv})
+	return nil
+}
+
+// apply sets the intervals in cfg. It exits with a usage error if an
+// interval names a test that is not selected, or names none when more than
+// one is, since test numbers differ from one program to another.
+func (f intervalsFlag) apply(cfg *suite.Config, tests []suite.Test) {
+	for _, iv := range f {
+		name := iv.name
+		switch {
+		case name == "" && len(tests) != 1:
+			fatalf(2, "-interval %d=%v,%v: %d tests are selected; give the test as name:%d=a,b",
+				iv.test, iv.iv.A, iv.iv.B, len(tests), iv.test)
+		case name == "":
+			name = tests[0].Name
+		case !selected(tests, name):
+			fatalf(2, "-interval %s:%d=%v,%v: test %q is not selected", name, iv.test, iv.iv.A, iv.iv.B, name)
+		}
+		if err := cfg.SetInterval(name, iv.test, iv.iv); err != nil {
+			fatalf(2, "-interval %s:%d=%v,%v: %v", name, iv.test, iv.iv.A, iv.iv.B, err)
+		}
+	}
+}
+
+func selected(tests []suite.Test, name string) bool {
+	for _, t := range tests {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// testConfig returns the configuration read from the file named path, if
+// any, with the sample count n and seed applied over it where their flags
+// are given on the command line, and the intervals.
+func testConfig(fs *flag.FlagSet, path string, n, seed int, intervals intervalsFlag, tests []suite.Test) *suite.Config {
+	cfg := &suite.Config{}
+	if path != "" {
+		var err error
+		if cfg, err = suite.LoadConfig(path); err != nil {
+			fatalf(2, "%v", err)
+		}
+	}
+	// Flags given on the command line override the configuration file
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "n":
+			cfg.SetN(n)
+		case "seed":
+			cfg.SetSeed(seed)
+		}
+	})
+	intervals.apply(cfg, tests)
+	return cfg
+}
+
+// selectTests returns the tests named in names, or every test if all is set.
+// It exits with a usage error if the selection is empty or unknown.
+func selectTests(fs *flag.FlagSet, all bool, names []string) []suite.Test {
+	var tests []suite.Test
+	switch {
+	case all && len(names) > 0:
+		fatalf(2, "-all cannot be combined with test names")
+	case all:
+		tests = suite.Tests()
+	case len(names) == 0:
+		fs.Usage()
+		os.Exit(2)
+	}
+	for _, name := range names {
+		t, ok := suite.Lookup(name)
+		if !ok {
+			fatalf(2, "unknown test %q", name)
+		}
+		tests = append(tests, t)
+	}
+	return tests
+}
+
+// writeReports prints the reports one after another as text,
+// or as a JSON array.
+func writeReports(reps []*elefunt.Report, format string) error {
+	if format == elefunt.FormatJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(reps)
+	}
+	for _, rep := range reps {
+		if err := rep.Encode(os.Stdout, format); err != nil {
+			return err
+		}
+	}
+	return nil
+}
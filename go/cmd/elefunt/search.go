@@ -0,0 +1,166 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"golefunt/elefunt"
+	"golefunt/oracle"
+	"golefunt/random"
+	"golefunt/search"
+	"golefunt/suite"
+)
+
+// defaultStarts is the number of worst random arguments refined per function.
+const defaultStarts = 8
+
+// searchResult is the outcome of the search for one function.
+type searchResult struct {
+	Function  string        `json:"function"`
+	Precision string        `json:"precision"`
+	Starts    int           `json:"starts"`    // Number of random arguments refined
+	StartULP  elefunt.Float `json:"start_ulp"` // Largest error among the random arguments
+	Cases     []search.Case `json:"cases"`
+}
+
+// start is a random argument of a test and its error in ULPs.
+type start struct {
+	x, y, ulp float64
+}
+
+// starts keeps the worst random arguments of each function measured by a run.
+type starts struct {
+	mu    sync.Mutex
+	k     int
+	names []string // Functions in the order they were first measured
+	worst map[string][]start
+}
+
+func (s *starts) observe(name string, x, y, ulp float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w, ok := s.worst[name]
+	if !ok {
+		s.names = append(s.names, name)
+	}
+	for _, p := range w {
+		if p.x == x && p.y == y {
+			return
+		}
+	}
+	i := sort.Search(len(w), func(i int) bool { return ulp > w[i].ulp })
+	if i == s.k {
+		return
+	}
+	if len(w) < s.k {
+		w = append(w, start{})
+	}
+	copy(w[i+1:], w[i:])
+	w[i] = start{x, y, ulp}
+	s.worst[name] = w
+}
+
+func searchCmd(args []string) {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	var out outputFlags
+	out.register(fs)
+	all := fs.Bool("all", false, "search the functions of every test")
+	n := fs.Int("n", suite.DefaultN, "number of random arguments per interval")
+	seed := fs.Int("seed", 0, "seed of the random number generator (0 for the ELEFUNT default)")
+	prec := fs.Uint("prec", oracle.DefaultPrec, "precision of the reference in bits")
+	nStarts := fs.Int("starts", defaultStarts, "number of worst random arguments refined per function")
+	evals := fs.Int("evals", search.DefaultEvals, "evaluations of the function from each random argument")
+	cases := fs.Int("cases", search.DefaultCases, "number of cases printed per function")
+	fs.Usage = func() {
+		fs.Output().Write([]byte("usage: elefunt search [flags] test... | -all\n"))
+		fs.PrintDefaults()
+	}
+	names := parseArgs(fs, args)
+	out.check()
+	if *n <= 0 {
+		fatalf(2, "invalid sample count %d", *n)
+	}
+	if *seed != 0 && !random.ValidSeed(*seed) {
+		fatalf(2, "invalid seed %d, want 1 to %d", *seed, random.MaxSeed)
+	}
+	if *nStarts <= 0 || *evals <= 0 || *cases <= 0 {
+		fatalf(2, "-starts, -evals and -cases must be positive")
+	}
+	tests := selectTests(fs, *all, names)
+
+	// Find the worst random arguments of each function measured by the tests
+	st := &starts{k: *nStarts, worst: make(map[string][]start)}
+	opts := suite.Options{N: *n, Seed: *seed, Oracle: true, Prec: *prec, Observe: st.observe}
+	for _, t := range tests {
+		t.Run(elefunt.NewReport(t.Name, Version, GitSHA), out.precision, opts)
+	}
+
+	sopts := search.Options{Evals: *evals, Cases: *cases, Seed: *seed, Prec: *prec}
+	var res []searchResult
+	for _, name := range st.names {
+		w := st.worst[name]
+		r := searchResult{Function: name, Precision: out.precision, Starts: len(w), StartULP: elefunt.Float(w[0].ulp)}
+		if out.precision == elefunt.PrecisionSingle {
+			r.Cases = searchFunc[float32](name, w, sopts)
+		} else {
+			r.Cases = searchFunc[float64](name, w, sopts)
+		}
+		res = append(res, r)
+	}
+
+	if out.format == elefunt.FormatJSON {
+		if err := writeJSON(res); err != nil {
+			fatalf(1, "%v", err)
+		}
+		return
+	}
+	for _, r := range res {
+		writeSearch(r)
+	}
+}
+
+// searchFunc searches the math package function named name in the
+// precision of T from the random arguments in w.
+func searchFunc[T elefunt.Real](name string, w []start, opts search.Options) []search.Case {
+	fns := elefunt.MathFuncs[T]()
+	if exact, ok := oracle.Lookup2(name); ok {
+		xy := make([][2]T, len(w))
+		for i, p := range w {
+			xy[i] = [2]T{T(p.x), T(p.y)}
+		}
+		return search.Func2(fns.Func2(name), exact, xy, opts)
+	}
+	exact, _ := oracle.Lookup1(name)
+	xs := make([]T, len(w))
+	for i, p := range w {
+		xs[i] = T(p.x)
+	}
+	return search.Func1(fns.Func1(name), exact, xs, opts)
+}
+
+// writeSearch prints the cases found for one function, hardest first, with
+// the arguments in full so they can be copied into regression tests.
+func writeSearch(r searchResult) {
+	fmt.Printf("\n SEARCH FOR HARD ARGUMENTS OF %s IN %s PRECISION\n\n", strings.ToUpper(r.Function), strings.ToUpper(r.Precision))
+	fmt.Printf(" THE %d WORST RANDOM ARGUMENTS WERE REFINED\n", r.Starts)
+	fmt.Printf(" THE WORST RANDOM ARGUMENT HAD AN ERROR OF %10.4f ULPS\n", r.StartULP)
+	if len(r.Cases) > 0 {
+		fmt.Printf(" THE WORST ARGUMENT FOUND HAS AN ERROR OF  %10.4f ULPS\n", r.Cases[0].ULP)
+	}
+	fmt.Println()
+	if len(r.Cases) > 0 && r.Cases[0].Y != nil {
+		fmt.Printf(" %4s  %-24s  %-24s  %-24s  %-24s  %10s\n", "RANK", "X", "Y", "F(X,Y)", "REFERENCE", "ULPS")
+	} else {
+		fmt.Printf(" %4s  %-24s  %-24s  %-24s  %10s\n", "RANK", "X", "F(X)", "REFERENCE", "ULPS")
+	}
+	for i, c := range r.Cases {
+		fmt.Printf(" %4d  %-24v", i+1, float64(c.X))
+		if c.Y != nil {
+			fmt.Printf("  %-24v", float64(*c.Y))
+		}
+		fmt.Printf("  %-24v  %-24v  %10.4f\n", float64(c.Got), float64(c.Want), c.ULP)
+	}
+}
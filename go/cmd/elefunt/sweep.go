@@ -0,0 +1,95 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"golefunt/elefunt"
+	"golefunt/oracle"
+	"golefunt/sweep"
+)
+
+func sweepCmd(args []string) {
+	fs := flag.NewFlagSet("sweep", flag.ExitOnError)
+	format := fs.String("format", elefunt.FormatText, "output format (text or json)")
+	lo := fs.Float64("lo", math.Inf(-1), "smallest argument, rounded to float32")
+	hi := fs.Float64("hi", math.Inf(1), "largest argument, rounded to float32")
+	workers := fs.Int("workers", 0, "number of goroutines (0 for one per CPU)")
+	worst := fs.Int("worst", sweep.DefaultWorst, "number of worst arguments to print")
+	useOracle := fs.Bool("oracle", false, "evaluate the math/big reference with -prec bits for every result, not only those near a rounding boundary")
+	prec := fs.Uint("prec", oracle.DefaultPrec, "precision of the reference in bits")
+	fs.Usage = func() {
+		fs.Output().Write([]byte("usage: elefunt sweep [flags] function...\n"))
+		fs.PrintDefaults()
+	}
+	names := parseArgs(fs, args)
+	if !elefunt.ValidFormat(*format) {
+		fatalf(2, "unknown output format %q", *format)
+	}
+	if math.IsNaN(*lo) || math.IsNaN(*hi) || *lo > *hi {
+		fatalf(2, "invalid interval [%v, %v]", *lo, *hi)
+	}
+	if *worst <= 0 {
+		fatalf(2, "invalid number of worst arguments %d", *worst)
+	}
+	if len(names) == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	refs := make([]sweep.Reference, len(names))
+	for i, name := range names {
+		ref, ok := sweep.Lookup(name)
+		if !ok {
+			fatalf(2, "no reference for function %q", name)
+		}
+		refs[i] = ref
+	}
+
+	opts := sweep.Options{
+		Lo:      float32(*lo),
+		Hi:      float32(*hi),
+		Workers: *workers,
+		Worst:   *worst,
+		Oracle:  *useOracle,
+		Prec:    *prec,
+	}
+	fns := elefunt.MathFuncs[float32]()
+	res := make([]sweep.Result, len(names))
+	for i, name := range names {
+		res[i] = sweep.Float32(fns.Func1(name), refs[i], opts)
+		res[i].Function = name
+	}
+
+	if *format == elefunt.FormatJSON {
+		if err := writeJSON(res); err != nil {
+			fatalf(1, "%v", err)
+		}
+		return
+	}
+	for _, r := range res {
+		writeSweep(r)
+	}
+}
+
+// writeSweep prints the result of a sweep in the style of the test programs.
+func writeSweep(r sweep.Result) {
+	fmt.Printf("\n EXHAUSTIVE TEST OF %s IN SINGLE PRECISION\n\n", strings.ToUpper(r.Function))
+	fmt.Printf(" %10d ARGUMENTS WERE TESTED FROM THE INTERVAL\n", r.N)
+	fmt.Printf("      (%.4E, %.4E)\n\n", r.Lo, r.Hi)
+	fmt.Printf(" THE MAXIMUM ERROR WAS %.4f ULPS\n", r.MaxULP)
+	fmt.Printf(" THE MEAN ERROR WAS    %.4f ULPS\n", r.MeanULP)
+	fmt.Printf(" %10d RESULTS WERE NOT CORRECTLY ROUNDED\n", r.Incorrect)
+	if len(r.Worst) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Println(" THE WORST ARGUMENTS WERE")
+	fmt.Println()
+	fmt.Println("        X              F(X)           REFERENCE        ULPS")
+	for _, p := range r.Worst {
+		fmt.Printf("  %14.7E  %14.7E  %14.7E  %9.4f\n", p.X, p.Got, p.Want, p.ULP)
+	}
+}
@@ -0,0 +1,408 @@
+// Package elefunt provides the accuracy bookkeeping shared by the ELEFUNT test programs.
+// It is a Go port of the random argument statistics kept by W.J. Cody's test drivers.
+package elefunt
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"golefunt/machar"
+)
+
+// Accumulator collects the statistics of one random argument identity test.
+type Accumulator struct {
+	mp        machar.Params
+	a, b      float64
+	n         int
+	k1, k3    int
+	x1, y1    float64
+	r6, r7    float64
+	bivariate bool
+
+	ulpN       int
+	ulpMax     float64
+	ulpX, ulpY float64
+	ulpSum     float64
+
+	hist, ulpHist *Histogram // Distributions of the errors, if kept
+
+	onError, onULP func(x, y, e float64)
+}
+
+// Result holds the summary of one random argument identity test.
+//
+// Larger and Smaller count the signs of the relative error, whatever the
+// printed form says. The exp and log programs print Smaller as the number
+// of times the function WAS LARGER and Larger as WAS SMALLER: exp.f
+// measures the error against EXP(X-V), and the log program counts a
+// negative error as LARGER, as the Go port always has.
+type Result struct {
+	Identity  string  // Identity tested, such as "SQRT(X) VS X/SQRT(X)"
+	A, B      float64 // Interval the arguments were drawn from
+	N         int     // Number of arguments tested
+	Larger    int     // Number of samples with a positive relative error, WAS SMALLER for exp and log
+	Agreed    int     // Number of samples with a zero relative error
+	Smaller   int     // Number of samples with a negative relative error, WAS LARGER for exp and log
+	MaxError  float64 // Maximum relative error (R6)
+	MaxX      float64 // Argument at which the maximum error occurred (X1)
+	MaxY      float64 // Second argument at which the maximum error occurred, if any
+	RMSError  float64 // Root mean square relative error (R7)
+	Bivariate bool    // Whether MaxY is meaningful
+	IBeta     int     // Radix of the floating-point representation
+	IT        int     // Number of base IBeta digits in the significand
+
+	ULPN    int     // Number of samples measured against a reference, or 0 if none
+	MaxULP  float64 // Maximum error in ULPs against the reference
+	MaxULPX float64 // Argument at which the maximum error in ULPs occurred
+	MaxULPY float64 // Second argument at which the maximum error in ULPs occurred, if any
+	MeanULP float64 // Mean error in ULPs against the reference
+
+	ErrorHist *Histogram // Distribution of the relative errors, if kept
+	ULPHist   *Histogram // Distribution of the errors in ULPs, if kept and measured
+}
+
+// NewAccumulator returns an Accumulator for arguments drawn from (a, b).
+func NewAccumulator(mp machar.Params, a, b float64) *Accumulator {
+	return &Accumulator{mp: mp, a: a, b: b}
+}
+
+// KeepHistograms makes acc keep the distributions of the relative errors
+// and of the errors in ULPs, for the histograms and percentiles of the result.
+func (acc *Accumulator) KeepHistograms() {
+	acc.hist = NewHistogram()
+	acc.ulpHist = NewHistogram()
+}
+
+// Trace makes acc call onError with the arguments and signed relative
+// error of every sample it records, and onULP with the arguments and error
+// in ULPs of every error measured against a reference. Either may be nil.
+func (acc *Accumulator) Trace(onError, onULP func(x, y, e float64)) {
+	acc.onError = onError
+	acc.onULP = onULP
+}
+
+// Add records the argument x with function value z and identity value zz.
+// The relative error is (z-zz)/z, or 1 if z is zero.
+func (acc *Accumulator) Add(x, z, zz float64) {
+	w := 1.0
+	if z != 0 {
+		w = (z - zz) / z
+	}
+	acc.AddError(x, w)
+}
+
+// AddError records the argument x with a precomputed relative error w.
+func (acc *Accumulator) AddError(x, w float64) {
+	acc.add(x, 0, w)
+}
+
+// AddError2 records the argument pair (x, y) with a precomputed relative error w.
+func (acc *Accumulator) AddError2(x, y, w float64) {
+	acc.bivariate = true
+	acc.add(x, y, w)
+}
+
+func (acc *Accumulator) add(x, y, w float64) {
+	if acc.onError != nil {
+		acc.onError(x, y, w)
+	}
+	acc.n++
+	if w > 0 {
+		acc.k1++
+	}
+	if w < 0 {
+		acc.k3++
+	}
+	w = math.Abs(w)
+	if w > acc.r6 {
+		acc.r6 = w
+		acc.x1 = x
+		acc.y1 = y
+	}
+	acc.r7 = acc.r7 + w*w
+	if acc.hist != nil {
+		acc.hist.Add(w)
+	}
+}
+
+// AddULP records the error u in ULPs against a reference value at the argument x.
+func (acc *Accumulator) AddULP(x, u float64) {
+	acc.addULP(x, 0, u)
+}
+
+// AddULP2 records the error u in ULPs against a reference value at the argument pair (x, y).
+func (acc *Accumulator) AddULP2(x, y, u float64) {
+	acc.addULP(x, y, u)
+}
+
+func (acc *Accumulator) addULP(x, y, u float64) {
+	if acc.onULP != nil {
+		acc.onULP(x, y, u)
+	}
+	if acc.ulpN == 0 || u > acc.ulpMax {
+		acc.ulpMax = u
+		acc.ulpX = x
+		acc.ulpY = y
+	}
+	acc.ulpN++
+	acc.ulpSum += u
+	if acc.ulpHist != nil {
+		acc.ulpHist.Add(u)
+	}
+}
+
+// Merge adds the samples recorded in o to acc, as if they had been recorded
+// in acc after its own. Merging the accumulators of consecutive shards of a
+// test in order gives the same counts and maximum errors as recording every
+// sample in one accumulator; the sums of squares may differ in rounding.
+func (acc *Accumulator) Merge(o *Accumulator) {
+	acc.n += o.n
+	acc.k1 += o.k1
+	acc.k3 += o.k3
+	if o.r6 > acc.r6 {
+		acc.r6 = o.r6
+		acc.x1 = o.x1
+		acc.y1 = o.y1
+	}
+	acc.r7 = acc.r7 + o.r7
+	acc.bivariate = acc.bivariate || o.bivariate
+
+	if o.ulpN > 0 && (acc.ulpN == 0 || o.ulpMax > acc.ulpMax) {
+		acc.ulpMax = o.ulpMax
+		acc.ulpX = o.ulpX
+		acc.ulpY = o.ulpY
+	}
+	acc.ulpN += o.ulpN
+	acc.ulpSum += o.ulpSum
+
+	if acc.hist != nil && o.hist != nil {
+		acc.hist.Merge(o.hist)
+		acc.ulpHist.Merge(o.ulpHist)
+	}
+}
+
+// Result returns the summary of the samples recorded so far.
+func (acc *Accumulator) Result() Result {
+	r7 := 0.0
+	if acc.n > 0 {
+		r7 = math.Sqrt(acc.r7 / float64(acc.n))
+	}
+	mean := 0.0
+	var ulpHist *Histogram
+	if acc.ulpN > 0 {
+		mean = acc.ulpSum / float64(acc.ulpN)
+		ulpHist = acc.ulpHist
+	}
+	return Result{
+		A:         acc.a,
+		B:         acc.b,
+		N:         acc.n,
+		Larger:    acc.k1,
+		Agreed:    acc.n - acc.k3 - acc.k1,
+		Smaller:   acc.k3,
+		MaxError:  acc.r6,
+		MaxX:      acc.x1,
+		MaxY:      acc.y1,
+		RMSError:  r7,
+		Bivariate: acc.bivariate,
+		IBeta:     acc.mp.IBeta,
+		IT:        acc.mp.IT,
+		ULPN:      acc.ulpN,
+		MaxULP:    acc.ulpMax,
+		MaxULPX:   acc.ulpX,
+		MaxULPY:   acc.ulpY,
+		MeanULP:   mean,
+		ErrorHist: acc.hist,
+		ULPHist:   ulpHist,
+	}
+}
+
+// exponent returns log base IBeta of e, or -999 if e is zero.
+func (r Result) exponent(e float64) float64 {
+	if e == 0 {
+		return -999.0
+	}
+	return math.Log(math.Abs(e)) / math.Log(float64(r.IBeta))
+}
+
+// MaxErrorExponent returns the maximum relative error as a power of IBeta.
+func (r Result) MaxErrorExponent() float64 {
+	return r.exponent(r.MaxError)
+}
+
+// RMSErrorExponent returns the root mean square relative error as a power of IBeta.
+func (r Result) RMSErrorExponent() float64 {
+	return r.exponent(r.RMSError)
+}
+
+// MaxErrorLoss returns the estimated loss of base IBeta digits for the maximum error.
+func (r Result) MaxErrorLoss() float64 {
+	return math.Max(float64(r.IT)+r.MaxErrorExponent(), 0)
+}
+
+// RMSErrorLoss returns the estimated loss of base IBeta digits for the RMS error.
+func (r Result) RMSErrorLoss() float64 {
+	return math.Max(float64(r.IT)+r.RMSErrorExponent(), 0)
+}
+
+// WriteHeading prints the identity and interval of the test in the format of Cody's programs.
+func (r Result) WriteHeading(w io.Writer) {
+	fmt.Fprintf(w, "\nTEST OF %s\n\n", r.Identity)
+	fmt.Fprintf(w, "%7d RANDOM ARGUMENTS WERE TESTED FROM THE INTERVAL\n", r.N)
+	fmt.Fprintf(w, "      (%.4E, %.4E)\n\n", r.A, r.B)
+}
+
+// WriteCounts prints how often the value fx, such as "SIN(X)", was larger
+// than, agreed with and was smaller than the other side of the identity,
+// in the format of Cody's programs.
+func WriteCounts(w io.Writer, fx string, larger, agreed, smaller int) {
+	pad := strings.Repeat(" ", len(fx))
+	fmt.Fprintf(w, " %s WAS LARGER %6d TIMES,\n", fx, larger)
+	fmt.Fprintf(w, " %s    AGREED %6d TIMES, AND\n", pad, agreed)
+	fmt.Fprintf(w, " %sWAS SMALLER %6d TIMES.\n\n", pad, smaller)
+}
+
+// WriteErrors prints the significant digit and error summary in the format of Cody's programs.
+func (r Result) WriteErrors(w io.Writer) {
+	fmt.Fprintf(w, " THERE ARE %4d BASE %4d SIGNIFICANT DIGITS IN A FLOATING-POINT NUMBER\n\n", r.IT, r.IBeta)
+
+	fmt.Fprintf(w, " THE MAXIMUM RELATIVE ERROR OF %.4E = %4d ** %7.2f\n", r.MaxError, r.IBeta, r.MaxErrorExponent())
+	if r.Bivariate {
+		fmt.Fprintf(w, "    OCCURRED FOR X = %.6E, Y = %.6E\n", r.MaxX, r.MaxY)
+	} else {
+		fmt.Fprintf(w, "    OCCURRED FOR X = %.6E\n", r.MaxX)
+	}
+	fmt.Fprintf(w, " THE ESTIMATED LOSS OF BASE %4d SIGNIFICANT DIGITS IS %7.2f\n\n", r.IBeta, r.MaxErrorLoss())
+
+	fmt.Fprintf(w, " THE ROOT MEAN SQUARE RELATIVE ERROR WAS %.4E = %4d ** %7.2f\n", r.RMSError, r.IBeta, r.RMSErrorExponent())
+	fmt.Fprintf(w, " THE ESTIMATED LOSS OF BASE %4d SIGNIFICANT DIGITS IS %7.2f\n\n", r.IBeta, r.RMSErrorLoss())
+	if r.ErrorHist != nil {
+		r.ErrorHist.writeErrors(w, r.IBeta)
+	}
+
+	if r.ULPN > 0 {
+		fmt.Fprintf(w, " THE MAXIMUM ERROR AGAINST THE REFERENCE WAS %10.4f ULPS\n", r.MaxULP)
+		if r.Bivariate {
+			fmt.Fprintf(w, "    OCCURRED FOR X = %.6E, Y = %.6E\n", r.MaxULPX, r.MaxULPY)
+		} else {
+			fmt.Fprintf(w, "    OCCURRED FOR X = %.6E\n", r.MaxULPX)
+		}
+		fmt.Fprintf(w, " THE MEAN ERROR AGAINST THE REFERENCE WAS %10.4f ULPS\n\n", r.MeanULP)
+		if r.ULPHist != nil {
+			r.ULPHist.writeULPs(w)
+		}
+	}
+}
+
+type resultJSON struct {
+	Identity         string         `json:"identity"`
+	A                Float          `json:"a"`
+	B                Float          `json:"b"`
+	N                int            `json:"n"`
+	Larger           int            `json:"larger"`
+	Agreed           int            `json:"agreed"`
+	Smaller          int            `json:"smaller"`
+	MaxError         Float          `json:"max_error"`
+	MaxErrorExponent Float          `json:"max_error_exponent"`
+	MaxErrorLoss     Float          `json:"max_error_loss"`
+	MaxX             Float          `json:"max_x"`
+	MaxY             *Float         `json:"max_y,omitempty"`
+	RMSError         Float          `json:"rms_error"`
+	RMSErrorExponent Float          `json:"rms_error_exponent"`
+	RMSErrorLoss     Float          `json:"rms_error_loss"`
+	IBeta            int            `json:"ibeta"`
+	IT               int            `json:"it"`
+	Histogram        *histogramJSON `json:"histogram,omitempty"`
+	ULP              *ulpJSON       `json:"ulp,omitempty"`
+}
+
+type ulpJSON struct {
+	N    int    `json:"n"`
+	Max  Float  `json:"max"`
+	MaxX Float  `json:"max_x"`
+	MaxY *Float `json:"max_y,omitempty"`
+	Mean Float  `json:"mean"`
+
+	Histogram *histogramJSON `json:"histogram,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// The encoding includes the error exponents and estimated digit losses.
+func (r Result) MarshalJSON() ([]byte, error) {
+	v := resultJSON{
+		Identity:         r.Identity,
+		A:                Float(r.A),
+		B:                Float(r.B),
+		N:                r.N,
+		Larger:           r.Larger,
+		Agreed:           r.Agreed,
+		Smaller:          r.Smaller,
+		MaxError:         Float(r.MaxError),
+		MaxErrorExponent: Float(r.MaxErrorExponent()),
+		MaxErrorLoss:     Float(r.MaxErrorLoss()),
+		MaxX:             Float(r.MaxX),
+		RMSError:         Float(r.RMSError),
+		RMSErrorExponent: Float(r.RMSErrorExponent()),
+		RMSErrorLoss:     Float(r.RMSErrorLoss()),
+		IBeta:            r.IBeta,
+		IT:               r.IT,
+	}
+	if r.Bivariate {
+		y := Float(r.MaxY)
+		v.MaxY = &y
+	}
+	if r.ErrorHist != nil {
+		v.Histogram = r.ErrorHist.errorsJSON(r.IBeta)
+	}
+	if r.ULPN > 0 {
+		v.ULP = &ulpJSON{N: r.ULPN, Max: Float(r.MaxULP), MaxX: Float(r.MaxULPX), Mean: Float(r.MeanULP)}
+		if r.Bivariate {
+			y := Float(r.MaxULPY)
+			v.ULP.MaxY = &y
+		}
+		if r.ULPHist != nil {
+			v.ULP.Histogram = r.ULPHist.ulpsJSON()
+		}
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// The derived exponents and digit losses, and the histograms, are ignored.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	var v resultJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*r = Result{
+		Identity: v.Identity,
+		A:        float64(v.A),
+		B:        float64(v.B),
+		N:        v.N,
+		Larger:   v.Larger,
+		Agreed:   v.Agreed,
+		Smaller:  v.Smaller,
+		MaxError: float64(v.MaxError),
+		MaxX:     float64(v.MaxX),
+		RMSError: float64(v.RMSError),
+		IBeta:    v.IBeta,
+		IT:       v.IT,
+	}
+	if v.MaxY != nil {
+		r.MaxY = float64(*v.MaxY)
+		r.Bivariate = true
+	}
+	if v.ULP != nil {
+		r.ULPN = v.ULP.N
+		r.MaxULP = float64(v.ULP.Max)
+		r.MaxULPX = float64(v.ULP.MaxX)
+		r.MeanULP = float64(v.ULP.Mean)
+		if v.ULP.MaxY != nil {
+			r.MaxULPY = float64(*v.ULP.MaxY)
+		}
+	}
+	return nil
+}
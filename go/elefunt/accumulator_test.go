@@ -0,0 +1,267 @@
+package elefunt
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	"golefunt/machar"
+)
+
+// sample is one recording of an Accumulator: a relative error at (x, y), or
+// with ulp set, an error in ULPs against a reference.
+type sample struct {
+	x, y, e   float64
+	bivariate bool
+	ulp       bool
+}
+
+func (s sample) record(acc *Accumulator) {
+	switch {
+	case s.ulp && s.bivariate:
+		acc.AddULP2(s.x, s.y, s.e)
+	case s.ulp:
+		acc.AddULP(s.x, s.e)
+	case s.bivariate:
+		acc.AddError2(s.x, s.y, s.e)
+	default:
+		acc.AddError(s.x, s.e)
+	}
+}
+
+func TestAdd(t *testing.T) {
+	tests := []struct {
+		name string
+		add  func(acc *Accumulator)
+		want Result
+	}{
+		{
+			name: "none",
+			add:  func(acc *Accumulator) {},
+			want: Result{},
+		},
+		{
+			name: "Add",
+			add: func(acc *Accumulator) {
+				acc.Add(0.25, 4, 3)  // (4-3)/4
+				acc.Add(0.5, 2, 2)   // Agreed
+				acc.Add(0.75, 4, 6)  // (4-6)/4
+				acc.Add(0.125, 0, 1) // A zero function value counts as an error of 1
+			},
+			want: Result{
+				N: 4, Larger: 2, Agreed: 1, Smaller: 1,
+				MaxError: 1, MaxX: 0.125,
+				RMSError: math.Sqrt((0.0625 + 0.25 + 1) / 4),
+			},
+		},
+		{
+			name: "AddError",
+			add: func(acc *Accumulator) {
+				acc.AddError(1, -0x1p-50)
+				acc.AddError(2, 0x1p-52)
+				acc.AddError(3, 0x1p-50) // A tie keeps the first argument
+			},
+			want: Result{
+				N: 3, Larger: 2, Smaller: 1,
+				MaxError: 0x1p-50, MaxX: 1,
+				RMSError: math.Sqrt((0x1p-100 + 0x1p-104 + 0x1p-100) / 3),
+			},
+		},
+		{
+			name: "AddError2",
+			add: func(acc *Accumulator) {
+				acc.AddError2(1, 2, 0x1p-52)
+				acc.AddError2(3, 4, -0x1p-51)
+			},
+			want: Result{
+				N: 2, Larger: 1, Smaller: 1,
+				MaxError: 0x1p-51, MaxX: 3, MaxY: 4,
+				RMSError: math.Sqrt((0x1p-104 + 0x1p-102) / 2), Bivariate: true,
+			},
+		},
+		{
+			name: "AddULP",
+			add: func(acc *Accumulator) {
+				acc.AddULP(1, 0.5)
+				acc.AddULP(2, 1.5)
+				acc.AddULP(3, 1.5)
+				acc.AddULP(4, 0)
+			},
+			want: Result{ULPN: 4, MaxULP: 1.5, MaxULPX: 2, MeanULP: 3.5 / 4},
+		},
+		{
+			name: "AddULP2",
+			add: func(acc *Accumulator) {
+				acc.AddError2(1, 2, 0)
+				acc.AddULP2(1, 2, 0.25)
+			},
+			want: Result{
+				N: 1, Agreed: 1, MaxX: 0, Bivariate: true,
+				ULPN: 1, MaxULP: 0.25, MaxULPX: 1, MaxULPY: 2, MeanULP: 0.25,
+			},
+		},
+	}
+	mp := machar.Float64()
+	for _, tt := range tests {
+		acc := NewAccumulator(mp, -1, 1)
+		tt.add(acc)
+		want := tt.want
+		want.A, want.B, want.IBeta, want.IT = -1, 1, mp.IBeta, mp.IT
+		if got := acc.Result(); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, want)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	// The largest errors occur several times, so that merging must keep
+	// the first of them.
+	var samples []sample
+	for i := 0; i < 300; i++ {
+		x := float64(i)
+		e := math.Ldexp(float64(i%37-18), -50-i%5)
+		samples = append(samples,
+			sample{x: x, y: -x, e: e, bivariate: i%3 == 0},
+			sample{x: x, y: -x, e: float64(i%41) / 8, bivariate: i%3 == 0, ulp: true})
+	}
+	record := func(acc *Accumulator, ss []sample) *Accumulator {
+		for _, s := range ss {
+			s.record(acc)
+		}
+		return acc
+	}
+	mp := machar.Float64()
+	for _, keep := range []bool{false, true} {
+		newAcc := func() *Accumulator {
+			acc := NewAccumulator(mp, 0, 300)
+			if keep {
+				acc.KeepHistograms()
+			}
+			return acc
+		}
+		want := record(newAcc(), samples).Result()
+		for _, shards := range []int{1, 2, 3, 10, len(samples)} {
+			got := newAcc()
+			for k := 0; k < shards; k++ {
+				got.Merge(record(newAcc(), samples[k*len(samples)/shards:(k+1)*len(samples)/shards]))
+			}
+			r := got.Result()
+			if math.Abs(r.RMSError-want.RMSError) > 1e-12*want.RMSError || math.Abs(r.MeanULP-want.MeanULP) > 1e-12*want.MeanULP {
+				t.Errorf("%d shards: RMS error %g and mean %g ULPs, want %g and %g", shards, r.RMSError, r.MeanULP, want.RMSError, want.MeanULP)
+			}
+			r.RMSError, r.MeanULP = want.RMSError, want.MeanULP
+			if !reflect.DeepEqual(r, want) {
+				t.Errorf("%d shards, histograms %v: got %+v, want %+v", shards, keep, r, want)
+			}
+		}
+	}
+
+	// An accumulator with no errors in ULPs takes those of the other.
+	acc := NewAccumulator(mp, 0, 1)
+	o := NewAccumulator(mp, 0, 1)
+	o.AddULP(0.5, 0)
+	acc.Merge(o)
+	if r := acc.Result(); r.ULPN != 1 || r.MaxULPX != 0.5 {
+		t.Errorf("merged %d errors in ULPs at %g, want 1 at 0.5", r.ULPN, r.MaxULPX)
+	}
+}
+
+func TestLoss(t *testing.T) {
+	tests := []struct {
+		ibeta, it int
+		e         float64
+		exponent  float64
+		loss      float64
+	}{
+		{ibeta: 2, it: 53, e: 0x1p-50, exponent: -50, loss: 3},
+		{ibeta: 2, it: 53, e: 0x1p-53, exponent: -53, loss: 0},
+		{ibeta: 2, it: 53, e: 0x1p-60, exponent: -60, loss: 0}, // Never negative
+		{ibeta: 2, it: 24, e: -0x1p-20, exponent: -20, loss: 4},
+		{ibeta: 2, it: 24, e: 0, exponent: -999, loss: 0},
+		{ibeta: 16, it: 14, e: 0x1p-44, exponent: -11, loss: 3},
+		{ibeta: 10, it: 16, e: 1e-10, exponent: -10, loss: 6},
+	}
+	for _, tt := range tests {
+		r := Result{IBeta: tt.ibeta, IT: tt.it, MaxError: tt.e, RMSError: tt.e / 2}
+		if got := r.MaxErrorExponent(); math.Abs(got-tt.exponent) > 1e-12 {
+			t.Errorf("base %d: exponent of %g = %g, want %g", tt.ibeta, tt.e, got, tt.exponent)
+		}
+		if got := r.MaxErrorLoss(); math.Abs(got-tt.loss) > 1e-12 {
+			t.Errorf("base %d, %d digits: loss for %g = %g, want %g", tt.ibeta, tt.it, tt.e, got, tt.loss)
+		}
+		if tt.e == 0 {
+			continue
+		}
+		// The RMS error is half the maximum, one base 2 digit less.
+		half := math.Log(2) / math.Log(float64(tt.ibeta))
+		if got, want := r.RMSErrorExponent(), tt.exponent-half; math.Abs(got-want) > 1e-12 {
+			t.Errorf("base %d: exponent of %g = %g, want %g", tt.ibeta, tt.e/2, got, want)
+		}
+		if got, want := r.RMSErrorLoss(), math.Max(tt.loss-half, 0); tt.loss > 0 && math.Abs(got-want) > 1e-12 {
+			t.Errorf("base %d, %d digits: loss for %g = %g, want %g", tt.ibeta, tt.it, tt.e/2, got, want)
+		}
+	}
+}
+
+func TestResultJSON(t *testing.T) {
+	tests := []Result{
+		{Identity: "EXP(X-2.8125) VS EXP(X)/EXP(2.8125)", A: -2.8125, B: 0.0625, N: 2000,
+			Larger: 12, Agreed: 1980, Smaller: 8, MaxError: 0x1p-52, MaxX: 0.03125,
+			RMSError: 0x1p-55, IBeta: 2, IT: 53},
+		{Identity: "X**Y VS (X**2)**(Y/2)", A: 0.01, B: 10, N: 2000, Larger: 1000, Smaller: 1000,
+			MaxError: 1e-15, MaxX: 3.5, MaxY: -7, RMSError: 1e-16, Bivariate: true, IBeta: 2, IT: 53,
+			ULPN: 2000, MaxULP: 0.75, MaxULPX: 2.5, MaxULPY: 6, MeanULP: 0.25},
+		{Identity: "SQRT(X) VS X/SQRT(X)", N: 1, Agreed: 1, IBeta: 2, IT: 24,
+			MaxError: 0, MaxX: math.Inf(-1), RMSError: math.NaN()},
+	}
+	for _, r := range tests {
+		b, err := json.Marshal(r)
+		if err != nil {
+			t.Fatalf("%s: %v", r.Identity, err)
+		}
+		var got Result
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatalf("%s: %v", r.Identity, err)
+		}
+		// NaN never equals itself, so compare the printed forms.
+		if g, w := jsonString(t, got), jsonString(t, r); g != w {
+			t.Errorf("round trip of %s:\n got %s\nwant %s", r.Identity, g, w)
+		}
+		if got.Bivariate != r.Bivariate || got.ULPN != r.ULPN {
+			t.Errorf("round trip of %s: bivariate %v, %d errors in ULPs, want %v, %d",
+				r.Identity, got.Bivariate, got.ULPN, r.Bivariate, r.ULPN)
+		}
+	}
+
+	// The histograms are encoded but not decoded.
+	acc := NewAccumulator(machar.Float64(), 0, 1)
+	acc.KeepHistograms()
+	acc.AddError(0.5, 0x1p-52)
+	acc.AddULP(0.5, 0.5)
+	r := acc.Result()
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Result
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ErrorHist != nil || got.ULPHist != nil {
+		t.Errorf("decoded histograms %v and %v, want none", got.ErrorHist, got.ULPHist)
+	}
+	r.ErrorHist, r.ULPHist = nil, nil
+	if !reflect.DeepEqual(got, r) {
+		t.Errorf("round trip: got %+v, want %+v", got, r)
+	}
+}
+
+func jsonString(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
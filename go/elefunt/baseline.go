@@ -0,0 +1,125 @@
+package elefunt
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Tolerance bounds how much a run may worsen against its baseline before
+// the change is reported as a regression.
+type Tolerance struct {
+	Loss float64 // Digits of loss the maximum and RMS errors may gain
+	ULP  float64 // ULPs the maximum and mean errors against the reference may gain
+}
+
+// Regression describes a result of a run that is worse than, or differs
+// from, the same result in its baseline.
+type Regression struct {
+	Function  string `json:"function"`
+	Precision string `json:"precision,omitempty"`
+	Test      int    `json:"test,omitempty"` // 1-based index of the random argument test, or 0 for a special test
+	Subject   string `json:"subject"`        // Identity or expression that changed
+	Message   string `json:"message"`
+}
+
+func (r Regression) String() string {
+	if r.Test != 0 {
+		return fmt.Sprintf("%s %s test %d (%s): %s", r.Function, r.Precision, r.Test, r.Subject, r.Message)
+	}
+	return fmt.Sprintf("%s %s %s: %s", r.Function, r.Precision, r.Subject, r.Message)
+}
+
+// bits formats v with enough digits to tell any two float64 values apart.
+func bits(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// same reports whether a and b are the same float64, bit for bit. All NaNs
+// are the same, since their payloads do not survive the JSON encoding.
+func same(a, b float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	return math.Float64bits(a) == math.Float64bits(b)
+}
+
+// Diff compares the report cur of a run with the report base of the same
+// test program in an earlier run. It returns the random argument tests
+// whose digit loss or error in ULPs grew by more than tol allows, and the
+// identity checks, special arguments and error returns whose values changed
+// in any bit. Tests that cannot be compared, because the identity, interval
+// or number of arguments differ, are reported too.
+func Diff(base, cur *Report, tol Tolerance) []Regression {
+	var rs []Regression
+	add := func(test int, subject, format string, args ...any) {
+		rs = append(rs, Regression{cur.Function, cur.Precision, test, subject, fmt.Sprintf(format, args...)})
+	}
+
+	if len(base.Tests) != len(cur.Tests) {
+		add(0, "RANDOM ARGUMENT TESTS", "%d tests, baseline has %d", len(cur.Tests), len(base.Tests))
+	}
+	for i := 0; i < len(base.Tests) && i < len(cur.Tests); i++ {
+		b, c := base.Tests[i], cur.Tests[i]
+		switch {
+		case b.Identity != c.Identity:
+			add(i+1, c.Identity, "baseline tests %s", b.Identity)
+			continue
+		case b.A != c.A || b.B != c.B || b.N != c.N:
+			add(i+1, c.Identity, "%d arguments from (%s, %s), baseline has %d from (%s, %s)",
+				c.N, bits(c.A), bits(c.B), b.N, bits(b.A), bits(b.B))
+			continue
+		}
+		if c.MaxErrorLoss() > b.MaxErrorLoss()+tol.Loss {
+			add(i+1, c.Identity, "maximum error loses %.2f digits, baseline %.2f", c.MaxErrorLoss(), b.MaxErrorLoss())
+		}
+		if c.RMSErrorLoss() > b.RMSErrorLoss()+tol.Loss {
+			add(i+1, c.Identity, "RMS error loses %.2f digits, baseline %.2f", c.RMSErrorLoss(), b.RMSErrorLoss())
+		}
+		if b.ULPN > 0 && c.ULPN > 0 {
+			if c.MaxULP > b.MaxULP+tol.ULP {
+				add(i+1, c.Identity, "maximum error is %.4f ULPs, baseline %.4f", c.MaxULP, b.MaxULP)
+			}
+			if c.MeanULP > b.MeanULP+tol.ULP {
+				add(i+1, c.Identity, "mean error is %.4f ULPs, baseline %.4f", c.MeanULP, b.MeanULP)
+			}
+		}
+	}
+
+	if len(base.Checks) != len(cur.Checks) {
+		add(0, "IDENTITY CHECKS", "%d checks, baseline has %d", len(cur.Checks), len(base.Checks))
+	}
+	for i := 0; i < len(base.Checks) && i < len(cur.Checks); i++ {
+		b, c := base.Checks[i], cur.Checks[i]
+		if b.Identity != c.Identity || len(b.Samples) != len(c.Samples) {
+			add(0, c.Identity, "baseline checks %s with %d samples", b.Identity, len(b.Samples))
+			continue
+		}
+		for j, s := range c.Samples {
+			bs := b.Samples[j]
+			if !same(float64(s.X), float64(bs.X)) || !same(float64(s.Value), float64(bs.Value)) {
+				add(0, c.Identity, "sample %d is %s at X = %s, baseline %s at X = %s",
+					j+1, bits(float64(s.Value)), bits(float64(s.X)), bits(float64(bs.Value)), bits(float64(bs.X)))
+			}
+		}
+	}
+
+	specials := func(kind string, base, cur []Special) {
+		if len(base) != len(cur) {
+			add(0, kind, "%d tests, baseline has %d", len(cur), len(base))
+		}
+		for i := 0; i < len(base) && i < len(cur); i++ {
+			b, c := base[i], cur[i]
+			if b.Expr != c.Expr {
+				add(0, c.Expr, "baseline evaluates %s", b.Expr)
+				continue
+			}
+			if !same(float64(c.Value), float64(b.Value)) {
+				add(0, c.Expr, "changed from %s to %s", bits(float64(b.Value)), bits(float64(c.Value)))
+			}
+		}
+	}
+	specials("SPECIAL ARGUMENTS", base.Specials, cur.Specials)
+	specials("ERROR RETURNS", base.Errors, cur.Errors)
+	return rs
+}
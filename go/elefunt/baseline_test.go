@@ -0,0 +1,109 @@
+package elefunt
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// baseReport returns a report of a run to compare changed copies against.
+func baseReport() *Report {
+	r := NewReport("exp", "", "")
+	r.Precision = PrecisionDouble
+	r.AddResult(Result{Identity: "EXP(X-V) VS EXP(X)/EXP(V)", A: -1, B: 1, N: 2000, IBeta: 2, IT: 53,
+		MaxError: 0x1p-51, RMSError: 0x1p-53, ULPN: 2000, MaxULP: 0.9, MeanULP: 0.3})
+	r.AddResult(Result{Identity: "EXP(X-V) VS EXP(X)/EXP(V)", A: 1, B: 2, N: 2000, IBeta: 2, IT: 53,
+		MaxError: 0x1p-50, RMSError: 0x1p-52})
+	r.AddCheck("EXP(X)*EXP(-X) = 1.0", 1.5, 0)
+	r.AddCheck("EXP(X)*EXP(-X) = 1.0", 1.75, -0x1p-52)
+	r.AddSpecial("EXP(0.0) - 1.0", 0)
+	r.AddSpecial("EXP(XMIN)", 1, 0x1p-1022)
+	r.AddError("LOG(-1)", "NaN", math.NaN(), -1)
+	r.AddError("EXP(X)", "OVERFLOW", math.Inf(1), 1000)
+	return r
+}
+
+func TestDiff(t *testing.T) {
+	// A NaN with another payload
+	otherNaN := math.Float64frombits(math.Float64bits(math.NaN()) ^ 1)
+
+	tests := []struct {
+		desc   string
+		tol    Tolerance
+		change func(r *Report)
+		want   []string // Subjects of the regressions, in order
+	}{
+		{"unchanged", Tolerance{}, func(r *Report) {}, nil},
+
+		// Digit loss: doubling the error loses one more base 2 digit
+		{"max loss within tolerance", Tolerance{Loss: 1.5}, func(r *Report) { r.Tests[0].MaxError *= 2 }, nil},
+		{"max loss beyond tolerance", Tolerance{Loss: 0.5}, func(r *Report) { r.Tests[0].MaxError *= 2 },
+			[]string{"EXP(X-V) VS EXP(X)/EXP(V)"}},
+		{"rms loss beyond tolerance", Tolerance{Loss: 0.5}, func(r *Report) { r.Tests[1].RMSError *= 4 },
+			[]string{"EXP(X-V) VS EXP(X)/EXP(V)"}},
+		{"both losses", Tolerance{}, func(r *Report) { r.Tests[0].MaxError *= 2; r.Tests[0].RMSError *= 2 },
+			[]string{"EXP(X-V) VS EXP(X)/EXP(V)", "EXP(X-V) VS EXP(X)/EXP(V)"}},
+		{"improvement", Tolerance{}, func(r *Report) { r.Tests[0].MaxError /= 4; r.Tests[0].MaxULP = 0.5 }, nil},
+		{"no loss below zero", Tolerance{}, func(r *Report) { r.Tests[0].RMSError = 0x1p-60 }, nil},
+
+		// Errors in ULPs, compared only when both runs measured them
+		{"ulps within tolerance", Tolerance{ULP: 0.25}, func(r *Report) { r.Tests[0].MaxULP += 0.2 }, nil},
+		{"ulps beyond tolerance", Tolerance{ULP: 0.1}, func(r *Report) { r.Tests[0].MaxULP += 0.2; r.Tests[0].MeanULP += 0.2 },
+			[]string{"EXP(X-V) VS EXP(X)/EXP(V)", "EXP(X-V) VS EXP(X)/EXP(V)"}},
+		{"ulps not measured", Tolerance{}, func(r *Report) { r.Tests[1].MaxULP = 5; r.Tests[1].ULPN = 2000 }, nil},
+
+		// Tests that cannot be compared
+		{"identity", Tolerance{}, func(r *Report) { r.Tests[1].Identity = "EXP(X) VS EXP(X/2)**2" },
+			[]string{"EXP(X) VS EXP(X/2)**2"}},
+		{"interval", Tolerance{}, func(r *Report) { r.Tests[1].B = 3 }, []string{"EXP(X-V) VS EXP(X)/EXP(V)"}},
+		{"arguments", Tolerance{}, func(r *Report) { r.Tests[1].N = 1000 }, []string{"EXP(X-V) VS EXP(X)/EXP(V)"}},
+		{"test added", Tolerance{}, func(r *Report) { r.AddResult(r.Tests[1]) }, []string{"RANDOM ARGUMENT TESTS"}},
+		{"test removed", Tolerance{}, func(r *Report) { r.Tests = r.Tests[:1] }, []string{"RANDOM ARGUMENT TESTS"}},
+
+		// Special tests are compared bit for bit
+		{"check value", Tolerance{Loss: 10, ULP: 10}, func(r *Report) { r.Checks[0].Samples[1].Value = -0x1p-53 },
+			[]string{"EXP(X)*EXP(-X) = 1.0"}},
+		{"check argument", Tolerance{}, func(r *Report) { r.Checks[0].Samples[0].X = Float(math.Nextafter(1.5, 2)) },
+			[]string{"EXP(X)*EXP(-X) = 1.0"}},
+		{"check removed", Tolerance{}, func(r *Report) { r.Checks = nil }, []string{"IDENTITY CHECKS"}},
+		{"check sample removed", Tolerance{}, func(r *Report) { r.Checks[0].Samples = r.Checks[0].Samples[:1] },
+			[]string{"EXP(X)*EXP(-X) = 1.0"}},
+		{"negative zero", Tolerance{}, func(r *Report) { r.Specials[0].Value = Float(math.Copysign(0, -1)) },
+			[]string{"EXP(0.0) - 1.0"}},
+		{"one ulp", Tolerance{Loss: 10, ULP: 10}, func(r *Report) { r.Specials[1].Value = Float(math.Nextafter(1, 2)) },
+			[]string{"EXP(XMIN)"}},
+		{"NaN payload", Tolerance{}, func(r *Report) { r.Errors[0].Value = Float(otherNaN) }, nil},
+		{"NaN to zero", Tolerance{}, func(r *Report) { r.Errors[0].Value = 0 }, []string{"LOG(-1)"}},
+		{"infinity to finite", Tolerance{}, func(r *Report) { r.Errors[1].Value = math.MaxFloat64 }, []string{"EXP(X)"}},
+		{"expression", Tolerance{}, func(r *Report) { r.Specials[1].Expr = "EXP(XMAX)" }, []string{"EXP(XMAX)"}},
+		{"special added", Tolerance{}, func(r *Report) { r.AddSpecial("EXP(1.0)", math.E) }, []string{"SPECIAL ARGUMENTS"}},
+		{"error removed", Tolerance{}, func(r *Report) { r.Errors = r.Errors[:1] }, []string{"ERROR RETURNS"}},
+	}
+	for _, tt := range tests {
+		cur := baseReport()
+		tt.change(cur)
+		rs := Diff(baseReport(), cur, tt.tol)
+		var got []string
+		for _, r := range rs {
+			got = append(got, r.Subject)
+			if r.Function != "exp" || r.Precision != PrecisionDouble || r.Message == "" {
+				t.Errorf("%s: regression %+v", tt.desc, r)
+			}
+		}
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("%s: regressions\n%v\nwant subjects %q", tt.desc, rs, tt.want)
+		}
+	}
+}
+
+func TestDiffTestNumbers(t *testing.T) {
+	cur := baseReport()
+	cur.Tests[1].MaxError *= 4
+	rs := Diff(baseReport(), cur, Tolerance{})
+	if len(rs) != 1 || rs[0].Test != 2 || !strings.Contains(rs[0].Message, "maximum error loses 5.00 digits, baseline 3.00") {
+		t.Errorf("regressions %v, want test 2 losing 5 digits", rs)
+	}
+	if got, want := rs[0].String(), "exp double test 2 (EXP(X-V) VS EXP(X)/EXP(V)): maximum error loses 5.00 digits, baseline 3.00"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
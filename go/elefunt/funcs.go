@@ -0,0 +1,120 @@
+package elefunt
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Func1 is a function of one argument under test.
+type Func1[T Real] func(T) T
+
+// Func2 is a function of two arguments under test.
+type Func2[T Real] func(T, T) T
+
+// Funcs is a set of functions under test keyed by name, such as "exp" or "pow".
+// The tests look up every function they call, so registering a function
+// replaces the implementation that is tested.
+type Funcs[T Real] struct {
+	f1 map[string]Func1[T]
+	f2 map[string]Func2[T]
+}
+
+// NewFuncs returns an empty function set.
+func NewFuncs[T Real]() *Funcs[T] {
+	return &Funcs[T]{
+		f1: make(map[string]Func1[T]),
+		f2: make(map[string]Func2[T]),
+	}
+}
+
+// MathFuncs returns a function set holding the math package functions rounded to T.
+func MathFuncs[T Real]() *Funcs[T] {
+	fs := NewFuncs[T]()
+	fs.Register1("exp", Round1[T](math.Exp))
+	fs.Register1("log", Round1[T](math.Log))
+	fs.Register1("log10", Round1[T](math.Log10))
+	fs.Register1("expm1", Round1[T](math.Expm1))
+	fs.Register1("log1p", Round1[T](math.Log1p))
+	fs.Register1("sqrt", Round1[T](math.Sqrt))
+	fs.Register1("cbrt", Round1[T](math.Cbrt))
+	fs.Register1("sin", Round1[T](math.Sin))
+	fs.Register1("cos", Round1[T](math.Cos))
+	fs.Register1("tan", Round1[T](math.Tan))
+	fs.Register1("asin", Round1[T](math.Asin))
+	fs.Register1("acos", Round1[T](math.Acos))
+	fs.Register1("atan", Round1[T](math.Atan))
+	fs.Register1("sinh", Round1[T](math.Sinh))
+	fs.Register1("cosh", Round1[T](math.Cosh))
+	fs.Register1("tanh", Round1[T](math.Tanh))
+	fs.Register1("asinh", Round1[T](math.Asinh))
+	fs.Register1("acosh", Round1[T](math.Acosh))
+	fs.Register1("atanh", Round1[T](math.Atanh))
+	fs.Register1("gamma", Round1[T](math.Gamma))
+	fs.Register1("lgamma", Round1[T](lgamma))
+	fs.Register1("signgam", Round1[T](signgam))
+	fs.Register1("erf", Round1[T](math.Erf))
+	fs.Register1("erfc", Round1[T](math.Erfc))
+	fs.Register1("erfinv", Round1[T](math.Erfinv))
+	fs.Register1("erfcinv", Round1[T](math.Erfcinv))
+	fs.Register2("atan2", Round2[T](math.Atan2))
+	fs.Register2("pow", Round2[T](math.Pow))
+	fs.Register2("hypot", Round2[T](math.Hypot))
+	return fs
+}
+
+// lgamma returns log|Gamma(x)|, the first result of math.Lgamma.
+func lgamma(x float64) float64 {
+	l, _ := math.Lgamma(x)
+	return l
+}
+
+// signgam returns the sign of Gamma(x) as 1 or -1, the second result of
+// math.Lgamma, named after the variable that C's lgamma sets.
+func signgam(x float64) float64 {
+	_, s := math.Lgamma(x)
+	return float64(s)
+}
+
+// Register1 sets the function of one argument named name.
+func (fs *Funcs[T]) Register1(name string, f Func1[T]) {
+	fs.f1[name] = f
+}
+
+// Register2 sets the function of two arguments named name.
+func (fs *Funcs[T]) Register2(name string, f Func2[T]) {
+	fs.f2[name] = f
+}
+
+// Func1 returns the function of one argument named name.
+// It panics if no such function is registered.
+func (fs *Funcs[T]) Func1(name string) Func1[T] {
+	f, ok := fs.f1[name]
+	if !ok {
+		panic(fmt.Sprintf("elefunt: no function of one argument named %q", name))
+	}
+	return f
+}
+
+// Func2 returns the function of two arguments named name.
+// It panics if no such function is registered.
+func (fs *Funcs[T]) Func2(name string) Func2[T] {
+	f, ok := fs.f2[name]
+	if !ok {
+		panic(fmt.Sprintf("elefunt: no function of two arguments named %q", name))
+	}
+	return f
+}
+
+// Names returns the sorted names of all registered functions.
+func (fs *Funcs[T]) Names() []string {
+	names := make([]string, 0, len(fs.f1)+len(fs.f2))
+	for name := range fs.f1 {
+		names = append(names, name)
+	}
+	for name := range fs.f2 {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,230 @@
+package elefunt
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// binsPerOctave is the number of bins of a Histogram between successive
+// powers of two.
+const binsPerOctave = 16
+
+// Percentiles reported with a histogram.
+var percentiles = []struct {
+	name string
+	q    float64
+}{{"P50", 0.50}, {"P90", 0.90}, {"P99", 0.99}, {"P99.9", 0.999}}
+
+// Histogram counts the magnitudes of errors in logarithmic bins, 16 to an
+// octave. Percentiles are estimated from the bins to within about 4%, from
+// a fixed amount of memory whatever the number of errors, and the
+// histograms of the shards of a test merge exactly.
+type Histogram struct {
+	n    int
+	zero int         // Number of zero errors
+	inf  int         // Number of infinite or NaN errors
+	max  float64     // Largest finite error
+	bins map[int]int // bins[k] counts the errors in (2**(k/16), 2**((k+1)/16)]
+}
+
+// NewHistogram returns an empty Histogram.
+func NewHistogram() *Histogram {
+	return &Histogram{bins: make(map[int]int)}
+}
+
+// Add counts the error e.
+func (h *Histogram) Add(e float64) {
+	h.n++
+	e = math.Abs(e)
+	switch {
+	case e == 0:
+		h.zero++
+	case math.IsInf(e, 0) || math.IsNaN(e):
+		h.inf++
+	default:
+		h.bins[int(math.Ceil(math.Log2(e)*binsPerOctave))-1]++
+		h.max = math.Max(h.max, e)
+	}
+}
+
+// Merge adds the errors counted in o to h.
+func (h *Histogram) Merge(o *Histogram) {
+	h.n += o.n
+	h.zero += o.zero
+	h.inf += o.inf
+	h.max = math.Max(h.max, o.max)
+	for k, c := range o.bins {
+		h.bins[k] += c
+	}
+}
+
+// N returns the number of errors counted.
+func (h *Histogram) N() int {
+	return h.n
+}
+
+// upper returns the upper bound of bin k.
+func upper(k int) float64 {
+	return math.Exp2(float64(k+1) / binsPerOctave)
+}
+
+func (h *Histogram) keys() []int {
+	ks := make([]int, 0, len(h.bins))
+	for k := range h.bins {
+		ks = append(ks, k)
+	}
+	sort.Ints(ks)
+	return ks
+}
+
+// Percentile returns an upper bound, within one bin, of the error below
+// which the fraction q of the errors lie. It is never more than the
+// largest error.
+func (h *Histogram) Percentile(q float64) float64 {
+	t := int(math.Ceil(q * float64(h.n)))
+	if h.n == 0 || t <= h.zero {
+		return 0
+	}
+	c := h.zero
+	for _, k := range h.keys() {
+		if c += h.bins[k]; c >= t {
+			return math.Min(upper(k), h.max)
+		}
+	}
+	return math.Inf(1)
+}
+
+// group is a row of a printed histogram: the errors in (2**(e-1)*s, 2**e*s]
+// for a scale s.
+type group struct {
+	e     int
+	count int
+}
+
+// groups gathers the bins into rows of a whole number of octaves each:
+// bin k goes to row ceil(log2(upper(k))/octaves), but no lower than min.
+func (h *Histogram) groups(octaves float64, min int) []group {
+	var gs []group
+	for _, k := range h.keys() {
+		e := int(math.Ceil(float64(k+1)/binsPerOctave/octaves - 1e-9))
+		if e < min {
+			e = min
+		}
+		if len(gs) > 0 && gs[len(gs)-1].e == e {
+			gs[len(gs)-1].count += h.bins[k]
+			continue
+		}
+		gs = append(gs, group{e, h.bins[k]})
+	}
+	return gs
+}
+
+func (h *Histogram) percent(c int) float64 {
+	return 100 * float64(c) / float64(h.n)
+}
+
+// writeErrors prints h, a histogram of relative errors, in rows of powers
+// of ibeta.
+func (h *Histogram) writeErrors(w io.Writer, ibeta int) {
+	exp := func(v float64) string {
+		switch {
+		case v == 0:
+			return "0"
+		case math.IsInf(v, 0):
+			return "INF"
+		}
+		return fmt.Sprintf("%d ** %.2f", ibeta, math.Log(v)/math.Log(float64(ibeta)))
+	}
+	fmt.Fprintln(w, " THE RELATIVE ERRORS WERE DISTRIBUTED AS FOLLOWS")
+	fmt.Fprintln(w, "        AT MOST      COUNT  PERCENT")
+	fmt.Fprintf(w, "           ZERO %10d %8.2f\n", h.zero, h.percent(h.zero))
+	for _, g := range h.groups(math.Log2(float64(ibeta)), math.MinInt) {
+		fmt.Fprintf(w, "    %4d ** %4d %10d %8.2f\n", ibeta, g.e, g.count, h.percent(g.count))
+	}
+	if h.inf > 0 {
+		fmt.Fprintf(w, "       INFINITE %10d %8.2f\n", h.inf, h.percent(h.inf))
+	}
+	h.writePercentiles(w, exp)
+}
+
+// writeULPs prints h, a histogram of errors in ULPs, in rows of powers of
+// two from 0.5 ULPs up.
+func (h *Histogram) writeULPs(w io.Writer) {
+	ulps := func(v float64) string {
+		if math.IsInf(v, 0) {
+			return "INF"
+		}
+		return fmt.Sprintf("%.4f", v)
+	}
+	fmt.Fprintln(w, " THE ERRORS AGAINST THE REFERENCE WERE DISTRIBUTED AS FOLLOWS")
+	fmt.Fprintln(w, "   AT MOST ULPS      COUNT  PERCENT")
+	fmt.Fprintf(w, "              0 %10d %8.2f\n", h.zero, h.percent(h.zero))
+	for _, g := range h.groups(1, -1) {
+		fmt.Fprintf(w, " %14g %10d %8.2f\n", math.Exp2(float64(g.e)), g.count, h.percent(g.count))
+	}
+	if h.inf > 0 {
+		fmt.Fprintf(w, "       INFINITE %10d %8.2f\n", h.inf, h.percent(h.inf))
+	}
+	h.writePercentiles(w, ulps)
+}
+
+func (h *Histogram) writePercentiles(w io.Writer, format func(float64) string) {
+	fmt.Fprint(w, " PERCENTILES")
+	for i, p := range percentiles {
+		sep := ","
+		if i == 0 {
+			sep = ""
+		}
+		fmt.Fprintf(w, "%s %s = %s", sep, p.name, format(h.Percentile(p.q)))
+	}
+	fmt.Fprint(w, "\n\n")
+}
+
+type histogramJSON struct {
+	N           int              `json:"n"`
+	Zero        int              `json:"zero"`
+	Infinite    int              `json:"infinite,omitempty"`
+	Bins        []binJSON        `json:"bins"`
+	Percentiles []percentileJSON `json:"percentiles"`
+}
+
+type binJSON struct {
+	Exponent *int  `json:"exponent,omitempty"` // Power of IBeta bounding a relative error
+	Max      Float `json:"max"`
+	Count    int   `json:"count"`
+}
+
+type percentileJSON struct {
+	Q     Float `json:"q"`
+	Value Float `json:"value"`
+}
+
+// errorsJSON returns the encoding of h, a histogram of relative errors in
+// rows of powers of ibeta.
+func (h *Histogram) errorsJSON(ibeta int) *histogramJSON {
+	v := h.json()
+	for _, g := range h.groups(math.Log2(float64(ibeta)), math.MinInt) {
+		e := g.e
+		v.Bins = append(v.Bins, binJSON{&e, Float(math.Pow(float64(ibeta), float64(e))), g.count})
+	}
+	return v
+}
+
+// ulpsJSON returns the encoding of h, a histogram of errors in ULPs.
+func (h *Histogram) ulpsJSON() *histogramJSON {
+	v := h.json()
+	for _, g := range h.groups(1, -1) {
+		v.Bins = append(v.Bins, binJSON{nil, Float(math.Exp2(float64(g.e))), g.count})
+	}
+	return v
+}
+
+func (h *Histogram) json() *histogramJSON {
+	v := &histogramJSON{N: h.n, Zero: h.zero, Infinite: h.inf, Bins: []binJSON{}}
+	for _, p := range percentiles {
+		v.Percentiles = append(v.Percentiles, percentileJSON{Float(p.q), Float(h.Percentile(p.q))})
+	}
+	return v
+}
@@ -0,0 +1,205 @@
+package elefunt
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func histogram(es ...float64) *Histogram {
+	h := NewHistogram()
+	for _, e := range es {
+		h.Add(e)
+	}
+	return h
+}
+
+func TestHistogramBins(t *testing.T) {
+	tests := []struct {
+		e float64
+		k int
+	}{
+		{1, -1}, // Bins are closed above
+		{math.Nextafter(1, 2), 0},
+		{math.Nextafter(1, 0), -1},
+		{2, 15},
+		{-2, 15},
+		{0.5, -17},
+		{3, 25},
+		{0x1p-52, -833},
+	}
+	for _, tt := range tests {
+		h := histogram(tt.e)
+		if want := map[int]int{tt.k: 1}; !reflect.DeepEqual(h.bins, want) {
+			t.Errorf("Add(%g): bins %v, want %v", tt.e, h.bins, want)
+		}
+	}
+
+	// Every error lies in (upper(k-1), upper(k)] of its bin k.
+	for e := 0x1p-60; e < 0x1p20; e *= 1.001 {
+		for k := range histogram(e).bins {
+			if e <= upper(k-1)*(1-1e-15) || e > upper(k)*(1+1e-15) {
+				t.Fatalf("Add(%g): bin %d, bounds (%g, %g]", e, k, upper(k-1), upper(k))
+			}
+		}
+	}
+}
+
+func TestHistogramSpecial(t *testing.T) {
+	h := histogram(0, math.Copysign(0, -1), math.Inf(1), math.Inf(-1), math.NaN())
+	if h.N() != 5 || h.zero != 2 || h.inf != 3 || len(h.bins) != 0 || h.max != 0 {
+		t.Errorf("n %d, zero %d, inf %d, bins %v, max %g; want 5, 2, 3, none, 0",
+			h.N(), h.zero, h.inf, h.bins, h.max)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		h    *Histogram
+		q    float64
+		want float64
+	}{
+		{histogram(), 0.5, 0},
+		{histogram(0, 0, 1, 2, 2, 3), 0, 0},
+		{histogram(0, 0, 1, 2, 2, 3), 0.3, 0},
+		{histogram(0, 0, 1, 2, 2, 3), 0.5, 1},
+		{histogram(0, 0, 1, 2, 2, 3), 0.6, 2},
+		{histogram(0, 0, 1, 2, 2, 3), 0.8, 2},
+		// The bin of 3 reaches 2**(26/16), above the largest error
+		{histogram(0, 0, 1, 2, 2, 3), 0.9, 3},
+		{histogram(0, 0, 1, 2, 2, 3), 1, 3},
+		{histogram(1, math.Inf(1)), 0.5, 1},
+		{histogram(1, math.Inf(1)), 1, math.Inf(1)},
+		{histogram(1, math.NaN()), 0.99, math.Inf(1)},
+	}
+	for _, tt := range tests {
+		if got := tt.h.Percentile(tt.q); got != tt.want {
+			t.Errorf("Percentile(%g) of %d errors = %g, want %g", tt.q, tt.h.N(), got, tt.want)
+		}
+	}
+
+	// Percentiles are bounds within one bin of the exact ones.
+	var es []float64
+	for i := 0; i < 1000; i++ {
+		es = append(es, math.Pow(1.013, float64(i*7%1000)))
+	}
+	h := histogram(es...)
+	sort.Float64s(es)
+	for _, q := range []float64{0.001, 0.1, 0.5, 0.9, 0.99, 0.999, 1} {
+		exact := es[int(math.Ceil(q*float64(len(es))))-1]
+		if got := h.Percentile(q); got < exact || got > exact*math.Exp2(1.0/binsPerOctave) || got > es[len(es)-1] {
+			t.Errorf("Percentile(%g) = %g, want %g to %g", q, got, exact, exact*math.Exp2(1.0/binsPerOctave))
+		}
+	}
+}
+
+func TestHistogramGroups(t *testing.T) {
+	tests := []struct {
+		h       *Histogram
+		octaves float64
+		min     int
+		want    []group
+	}{
+		{histogram(), 1, -1, nil},
+		{histogram(0.1, 0.5, 0.6, 1, 1.5, 2, 3), 1, -1, []group{{-1, 2}, {0, 2}, {1, 2}, {2, 1}}},
+		// Powers of two fall in the row they bound
+		{histogram(0x1p-53, 0x1p-52, 0x1p-52, 0x1p-50), 1, math.MinInt, []group{{-53, 1}, {-52, 2}, {-50, 1}}},
+		{histogram(0x1p-52, 0x1p-50, 0x1p-40), math.Log2(10), math.MinInt, []group{{-15, 2}, {-12, 1}}},
+		{histogram(0x1p-52, 0x1p-50, 0x1p-40), math.Log2(10), -13, []group{{-13, 2}, {-12, 1}}},
+	}
+	for _, tt := range tests {
+		if got := tt.h.groups(tt.octaves, tt.min); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("groups(%.4g, %d) of %d errors = %v, want %v", tt.octaves, tt.min, tt.h.N(), got, tt.want)
+		}
+	}
+}
+
+func TestHistogramMerge(t *testing.T) {
+	var es []float64
+	for i := 0; i < 1000; i++ {
+		e := math.Pow(1.02, float64(i%500-250))
+		switch i % 97 {
+		case 0:
+			e = 0
+		case 1:
+			e = math.Inf(1)
+		}
+		es = append(es, e)
+	}
+	want := histogram(es...)
+
+	// Shards of any sizes, merged in any order, give the histogram of a
+	// single run.
+	for _, shards := range []int{1, 2, 7, 1000} {
+		hs := make([]*Histogram, shards)
+		for i := range hs {
+			hs[i] = NewHistogram()
+		}
+		for i, e := range es {
+			hs[i*shards/len(es)].Add(e)
+		}
+		for _, forward := range []bool{true, false} {
+			got := NewHistogram()
+			for i := range hs {
+				if !forward {
+					i = len(hs) - 1 - i
+				}
+				got.Merge(hs[i])
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%d shards merged, forward %v: %+v, want %+v", shards, forward, got, want)
+			}
+		}
+	}
+}
+
+func TestWriteULPs(t *testing.T) {
+	var b bytes.Buffer
+	histogram(0, 0.5, 1, 1, 3, math.Inf(1)).writeULPs(&b)
+	want := ` THE ERRORS AGAINST THE REFERENCE WERE DISTRIBUTED AS FOLLOWS
+   AT MOST ULPS      COUNT  PERCENT
+              0          1    16.67
+            0.5          1    16.67
+              1          2    33.33
+              4          1    16.67
+       INFINITE          1    16.67
+ PERCENTILES P50 = 1.0000, P90 = INF, P99 = INF, P99.9 = INF
+
+`
+	if got := b.String(); got != want {
+		t.Errorf("writeULPs printed\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteErrors(t *testing.T) {
+	var b bytes.Buffer
+	histogram(0, 0x1p-53, 0x1p-52, 0x1p-52, 0x1p-50).writeErrors(&b, 2)
+	want := ` THE RELATIVE ERRORS WERE DISTRIBUTED AS FOLLOWS
+        AT MOST      COUNT  PERCENT
+           ZERO          1    20.00
+       2 **  -53          1    20.00
+       2 **  -52          2    40.00
+       2 **  -50          1    20.00
+ PERCENTILES P50 = 2 ** -52.00, P90 = 2 ** -50.00, P99 = 2 ** -50.00, P99.9 = 2 ** -50.00
+
+`
+	if got := b.String(); got != want {
+		t.Errorf("writeErrors printed\n%s\nwant\n%s", got, want)
+	}
+
+	b.Reset()
+	histogram(0x1p-40, math.NaN()).writeErrors(&b, 16)
+	want = ` THE RELATIVE ERRORS WERE DISTRIBUTED AS FOLLOWS
+        AT MOST      COUNT  PERCENT
+           ZERO          0     0.00
+      16 **  -10          1    50.00
+       INFINITE          1    50.00
+ PERCENTILES P50 = 16 ** -10.00, P90 = INF, P99 = INF, P99.9 = INF
+
+`
+	if got := b.String(); got != want {
+		t.Errorf("writeErrors printed\n%s\nwant\n%s", got, want)
+	}
+}
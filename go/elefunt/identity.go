@@ -0,0 +1,109 @@
+package elefunt
+
+import "fmt"
+
+// Identity is the relation a random argument test checks between the value
+// of a function at an argument and other values of the same function.
+// Eval is called with the function under test and an argument drawn from
+// the interval. It returns the argument x after any purification, the
+// argument at which the function value z was taken, and the value zz the
+// identity gives for z. The relative error is (z-zz)/z, as Accumulator.Add
+// computes it.
+type Identity[T Real] struct {
+	Name string
+	Eval func(f func(T) T, x T) (arg, at, z, zz T)
+}
+
+// ExpShift is the identity EXP(X-1/16) = EXP(X)*EXP(-1/16) of exp.f, which
+// it checks over (-0.2841, 0.3466) for binary radix.
+func ExpShift[T Real]() Identity[T] {
+	return expShift(T(0.0625), func(z T) T { return z - z*6.058693718652421388e-2 })
+}
+
+// ExpShiftLarge is the identity EXP(X-45/16) = EXP(X)*EXP(-45/16) of exp.f
+// for radix ibeta, which it checks over larger intervals.
+func ExpShiftLarge[T Real](ibeta int) Identity[T] {
+	if ibeta == 10 {
+		return expShift(T(45.0/16.0), func(z T) T { return z*6.0e-2 + z*5.466789530794296106e-5 })
+	}
+	return expShift(T(45.0/16.0), func(z T) T { return z*0.0625 - z*2.4453321046920570389e-3 })
+}
+
+// expShift returns the identity EXP(X-V) = EXP(X)*EXP(-V), with scale
+// multiplying by EXP(-V).
+func expShift[T Real](v T, scale func(T) T) Identity[T] {
+	return Identity[T]{
+		Name: fmt.Sprintf("EXP(X-%.4f) VS EXP(X)/EXP(%.4f)", v, v),
+		Eval: func(f func(T) T, x T) (T, T, T, T) {
+			// Purify arguments so that X-V is exact
+			y := x - v
+			if y < 0 {
+				x = y + v
+			}
+			return x, y, f(y), scale(f(x))
+		},
+	}
+}
+
+// LogScale is the identity LOG(X) = LOG(Y) + LOG((D+1)/D) for X = (D+1)Y/D
+// of alog.f, which checks it with D = 16 and 10 over (1/sqrt(2), sqrt(2)).
+func LogScale[T Real](d int) Identity[T] {
+	half := T(0.5)
+	dd := T(d)
+	return Identity[T]{
+		Name: fmt.Sprintf("LOG(X) VS LOG(%dX/%d) - LOG(%d/%d)", d+1, d, d+1, d),
+		Eval: func(f func(T) T, x T) (T, T, T, T) {
+			// Purify arguments so that X = Y + Y/D is exact
+			y := x - half
+			y = (y + half) - half
+			x = y + y/dd
+			return x, x, f(x), f(y) + f(T(d+1)/dd)
+		},
+	}
+}
+
+// LogSquare is the identity LOG(X*X) = 2*LOG(X) of alog.f, which checks it
+// over (1/sqrt(2), 15/16).
+func LogSquare[T Real]() Identity[T] {
+	return Identity[T]{
+		Name: "LOG(X*X) VS 2*LOG(X)",
+		Eval: func(f func(T) T, x T) (T, T, T, T) {
+			return x, x * x, f(x * x), 2.0 * f(x)
+		},
+	}
+}
+
+// SinTriple is the identity SIN(X) = 3*SIN(X/3)-4*SIN(X/3)**3 of sin.f,
+// which checks it over (0, pi/2) and (6*pi, 6.5*pi).
+func SinTriple[T Real]() Identity[T] {
+	three := T(3.0)
+	return Identity[T]{
+		Name: "SIN(X) VS 3*SIN(X/3)-4*SIN(X/3)**3",
+		Eval: func(f func(T) T, x T) (T, T, T, T) {
+			x, y := third(x)
+			zz := f(y)
+			return x, x, f(x), zz * (three - 4.0*zz*zz)
+		},
+	}
+}
+
+// CosTriple is the identity COS(X) = 4*COS(X/3)**3-3*COS(X/3) of sin.f,
+// which checks it over (7*pi, 7.5*pi).
+func CosTriple[T Real]() Identity[T] {
+	three := T(3.0)
+	return Identity[T]{
+		Name: "COS(X) VS 4*COS(X/3)**3-3*COS(X/3)",
+		Eval: func(f func(T) T, x T) (T, T, T, T) {
+			x, y := third(x)
+			zz := f(y)
+			return x, x, f(x), -zz * (three - 4.0*zz*zz)
+		},
+	}
+}
+
+// third purifies x so that y = x/3 is exact.
+func third[T Real](x T) (T, T) {
+	y := x / 3.0
+	y = (x + y) - x
+	return 3.0 * y, y
+}
@@ -0,0 +1,67 @@
+package elefunt
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"golefunt/random"
+)
+
+func TestIdentities(t *testing.T) {
+	// The log program's purification leaves Y+Y/D inexact, so LogScale
+	// shows larger losses than the other identities.
+	same := func(x float64) float64 { return x }
+	tests := []struct {
+		id   Identity[float64]
+		f    func(float64) float64
+		a, b float64
+		arg  func(x float64) float64 // Argument recorded for a drawn argument
+		at   func(x float64) float64 // Argument of the tested value
+		loss float64                 // Largest digit loss of the math package
+	}{
+		{ExpShift[float64](), math.Exp, -0.2841, 0.3466, same, func(x float64) float64 { return x - 0.0625 }, 3},
+		{ExpShiftLarge[float64](2), math.Exp, -700, -10, same, func(x float64) float64 { return x - 45.0/16.0 }, 3},
+		{ExpShiftLarge[float64](10), math.Exp, 10, 700, same, func(x float64) float64 { return x - 45.0/16.0 }, 3},
+		{LogScale[float64](16), math.Log, 1 / math.Sqrt2, math.Sqrt2, func(x float64) float64 { return (x - 0.5) * 17 / 16 }, same, 12},
+		{LogScale[float64](10), math.Log, 1 / math.Sqrt2, math.Sqrt2, func(x float64) float64 { return (x - 0.5) * 11 / 10 }, same, 12},
+		{LogSquare[float64](), math.Log, 1 / math.Sqrt2, 15.0 / 16.0, same, func(x float64) float64 { return x * x }, 3},
+		{SinTriple[float64](), math.Sin, 0, math.Pi / 2, same, same, 3},
+		{CosTriple[float64](), math.Cos, 7 * math.Pi, 7.5 * math.Pi, same, same, 3},
+	}
+	mp := Params[float64]()
+	for _, tt := range tests {
+		rng := random.New()
+		acc := NewAccumulator(mp, tt.a, tt.b)
+		for i := 0; i < 1000; i++ {
+			drawn := tt.a + (tt.b-tt.a)*Random[float64](rng)
+			x, at, z, zz := tt.id.Eval(tt.f, drawn)
+			// Purification moves the argument by a few ULPs at most. The log
+			// program's purification also subtracts 1/2.
+			if want := tt.arg(drawn); math.Abs(x-want) > 8*math.Abs(want)*0x1p-52 {
+				t.Fatalf("%s: recorded %g for %g, want %g", tt.id.Name, x, drawn, want)
+			}
+			if want := tt.at(x); math.Abs(at-want) > math.Abs(want)*0x1p-52 {
+				t.Fatalf("%s: value at %g for %g, want %g", tt.id.Name, at, x, want)
+			}
+			if z != tt.f(at) {
+				t.Fatalf("%s: value %g at %g, want %g", tt.id.Name, z, at, tt.f(at))
+			}
+			acc.Add(x, z, zz)
+		}
+		if r := acc.Result(); r.MaxErrorLoss() > tt.loss {
+			t.Errorf("%s: the math package loses %.2f digits, want at most %g", tt.id.Name, r.MaxErrorLoss(), tt.loss)
+		}
+	}
+}
+
+func TestWriteCounts(t *testing.T) {
+	var sb strings.Builder
+	WriteCounts(&sb, "SIN(X)", 1, 22, 333)
+	want := " SIN(X) WAS LARGER      1 TIMES,\n" +
+		"           AGREED     22 TIMES, AND\n" +
+		"       WAS SMALLER    333 TIMES.\n\n"
+	if sb.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", sb.String(), want)
+	}
+}
@@ -0,0 +1,121 @@
+package elefunt
+
+import (
+	"math"
+	"math/big"
+
+	"golefunt/machar"
+	"golefunt/random"
+)
+
+// Precisions understood by the test programs.
+const (
+	PrecisionSingle = "single"
+	PrecisionDouble = "double"
+)
+
+// Real is the constraint satisfied by the floating-point types the tests run in.
+type Real interface {
+	~float32 | ~float64
+}
+
+// isSingle reports whether T is a single precision type.
+func isSingle[T Real]() bool {
+	var x T = 1 << 24
+	return x+1 == x
+}
+
+// Precision returns the name of the precision of T.
+func Precision[T Real]() string {
+	if isSingle[T]() {
+		return PrecisionSingle
+	}
+	return PrecisionDouble
+}
+
+// Params returns the static machine parameters for T.
+func Params[T Real]() machar.Params {
+	if isSingle[T]() {
+		return machar.Float32()
+	}
+	return machar.Float64()
+}
+
+// Random returns the next random number from rng in the precision of T.
+func Random[T Real](rng *random.Generator) T {
+	if isSingle[T]() {
+		return T(rng.Float32())
+	}
+	return T(rng.Float64())
+}
+
+// Round1 returns f evaluated in double precision and rounded to T.
+func Round1[T Real](f func(float64) float64) func(T) T {
+	if g, ok := any(f).(func(T) T); ok {
+		return g
+	}
+	return func(x T) T {
+		return T(f(float64(x)))
+	}
+}
+
+// Round2 returns f evaluated in double precision and rounded to T.
+func Round2[T Real](f func(float64, float64) float64) func(T, T) T {
+	if g, ok := any(f).(func(T, T) T); ok {
+		return g
+	}
+	return func(x, y T) T {
+		return T(f(float64(x), float64(y)))
+	}
+}
+
+// RoundBig returns the value of T nearest x, or NaN if x is nil, as the
+// oracle functions return for an undefined result.
+func RoundBig[T Real](x *big.Float) T {
+	if x == nil {
+		return T(math.NaN())
+	}
+	if isSingle[T]() {
+		r, _ := x.Float32()
+		return T(r)
+	}
+	r, _ := x.Float64()
+	return T(r)
+}
+
+// Ordinal maps the values of T to consecutive integers in the order of
+// their values, with -0 just below +0, so that the difference of the
+// ordinals of two values is the number of floating-point values between them.
+func Ordinal[T Real](x T) int64 {
+	if isSingle[T]() {
+		b := math.Float32bits(float32(x))
+		if b&(1<<31) != 0 {
+			return -int64(b&^(1<<31)) - 1
+		}
+		return int64(b)
+	}
+	b := math.Float64bits(float64(x))
+	if b&(1<<63) != 0 {
+		return -int64(b&^(1<<63)) - 1
+	}
+	return int64(b)
+}
+
+// FromOrdinal is the inverse of Ordinal.
+func FromOrdinal[T Real](k int64) T {
+	if isSingle[T]() {
+		if k < 0 {
+			return T(math.Float32frombits(uint32(-(k + 1)) | 1<<31))
+		}
+		return T(math.Float32frombits(uint32(k)))
+	}
+	if k < 0 {
+		return T(math.Float64frombits(uint64(-(k + 1)) | 1<<63))
+	}
+	return T(math.Float64frombits(uint64(k)))
+}
+
+// ValidPrecision reports whether precision names a supported precision.
+func ValidPrecision(precision string) bool {
+	return precision == PrecisionSingle || precision == PrecisionDouble
+}
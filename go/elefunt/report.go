@@ -0,0 +1,154 @@
+package elefunt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// Output formats understood by Report.Encode.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Float is a float64 that survives a JSON round trip when it is NaN or infinite.
+// Non-finite values are encoded as the strings "NaN", "+Inf" and "-Inf".
+type Float float64
+
+// MarshalJSON implements json.Marshaler.
+func (f Float) MarshalJSON() ([]byte, error) {
+	v := float64(f)
+	switch {
+	case math.IsNaN(v):
+		return []byte(`"NaN"`), nil
+	case math.IsInf(v, 1):
+		return []byte(`"+Inf"`), nil
+	case math.IsInf(v, -1):
+		return []byte(`"-Inf"`), nil
+	}
+	return strconv.AppendFloat(nil, v, 'g', -1, 64), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *Float) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("elefunt: invalid float %q", s)
+		}
+		*f = Float(v)
+		return nil
+	}
+	var v float64
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*f = Float(v)
+	return nil
+}
+
+func floats(v []float64) []Float {
+	if len(v) == 0 {
+		return nil
+	}
+	fs := make([]Float, len(v))
+	for i, x := range v {
+		fs[i] = Float(x)
+	}
+	return fs
+}
+
+// Sample is one row of a special identity test.
+type Sample struct {
+	X     Float `json:"x"`
+	Value Float `json:"value"`
+}
+
+// Check holds the rows of a special identity test such as EXP(X)*EXP(-X) = 1.0.
+type Check struct {
+	Identity string   `json:"identity"`
+	Samples  []Sample `json:"samples"`
+}
+
+// Special is the value of an expression evaluated at special arguments.
+type Special struct {
+	Expr   string  `json:"expr"`
+	Args   []Float `json:"args,omitempty"`
+	Value  Float   `json:"value"`
+	Expect string  `json:"expect,omitempty"` // Expected behavior of an error return, such as "OVERFLOW"
+}
+
+// Report is the structured record of one test program run.
+// Text written to a Report is kept as the classic printed form of the run.
+type Report struct {
+	Function  string    `json:"function"`
+	Precision string    `json:"precision,omitempty"`
+	Version   string    `json:"version"`
+	GitSHA    string    `json:"git_sha"`
+	Tests     []Result  `json:"tests"`
+	Checks    []Check   `json:"checks,omitempty"`
+	Specials  []Special `json:"special_arguments,omitempty"`
+	Errors    []Special `json:"error_returns,omitempty"`
+
+	Violations []Violation `json:"violations,omitempty"` // Thresholds the run failed to meet
+
+	text bytes.Buffer
+}
+
+// NewReport returns an empty Report for the named function.
+func NewReport(function, version, gitSHA string) *Report {
+	return &Report{Function: function, Version: version, GitSHA: gitSHA}
+}
+
+// Write appends p to the printed form of the report.
+func (r *Report) Write(p []byte) (int, error) {
+	return r.text.Write(p)
+}
+
+// AddResult records the result of a random argument test.
+func (r *Report) AddResult(res Result) {
+	r.Tests = append(r.Tests, res)
+}
+
+// AddCheck records one row of the special identity test named identity.
+func (r *Report) AddCheck(identity string, x, value float64) {
+	if n := len(r.Checks); n == 0 || r.Checks[n-1].Identity != identity {
+		r.Checks = append(r.Checks, Check{Identity: identity})
+	}
+	c := &r.Checks[len(r.Checks)-1]
+	c.Samples = append(c.Samples, Sample{X: Float(x), Value: Float(value)})
+}
+
+// AddSpecial records the value of expr at the special arguments args.
+func (r *Report) AddSpecial(expr string, value float64, args ...float64) {
+	r.Specials = append(r.Specials, Special{Expr: expr, Args: floats(args), Value: Float(value)})
+}
+
+// AddError records the value returned by expr for an argument expected to cause an error.
+func (r *Report) AddError(expr, expect string, value float64, args ...float64) {
+	r.Errors = append(r.Errors, Special{Expr: expr, Args: floats(args), Value: Float(value), Expect: expect})
+}
+
+// Encode writes the report to w in the given format.
+func (r *Report) Encode(w io.Writer, format string) error {
+	switch format {
+	case FormatText:
+		_, err := w.Write(r.text.Bytes())
+		return err
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	}
+	return fmt.Errorf("elefunt: unknown output format %q", format)
+}
+
+// ValidFormat reports whether format is understood by Encode.
+func ValidFormat(format string) bool {
+	return format == FormatText || format == FormatJSON
+}
@@ -0,0 +1,166 @@
+package elefunt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestFloatJSON(t *testing.T) {
+	tests := []struct {
+		f    float64
+		want string
+	}{
+		{0, `0`},
+		{-0.5, `-0.5`},
+		{0x1p-52, `2.220446049250313e-16`},
+		{math.MaxFloat64, `1.7976931348623157e+308`},
+		{math.NaN(), `"NaN"`},
+		{math.Inf(1), `"+Inf"`},
+		{math.Inf(-1), `"-Inf"`},
+	}
+	for _, tt := range tests {
+		b, err := json.Marshal(Float(tt.f))
+		if err != nil {
+			t.Fatalf("%g: %v", tt.f, err)
+		}
+		if string(b) != tt.want {
+			t.Errorf("%g encoded as %s, want %s", tt.f, b, tt.want)
+		}
+		var got Float
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatalf("%s: %v", b, err)
+		}
+		if g := float64(got); g != tt.f && !(math.IsNaN(g) && math.IsNaN(tt.f)) {
+			t.Errorf("%s decoded as %g, want %g", b, g, tt.f)
+		}
+	}
+
+	// Strings such as "Infinity" are read by strconv, others are rejected.
+	var f Float
+	if err := json.Unmarshal([]byte(`"Infinity"`), &f); err != nil || !math.IsInf(float64(f), 1) {
+		t.Errorf(`"Infinity" decoded as %g, %v, want +Inf`, f, err)
+	}
+	for _, s := range []string{`"huge"`, `true`, `[1]`} {
+		if err := json.Unmarshal([]byte(s), &f); err == nil {
+			t.Errorf("%s decoded as %g, want an error", s, f)
+		}
+	}
+}
+
+func TestReportJSON(t *testing.T) {
+	r := NewReport("exp", "v1.2.3", "abc123")
+	r.Precision = PrecisionDouble
+	r.AddResult(Result{Identity: "EXP(X-V) VS EXP(X)/EXP(V)", A: -1, B: 1, N: 4, Larger: 1, Agreed: 2, Smaller: 1,
+		MaxError: 0x1p-52, MaxX: 0.5, RMSError: 0x1p-53, IBeta: 2, IT: 53})
+	r.AddCheck("EXP(X)*EXP(-X) = 1.0", 1.5, 0)
+	r.AddSpecial("EXP(XMIN)", 1, 0x1p-1022)
+	r.AddError("EXP(X)", "OVERFLOW", math.Inf(1), 1000)
+	r.AddError("EXP(X)", "NaN", math.NaN(), math.Inf(-1))
+	r.Violations = []Violation{{Function: "exp", Test: 1, Subject: "EXP(X-V) VS EXP(X)/EXP(V)", Message: "too large"}}
+	fmt.Fprint(r, "printed form")
+
+	var b bytes.Buffer
+	if err := r.Encode(&b, FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "function": "exp",
+  "precision": "double",
+  "version": "v1.2.3",
+  "git_sha": "abc123",
+  "tests": [
+    {
+      "identity": "EXP(X-V) VS EXP(X)/EXP(V)",
+      "a": -1,
+      "b": 1,
+      "n": 4,
+      "larger": 1,
+      "agreed": 2,
+      "smaller": 1,
+      "max_error": 2.220446049250313e-16,
+      "max_error_exponent": -52,
+      "max_error_loss": 1,
+      "max_x": 0.5,
+      "rms_error": 1.1102230246251565e-16,
+      "rms_error_exponent": -53,
+      "rms_error_loss": 0,
+      "ibeta": 2,
+      "it": 53
+    }
+  ],
+  "checks": [
+    {
+      "identity": "EXP(X)*EXP(-X) = 1.0",
+      "samples": [
+        {
+          "x": 1.5,
+          "value": 0
+        }
+      ]
+    }
+  ],
+  "special_arguments": [
+    {
+      "expr": "EXP(XMIN)",
+      "args": [
+        2.2250738585072014e-308
+      ],
+      "value": 1
+    }
+  ],
+  "error_returns": [
+    {
+      "expr": "EXP(X)",
+      "args": [
+        1000
+      ],
+      "value": "+Inf",
+      "expect": "OVERFLOW"
+    },
+    {
+      "expr": "EXP(X)",
+      "args": [
+        "-Inf"
+      ],
+      "value": "NaN",
+      "expect": "NaN"
+    }
+  ],
+  "violations": [
+    {
+      "function": "exp",
+      "test": 1,
+      "subject": "EXP(X-V) VS EXP(X)/EXP(V)",
+      "message": "too large"
+    }
+  ]
+}
+`
+	if got := b.String(); got != want {
+		t.Errorf("JSON document:\n%s\nwant:\n%s", got, want)
+	}
+
+	// The document decodes back to the same report, less the printed form.
+	var got Report
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if g, w := jsonString(t, &got), jsonString(t, r); g != w {
+		t.Errorf("round trip:\n got %s\nwant %s", g, w)
+	}
+	if !math.IsNaN(float64(got.Errors[1].Value)) || !math.IsInf(float64(got.Errors[1].Args[0]), -1) {
+		t.Errorf("decoded %g at %g, want NaN at -Inf", got.Errors[1].Value, got.Errors[1].Args[0])
+	}
+
+	b.Reset()
+	if err := r.Encode(&b, FormatText); err != nil || b.String() != "printed form" {
+		t.Errorf("text form %q, %v, want %q", b.String(), err, "printed form")
+	}
+	if err := r.Encode(&b, "xml"); err == nil || !strings.Contains(err.Error(), "xml") {
+		t.Errorf("encoding as xml: %v, want an unknown format error", err)
+	}
+}
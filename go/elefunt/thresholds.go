@@ -0,0 +1,168 @@
+package elefunt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+)
+
+// Limit bounds the estimated digit loss of random argument tests.
+// Function, Precision and Test select the tests the limit applies to;
+// empty or zero values match every test. Each bound of a test comes from
+// the matching limit with the most selectors set among those that set it.
+type Limit struct {
+	Function     string `json:"function,omitempty"`       // Test program, such as "exp"
+	Precision    string `json:"precision,omitempty"`      // "single" or "double"
+	Test         int    `json:"test,omitempty"`           // 1-based index of the random argument test
+	MaxErrorLoss *Float `json:"max_error_loss,omitempty"` // Largest allowed loss of digits for the maximum error
+	RMSErrorLoss *Float `json:"rms_error_loss,omitempty"` // Largest allowed loss of digits for the RMS error
+}
+
+func (l Limit) matches(rep *Report, test int) bool {
+	return (l.Function == "" || l.Function == rep.Function) &&
+		(l.Precision == "" || l.Precision == rep.Precision) &&
+		(l.Test == 0 || l.Test == test)
+}
+
+func (l Limit) specificity() int {
+	n := 0
+	if l.Function != "" {
+		n++
+	}
+	if l.Precision != "" {
+		n++
+	}
+	if l.Test != 0 {
+		n++
+	}
+	return n
+}
+
+// Expect is the expected value of a special argument test, such as
+// EXP(0.0) - 1.0 = 0. Expr is matched against the expressions recorded by
+// Report.AddSpecial, or against those recorded by Report.AddError with the
+// given behavior when Behavior is set.
+type Expect struct {
+	Function  string `json:"function"`
+	Precision string `json:"precision,omitempty"`
+	Expr      string `json:"expr"`
+	Behavior  string `json:"behavior,omitempty"`  // Error return behavior, such as "OVERFLOW"
+	Value     Float  `json:"value"`               // Expected value; NaN matches NaN
+	Tolerance Float  `json:"tolerance,omitempty"` // Largest allowed absolute difference
+}
+
+func (e Expect) matches(rep *Report) bool {
+	return e.Function == rep.Function && (e.Precision == "" || e.Precision == rep.Precision)
+}
+
+func (e Expect) accepts(v float64) bool {
+	want := float64(e.Value)
+	switch {
+	case math.IsNaN(want) || math.IsNaN(v):
+		return math.IsNaN(want) && math.IsNaN(v)
+	case v == want:
+		return true
+	}
+	return math.Abs(v-want) <= float64(e.Tolerance)
+}
+
+// Thresholds holds the pass/fail criteria of a run.
+type Thresholds struct {
+	Limits  []Limit  `json:"limits,omitempty"`
+	Expects []Expect `json:"expects,omitempty"`
+}
+
+// LoadThresholds reads thresholds in JSON form from the file named path.
+func LoadThresholds(path string) (*Thresholds, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var t Thresholds
+	if err := dec.Decode(&t); err != nil {
+		return nil, fmt.Errorf("elefunt: %s: %v", path, err)
+	}
+	return &t, nil
+}
+
+// Violation describes a threshold that a report failed to meet.
+type Violation struct {
+	Function string `json:"function"`
+	Test     int    `json:"test,omitempty"` // 1-based index of the random argument test, or 0 for a special argument
+	Subject  string `json:"subject"`        // Identity or expression that failed
+	Message  string `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.Test != 0 {
+		return fmt.Sprintf("%s test %d (%s): %s", v.Function, v.Test, v.Subject, v.Message)
+	}
+	return fmt.Sprintf("%s %s: %s", v.Function, v.Subject, v.Message)
+}
+
+// limit returns the bounds for the test, taking each from the most specific
+// matching limit that sets it, and whether any limit matched.
+func (t *Thresholds) limit(rep *Report, test int) (Limit, bool) {
+	var best Limit
+	maxSpec, rmsSpec := -1, -1
+	for _, l := range t.Limits {
+		if !l.matches(rep, test) {
+			continue
+		}
+		n := l.specificity()
+		if l.MaxErrorLoss != nil && n > maxSpec {
+			best.MaxErrorLoss, maxSpec = l.MaxErrorLoss, n
+		}
+		if l.RMSErrorLoss != nil && n > rmsSpec {
+			best.RMSErrorLoss, rmsSpec = l.RMSErrorLoss, n
+		}
+	}
+	return best, maxSpec >= 0 || rmsSpec >= 0
+}
+
+// Check returns the thresholds that rep violates.
+func (t *Thresholds) Check(rep *Report) []Violation {
+	var vs []Violation
+	for i, res := range rep.Tests {
+		l, ok := t.limit(rep, i+1)
+		if !ok {
+			continue
+		}
+		if l.MaxErrorLoss != nil && res.MaxErrorLoss() > float64(*l.MaxErrorLoss) {
+			vs = append(vs, Violation{rep.Function, i + 1, res.Identity,
+				fmt.Sprintf("maximum error loses %.2f digits, limit %.2f", res.MaxErrorLoss(), float64(*l.MaxErrorLoss))})
+		}
+		if l.RMSErrorLoss != nil && res.RMSErrorLoss() > float64(*l.RMSErrorLoss) {
+			vs = append(vs, Violation{rep.Function, i + 1, res.Identity,
+				fmt.Sprintf("RMS error loses %.2f digits, limit %.2f", res.RMSErrorLoss(), float64(*l.RMSErrorLoss))})
+		}
+	}
+	for _, e := range t.Expects {
+		if !e.matches(rep) {
+			continue
+		}
+		specials := rep.Specials
+		if e.Behavior != "" {
+			specials = rep.Errors
+		}
+		found := false
+		for _, s := range specials {
+			if s.Expr != e.Expr || s.Expect != e.Behavior {
+				continue
+			}
+			found = true
+			if !e.accepts(float64(s.Value)) {
+				vs = append(vs, Violation{Function: rep.Function, Subject: e.Expr,
+					Message: fmt.Sprintf("got %v, want %v", float64(s.Value), float64(e.Value))})
+			}
+		}
+		if !found {
+			vs = append(vs, Violation{Function: rep.Function, Subject: e.Expr, Message: "no such special argument test"})
+		}
+	}
+	return vs
+}
@@ -0,0 +1,140 @@
+package elefunt
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+// thresholdReport returns a report whose first test loses 2 digits in the
+// maximum error and none in the RMS error, and whose second loses 5 and 3.
+func thresholdReport() *Report {
+	r := NewReport("exp", "", "")
+	r.Precision = PrecisionDouble
+	r.AddResult(Result{Identity: "EXP 1", IBeta: 2, IT: 53, MaxError: 0x1p-51, RMSError: 0x1p-53})
+	r.AddResult(Result{Identity: "EXP 2", IBeta: 2, IT: 53, MaxError: 0x1p-48, RMSError: 0x1p-50})
+	r.AddSpecial("EXP(0.0) - 1.0", 0)
+	r.AddSpecial("EXP(1.0)", math.E)
+	r.AddError("LOG(-1)", "NaN", math.NaN(), -1)
+	r.AddError("EXP(X)", "OVERFLOW", math.Inf(1), 1000)
+	return r
+}
+
+// violations returns vs as "test max", "test rms" or "expr message"
+// strings for comparison.
+func violations(vs []Violation) string {
+	var s []string
+	for _, v := range vs {
+		switch {
+		case strings.HasPrefix(v.Message, "maximum"):
+			s = append(s, fmt.Sprintf("%d max", v.Test))
+		case strings.HasPrefix(v.Message, "RMS"):
+			s = append(s, fmt.Sprintf("%d rms", v.Test))
+		default:
+			s = append(s, v.Subject+": "+v.Message)
+		}
+	}
+	return strings.Join(s, ", ")
+}
+
+func TestCheckLimits(t *testing.T) {
+	b := func(v float64) *Float {
+		f := Float(v)
+		return &f
+	}
+	tests := []struct {
+		desc   string
+		limits []Limit
+		want   string
+	}{
+		{"no limits", nil, ""},
+		{"global", []Limit{{MaxErrorLoss: b(3), RMSErrorLoss: b(1)}}, "2 max, 2 rms"},
+		{"zero", []Limit{{MaxErrorLoss: b(0), RMSErrorLoss: b(0)}}, "1 max, 2 max, 2 rms"},
+		{"other function", []Limit{{Function: "log", MaxErrorLoss: b(0)}}, ""},
+		{"other precision", []Limit{{Precision: PrecisionSingle, MaxErrorLoss: b(0)}}, ""},
+		{"other test", []Limit{{Test: 3, MaxErrorLoss: b(0)}}, ""},
+		{"one test", []Limit{{Test: 1, MaxErrorLoss: b(0)}}, "1 max"},
+
+		// The most specific matching limit wins, looser or not
+		{"specific looser", []Limit{
+			{MaxErrorLoss: b(3)},
+			{Function: "exp", Test: 2, MaxErrorLoss: b(6)},
+		}, ""},
+		{"specific stricter", []Limit{
+			{Function: "exp", Precision: PrecisionDouble, Test: 1, MaxErrorLoss: b(1)},
+			{Function: "exp", MaxErrorLoss: b(6)},
+		}, "1 max"},
+		{"precision and function", []Limit{
+			{Function: "exp", MaxErrorLoss: b(1)},
+			{Function: "exp", Precision: PrecisionDouble, MaxErrorLoss: b(10)},
+		}, ""},
+		// Among equally specific limits the first wins
+		{"first of equals", []Limit{
+			{Function: "exp", MaxErrorLoss: b(1)},
+			{Function: "exp", MaxErrorLoss: b(10)},
+		}, "1 max, 2 max"},
+		{"first of equals reversed", []Limit{
+			{Function: "exp", MaxErrorLoss: b(10)},
+			{Function: "exp", MaxErrorLoss: b(1)},
+		}, ""},
+
+		// Each bound comes from the most specific limit that sets it
+		{"rms from global", []Limit{
+			{RMSErrorLoss: b(1)},
+			{Function: "exp", Test: 2, MaxErrorLoss: b(6)},
+		}, "2 rms"},
+		{"max from global", []Limit{
+			{MaxErrorLoss: b(3)},
+			{Function: "exp", RMSErrorLoss: b(10)},
+		}, "2 max"},
+		{"both specific", []Limit{
+			{MaxErrorLoss: b(0), RMSErrorLoss: b(0)},
+			{Function: "exp", MaxErrorLoss: b(10)},
+			{Test: 2, RMSErrorLoss: b(4)},
+		}, ""},
+	}
+	for _, tt := range tests {
+		th := &Thresholds{Limits: tt.limits}
+		if got := violations(th.Check(thresholdReport())); got != tt.want {
+			t.Errorf("%s: violations %q, want %q", tt.desc, got, tt.want)
+		}
+	}
+}
+
+func TestCheckExpects(t *testing.T) {
+	const missing = ": no such special argument test"
+	tests := []struct {
+		desc   string
+		expect Expect
+		want   string
+	}{
+		{"equal", Expect{Function: "exp", Expr: "EXP(0.0) - 1.0", Value: 0}, ""},
+		{"differs", Expect{Function: "exp", Expr: "EXP(0.0) - 1.0", Value: 1}, "EXP(0.0) - 1.0: got 0, want 1"},
+		{"within tolerance", Expect{Function: "exp", Expr: "EXP(1.0)", Value: 2.718, Tolerance: 0.001}, ""},
+		{"beyond tolerance", Expect{Function: "exp", Expr: "EXP(1.0)", Value: 2.7, Tolerance: 0.001},
+			"EXP(1.0): got 2.718281828459045, want 2.7"},
+		{"precision", Expect{Function: "exp", Precision: PrecisionDouble, Expr: "EXP(1.0)", Value: 2}, "EXP(1.0): got 2.718281828459045, want 2"},
+		{"other precision", Expect{Function: "exp", Precision: PrecisionSingle, Expr: "EXP(1.0)", Value: 2}, ""},
+		{"other function", Expect{Function: "log", Expr: "EXP(1.0)", Value: 2}, ""},
+		{"missing", Expect{Function: "exp", Expr: "EXP(2.0)", Value: 2}, "EXP(2.0)" + missing},
+
+		// Error returns are matched by expression and behavior
+		{"NaN", Expect{Function: "exp", Expr: "LOG(-1)", Behavior: "NaN", Value: Float(math.NaN())}, ""},
+		{"not NaN", Expect{Function: "exp", Expr: "LOG(-1)", Behavior: "NaN", Value: 0, Tolerance: Float(math.Inf(1))},
+			"LOG(-1): got NaN, want 0"},
+		{"infinity", Expect{Function: "exp", Expr: "EXP(X)", Behavior: "OVERFLOW", Value: Float(math.Inf(1))}, ""},
+		{"other behavior", Expect{Function: "exp", Expr: "LOG(-1)", Behavior: "OVERFLOW", Value: Float(math.NaN())},
+			"LOG(-1)" + missing},
+		{"error return without behavior", Expect{Function: "exp", Expr: "LOG(-1)", Value: Float(math.NaN())},
+			"LOG(-1)" + missing},
+		{"special argument with behavior", Expect{Function: "exp", Expr: "EXP(1.0)", Behavior: "NaN", Value: Float(math.E)},
+			"EXP(1.0)" + missing},
+	}
+	for _, tt := range tests {
+		th := &Thresholds{Expects: []Expect{tt.expect}}
+		if got := violations(th.Check(thresholdReport())); got != tt.want {
+			t.Errorf("%s: violations %q, want %q", tt.desc, got, tt.want)
+		}
+	}
+}
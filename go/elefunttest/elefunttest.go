@@ -0,0 +1,147 @@
+// Package elefunttest runs ELEFUNT random argument identity tests from Go unit tests.
+//
+// A test compares the function under test at random arguments with an
+// identity built from other values of the same function, as Cody's
+// programs do, and fails when the estimated loss of significant digits
+// exceeds a bound. The identities of Cody's programs are in package
+// elefunt, shared with the suite:
+//
+//	func TestExp(t *testing.T) {
+//		elefunttest.CheckIdentity(t, "exp", mymath.Exp,
+//			elefunt.ExpShift[float64](),
+//			elefunttest.Interval{A: -0.2841, B: 0.3466},
+//			elefunttest.Options{})
+//	}
+package elefunttest
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"golefunt/elefunt"
+	"golefunt/oracle"
+	"golefunt/random"
+)
+
+// Default digit loss bounds used when Options leaves them nil.
+const (
+	DefaultMaxErrorLoss = 3.0
+	DefaultRMSErrorLoss = 1.0
+)
+
+// DefaultN is the number of random arguments drawn when Options leaves N zero.
+const DefaultN = 2000
+
+// Interval is the interval (A, B) random arguments are drawn from.
+type Interval struct {
+	A, B float64
+}
+
+// Options controls a check. The zero value draws DefaultN arguments with the
+// ELEFUNT seed and applies the default digit loss bounds.
+type Options struct {
+	N            int      // Number of random arguments, or DefaultN if zero
+	Seed         int      // Seed of the random number generator, 1 to random.MaxSeed, or the ELEFUNT seed if zero
+	MaxErrorLoss *float64 // Largest allowed loss of base IBeta digits for the maximum error, DefaultMaxErrorLoss if nil; negative for no limit
+	RMSErrorLoss *float64 // Largest allowed loss of base IBeta digits for the RMS error, DefaultRMSErrorLoss if nil; negative for no limit
+	MaxULP       float64  // If positive, also measure against the math/big reference for the function and bound the error in ULPs
+	Prec         uint     // Precision of the reference in bits, or oracle.DefaultPrec if zero
+}
+
+// Bound returns a pointer to v, for setting a digit loss bound in Options.
+func Bound(v float64) *float64 {
+	return &v
+}
+
+func limit(v *float64, def float64) float64 {
+	if v == nil {
+		return def
+	}
+	return *v
+}
+
+// CheckIdentity runs the random argument test of f, the function named name,
+// against id over the interval iv, and reports a failure to t with the
+// classic ELEFUNT summary when a bound in opts is exceeded. The name labels
+// the report and selects the reference function when opts.MaxULP is set;
+// it uses the names of elefunt.MathFuncs, such as "exp" or "log".
+func CheckIdentity[T elefunt.Real](t testing.TB, name string, f func(T) T, id elefunt.Identity[T], iv Interval, opts Options) elefunt.Result {
+	t.Helper()
+	mp := elefunt.Params[T]()
+	rng := random.New()
+	if opts.Seed != 0 {
+		if !random.ValidSeed(opts.Seed) {
+			t.Fatalf("elefunttest: invalid seed %d, want 1 to %d", opts.Seed, random.MaxSeed)
+		}
+		rng.Seed(opts.Seed)
+	}
+	n := opts.N
+	if n == 0 {
+		n = DefaultN
+	}
+
+	var ref oracle.Func1
+	if opts.MaxULP > 0 {
+		var ok bool
+		if ref, ok = oracle.Lookup1(name); !ok {
+			t.Fatalf("elefunttest: no reference function named %q", name)
+		}
+	}
+	prec := opts.Prec
+	if prec == 0 {
+		prec = oracle.DefaultPrec
+	}
+
+	a, b := T(iv.A), T(iv.B)
+	acc := elefunt.NewAccumulator(mp, iv.A, iv.B)
+	del := (b - a) / T(n)
+	xl := a
+	for i := 0; i < n; i++ {
+		x := del*elefunt.Random[T](rng) + xl
+		x, at, z, zz := id.Eval(f, x)
+		acc.Add(float64(x), float64(z), float64(zz))
+		if ref != nil {
+			acc.AddULP(float64(at), oracle.ULPs(float64(z), ref(big.NewFloat(float64(at)), prec), mp))
+		}
+		xl = xl + del
+	}
+
+	res := acc.Result()
+	res.Identity = id.Name
+
+	var failures []string
+	if l := limit(opts.MaxErrorLoss, DefaultMaxErrorLoss); l >= 0 && res.MaxErrorLoss() > l {
+		failures = append(failures, fmt.Sprintf("maximum error loses %.2f digits, limit %.2f", res.MaxErrorLoss(), l))
+	}
+	if l := limit(opts.RMSErrorLoss, DefaultRMSErrorLoss); l >= 0 && res.RMSErrorLoss() > l {
+		failures = append(failures, fmt.Sprintf("RMS error loses %.2f digits, limit %.2f", res.RMSErrorLoss(), l))
+	}
+	if opts.MaxULP > 0 && !(res.MaxULP <= opts.MaxULP) {
+		failures = append(failures, fmt.Sprintf("maximum error is %.4f ULPs, limit %.4f", res.MaxULP, opts.MaxULP))
+	}
+	if len(failures) > 0 {
+		t.Errorf("%s: %s\n%s", name, strings.Join(failures, "; "), report(res))
+	}
+	return res
+}
+
+// report returns the printed summary of res.
+func report(res elefunt.Result) string {
+	var sb strings.Builder
+	res.WriteHeading(&sb)
+	elefunt.WriteCounts(&sb, "F(X)", res.Larger, res.Agreed, res.Smaller)
+	res.WriteErrors(&sb)
+	return sb.String()
+}
+
+// Reference compares f with the trusted implementation g of the same function.
+func Reference[T elefunt.Real](g func(T) T) elefunt.Identity[T] {
+	return elefunt.Identity[T]{
+		Name: "F(X) VS REFERENCE(X)",
+		Eval: func(f func(T) T, x T) (T, T, T, T) {
+			return x, x, f(x), g(x)
+		},
+	}
+}
@@ -0,0 +1,151 @@
+package elefunttest_test
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"golefunt/elefunt"
+	"golefunt/elefunttest"
+	"golefunt/random"
+)
+
+// recorder is a testing.TB that records the failures reported to it.
+type recorder struct {
+	testing.TB
+	errors []string
+	fatal  bool
+}
+
+// errFatal unwinds a check that called Fatalf.
+type errFatal struct{}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+	r.fatal = true
+	panic(errFatal{})
+}
+
+// check runs CheckIdentity against a recorder and returns it.
+func check(name string, f func(float64) float64, id elefunt.Identity[float64], iv elefunttest.Interval, opts elefunttest.Options) (r *recorder) {
+	r = &recorder{}
+	defer func() {
+		if e := recover(); e != nil {
+			if _, ok := e.(errFatal); !ok {
+				panic(e)
+			}
+		}
+	}()
+	elefunttest.CheckIdentity(r, name, f, id, iv, opts)
+	return r
+}
+
+var (
+	expInterval = elefunttest.Interval{A: -0.2841, B: 0.3466}
+
+	// badExp has a relative error of 1e-13, about 900 ULPs.
+	badExp = func(x float64) float64 { return math.Exp(x) * (1 + 1e-13) }
+)
+
+func TestPass(t *testing.T) {
+	r := check("exp", math.Exp, elefunt.ExpShift[float64](), expInterval, elefunttest.Options{MaxULP: 1})
+	if len(r.errors) != 0 {
+		t.Errorf("math.Exp failed:\n%s", strings.Join(r.errors, "\n"))
+	}
+}
+
+func TestFail(t *testing.T) {
+	r := check("exp", badExp, elefunttest.Reference[float64](math.Exp), expInterval, elefunttest.Options{})
+	if len(r.errors) != 1 {
+		t.Fatalf("got %d failures, want 1", len(r.errors))
+	}
+	for _, s := range []string{"maximum error loses", "RMS error loses", "TEST OF F(X) VS REFERENCE(X)"} {
+		if !strings.Contains(r.errors[0], s) {
+			t.Errorf("failure does not mention %q:\n%s", s, r.errors[0])
+		}
+	}
+}
+
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		desc string
+		f    func(float64) float64
+		id   elefunt.Identity[float64]
+		opts elefunttest.Options
+		fail bool
+	}{
+		// math.Exp loses about 1.3 digits against ExpShift, within the
+		// default bound of 3 but not within a bound of 0.
+		{"default", math.Exp, elefunt.ExpShift[float64](), elefunttest.Options{}, false},
+		{"zero", math.Exp, elefunt.ExpShift[float64](), elefunttest.Options{MaxErrorLoss: elefunttest.Bound(0)}, true},
+		{"exact", math.Exp, elefunttest.Reference[float64](math.Exp),
+			elefunttest.Options{MaxErrorLoss: elefunttest.Bound(0), RMSErrorLoss: elefunttest.Bound(0)}, false},
+		{"no limit", badExp, elefunttest.Reference[float64](math.Exp),
+			elefunttest.Options{MaxErrorLoss: elefunttest.Bound(-1), RMSErrorLoss: elefunttest.Bound(-1)}, false},
+		{"ulps", badExp, elefunttest.Reference[float64](math.Exp),
+			elefunttest.Options{MaxErrorLoss: elefunttest.Bound(-1), RMSErrorLoss: elefunttest.Bound(-1), MaxULP: 1}, true},
+	}
+	for _, tt := range tests {
+		r := check("exp", tt.f, tt.id, expInterval, tt.opts)
+		if fail := len(r.errors) > 0; fail != tt.fail {
+			t.Errorf("%s: failed = %v, want %v\n%s", tt.desc, fail, tt.fail, strings.Join(r.errors, "\n"))
+		}
+	}
+}
+
+func TestArgument(t *testing.T) {
+	// The errors are recorded at the drawn argument X, not at X-1/16 or X*X.
+	tests := []struct {
+		name string
+		f    func(float64) float64
+		id   elefunt.Identity[float64]
+		iv   elefunttest.Interval
+	}{
+		{"exp", math.Exp, elefunt.ExpShift[float64](), expInterval},
+		{"log", math.Log, elefunt.LogSquare[float64](), elefunttest.Interval{A: 1 / math.Sqrt2, B: 15.0 / 16.0}},
+	}
+	for _, tt := range tests {
+		res := elefunttest.CheckIdentity(t, tt.name, tt.f, tt.id, tt.iv, elefunttest.Options{MaxULP: 1})
+		if res.MaxX < tt.iv.A || res.MaxX > tt.iv.B {
+			t.Errorf("%s: maximum error at %g, outside (%g, %g)", tt.name, res.MaxX, tt.iv.A, tt.iv.B)
+		}
+	}
+}
+
+func TestUnknownReference(t *testing.T) {
+	r := check("nosuch", math.Exp, elefunt.ExpShift[float64](), expInterval, elefunttest.Options{MaxULP: 1})
+	if !r.fatal {
+		t.Errorf("no fatal failure for an unknown reference")
+	}
+}
+
+func TestInvalidSeed(t *testing.T) {
+	for _, seed := range []int{-5, random.MaxSeed + 1, 1 << 40} {
+		r := check("exp", math.Exp, elefunt.ExpShift[float64](), expInterval, elefunttest.Options{Seed: seed})
+		if !r.fatal || !strings.Contains(r.errors[0], "invalid seed") {
+			t.Errorf("seed %d: failures %q, want a fatal invalid seed", seed, r.errors)
+		}
+	}
+	r := check("exp", math.Exp, elefunt.ExpShift[float64](), expInterval, elefunttest.Options{Seed: random.MaxSeed})
+	if len(r.errors) != 0 {
+		t.Errorf("seed %d: failures %q, want none", random.MaxSeed, r.errors)
+	}
+}
+
+func ExampleCheckIdentity() {
+	// CheckIdentity is called from a test function with its *testing.T.
+	testExp := func(t *testing.T) {
+		elefunttest.CheckIdentity(t, "exp", math.Exp,
+			elefunt.ExpShift[float64](),
+			elefunttest.Interval{A: -0.2841, B: 0.3466},
+			elefunttest.Options{RMSErrorLoss: elefunttest.Bound(0), MaxULP: 1})
+	}
+	_ = testExp
+}
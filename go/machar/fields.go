@@ -0,0 +1,57 @@
+package machar
+
+// Field is one named machine parameter, using the names of Cody's MACHAR.
+type Field struct {
+	Name  string
+	Value float64
+	Int   bool // Whether the parameter is an integer
+}
+
+// Fields returns the parameters of p in the order MACHAR returns them.
+func (p Params) Fields() []Field {
+	return []Field{
+		{"IBETA", float64(p.IBeta), true},
+		{"IT", float64(p.IT), true},
+		{"IRND", float64(p.IRnd), true},
+		{"NGRD", float64(p.NGrd), true},
+		{"MACHEP", float64(p.MachEp), true},
+		{"NEGEP", float64(p.NegEp), true},
+		{"IEXP", float64(p.IExp), true},
+		{"MINEXP", float64(p.MinExp), true},
+		{"MAXEXP", float64(p.MaxExp), true},
+		{"EPS", p.Eps, false},
+		{"EPSNEG", p.EpsNeg, false},
+		{"XMIN", p.XMin, false},
+		{"XMAX", p.XMax, false},
+	}
+}
+
+// Mismatch is a parameter whose value differs between two parameter sets.
+type Mismatch struct {
+	Name      string
+	Got, Want float64
+}
+
+// MinExpOffset is the amount by which MINEXP in the static tables, which
+// follow the C convention of FLT_MIN_EXP and DBL_MIN_EXP, exceeds the MINEXP
+// that MACHAR determines: XMIN is IBETA**(MINEXP-1) in the one and
+// IBETA**MINEXP in the other.
+const MinExpOffset = 1
+
+// Diff returns the parameters in which got, as determined by MACHAR,
+// differs from want, taken from a static table. MINEXP is compared
+// allowing for MinExpOffset.
+func Diff(got, want Params) []Mismatch {
+	got.MinExp += MinExpOffset
+	var ms []Mismatch
+	wf := want.Fields()
+	for i, f := range got.Fields() {
+		if f.Value != wf[i].Value {
+			if f.Name == "MINEXP" {
+				f.Value -= MinExpOffset
+			}
+			ms = append(ms, Mismatch{f.Name, f.Value, wf[i].Value})
+		}
+	}
+	return ms
+}
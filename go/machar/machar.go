@@ -60,14 +60,26 @@ func Float32() Params {
 	}
 }
 
-// Machar dynamically determines machine parameters using the original algorithm.
+// Float is the constraint satisfied by the floating-point types MacharOf can probe.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Machar dynamically determines machine parameters for float64 using the original algorithm.
 // This is a direct port of Cody's MACHAR routine.
 func Machar() Params {
+	return MacharOf[float64]()
+}
+
+// MacharOf dynamically determines machine parameters for the arithmetic of T.
+// Every intermediate result is rounded to T, and products are converted
+// explicitly so that the compiler cannot fuse them into a multiply-add.
+func MacharOf[T Float]() Params {
 	var p Params
 
-	one := 1.0
-	two := 2.0
-	zero := 0.0
+	one := T(1.0)
+	two := T(2.0)
+	zero := T(0.0)
 
 	// Determine IBeta, Beta ala Malcolm
 	a := one
@@ -92,14 +104,14 @@ func Machar() Params {
 		p.IBeta = itemp
 		break
 	}
-	beta := float64(p.IBeta)
+	beta := T(p.IBeta)
 
 	// Determine IT, IRnd
 	p.IT = 0
 	b = one
 	for {
 		p.IT = p.IT + 1
-		b = b * beta
+		b = T(b * beta)
 		temp := b + one
 		temp1 := temp - b
 		if temp1-one == zero {
@@ -125,7 +137,7 @@ func Machar() Params {
 	betain := one / beta
 	a = one
 	for i := 1; i <= negep; i++ {
-		a = a * betain
+		a = T(a * betain)
 	}
 	b = a
 
@@ -134,11 +146,11 @@ func Machar() Params {
 		if temp-one != zero {
 			break
 		}
-		a = a * beta
+		a = T(a * beta)
 		negep = negep - 1
 	}
 	p.NegEp = -negep
-	p.EpsNeg = a
+	epsneg := a
 
 	// Determine MachEp, Eps
 	machep := -p.IT - 3
@@ -148,16 +160,16 @@ func Machar() Params {
 		if temp-one != zero {
 			break
 		}
-		a = a * beta
+		a = T(a * beta)
 		machep = machep + 1
 	}
 	p.MachEp = machep
-	p.Eps = a
+	eps := a
 
 	// Determine NGrd
 	p.NGrd = 0
-	temp = one + p.Eps
-	if p.IRnd == 0 && temp*one-one != zero {
+	temp = one + eps
+	if p.IRnd == 0 && T(temp*one)-one != zero {
 		p.NGrd = 1
 	}
 
@@ -165,31 +177,30 @@ func Machar() Params {
 	i := 0
 	k := 1
 	z := betain
-	t := one + p.Eps
+	t := one + eps
 	nxres := 0
 	y := zero
 
 	for {
 		y = z
-		z = y * y
-		a = z * one
-		temp = z * t
-		if a+a == zero || math.Abs(z) >= y {
+		z = T(y * y)
+		a = T(z * one)
+		temp = T(z * t)
+		if a+a == zero || abs(z) >= y {
 			break
 		}
-		temp1 := temp * betain
-		if temp1*beta == z {
+		temp1 := T(temp * betain)
+		if T(temp1*beta) == z {
 			break
 		}
 		i = i + 1
 		k = k + k
 	}
 
+	mx := 0
 	if p.IBeta != 10 {
 		p.IExp = i + 1
-		mx := k + k
-		p.MinExp, p.XMin, nxres = determineMinExp(y, betain, t, beta, k, mx, nxres)
-		p.MaxExp = mx + p.MinExp
+		mx = k + k
 	} else {
 		p.IExp = 2
 		iz := p.IBeta
@@ -197,10 +208,17 @@ func Machar() Params {
 			iz = iz * p.IBeta
 			p.IExp = p.IExp + 1
 		}
-		mx := iz + iz - 1
-		p.MinExp, p.XMin, nxres = determineMinExp(y, betain, t, beta, k, mx, nxres)
-		p.MaxExp = mx + p.MinExp
+		mx = iz + iz - 1
+	}
+	var xmin T
+	p.MinExp, xmin, nxres, a, y = determineMinExp(y, betain, t, beta, k, nxres)
+
+	// Determine MaxExp
+	if mx <= -p.MinExp*2-3 && p.IBeta != 10 {
+		mx = mx + mx
+		p.IExp = p.IExp + 1
 	}
+	p.MaxExp = mx + p.MinExp
 
 	// Adjust IRnd for partial underflow
 	p.IRnd = p.IRnd + nxres
@@ -223,41 +241,54 @@ func Machar() Params {
 	}
 
 	// Determine XMax
-	p.XMax = one - p.EpsNeg
-	if p.XMax*one != p.XMax {
-		p.XMax = one - beta*p.EpsNeg
+	xmax := one - epsneg
+	if T(xmax*one) != xmax {
+		xmax = one - T(beta*epsneg)
 	}
-	p.XMax = p.XMax / (beta * beta * beta * p.XMin)
+	xmax = xmax / T(T(T(beta*beta)*beta)*xmin)
 	isum = p.MaxExp + p.MinExp + 3
 	for j := 1; j <= isum; j++ {
 		if p.IBeta == 2 {
-			p.XMax = p.XMax + p.XMax
+			xmax = xmax + xmax
 		} else {
-			p.XMax = p.XMax * beta
+			xmax = T(xmax * beta)
 		}
 	}
 
+	p.Eps = float64(eps)
+	p.EpsNeg = float64(epsneg)
+	p.XMin = float64(xmin)
+	p.XMax = float64(xmax)
 	return p
 }
 
-func determineMinExp(y, betain, t, beta float64, k, mx, nxres int) (minexp int, xmin float64, nxresOut int) {
-	xmin = y
+// determineMinExp divides y by the radix until it underflows, returning
+// MinExp, XMin and the partial underflow adjustment to IRnd, along with the
+// last values of A and Y in the loop.
+func determineMinExp[T Float](y, betain, t, beta T, k, nxres int) (minexp int, xmin T, nxresOut int, a, yOut T) {
 	for {
-		y = y * betain
-		a := y * 1.0
-		temp := y * t
-		if (a+a) == 0.0 || math.Abs(y) >= xmin {
+		xmin = y
+		y = T(y * betain)
+		a = T(y * 1.0)
+		temp := T(y * t)
+		if (a+a) == 0.0 || abs(y) >= xmin {
 			break
 		}
 		k = k + 1
-		temp1 := temp * betain
-		if temp1*beta != y || temp == y {
-			xmin = y
+		temp1 := T(temp * betain)
+		if T(temp1*beta) != y || temp == y {
 			continue
-		} else {
-			nxres = 3
-			xmin = y
 		}
+		nxres = 3
+		xmin = y
+		break
+	}
+	return -k, xmin, nxres, a, y
+}
+
+func abs[T Float](x T) T {
+	if x < 0 {
+		return -x
 	}
-	return -k, xmin, nxres
+	return x
 }
@@ -0,0 +1,33 @@
+package machar
+
+import "testing"
+
+// testMacharOf compares the parameters MACHAR determines with the static
+// table field by field. MACHAR's MINEXP is MinExpOffset below the table's,
+// which follows the C convention; XMIN is the same in both.
+func testMacharOf(t *testing.T, got, want Params) {
+	t.Helper()
+	if got.MinExp+MinExpOffset != want.MinExp {
+		t.Errorf("MINEXP = %d, want %d-%d", got.MinExp, want.MinExp, MinExpOffset)
+	}
+	wf := want.Fields()
+	for i, f := range got.Fields() {
+		if f.Name == "MINEXP" {
+			continue
+		}
+		if f.Value != wf[i].Value {
+			t.Errorf("%s = %g, want %g", f.Name, f.Value, wf[i].Value)
+		}
+	}
+	if ms := Diff(got, want); len(ms) != 0 {
+		t.Errorf("Diff = %v, want none", ms)
+	}
+}
+
+func TestMacharOfFloat32(t *testing.T) {
+	testMacharOf(t, MacharOf[float32](), Float32())
+}
+
+func TestMacharOfFloat64(t *testing.T) {
+	testMacharOf(t, MacharOf[float64](), Float64())
+}
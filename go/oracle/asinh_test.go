@@ -0,0 +1,26 @@
+package oracle
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAsinh(t *testing.T) {
+	checkValues(t, []value1{
+		{"asinh", "1", "8.81373587019543025232609324979792309028160328261635e-1"},
+		{"asinh", "-1e-3", "-9.99999833333408333288690506572398262778896762002256e-4"},
+		{"acosh", "2", "1.31695789692481670862504634730796844402698197146752"},
+		{"acosh", "10", "2.99322284612638089791266771377418291308366045118098"},
+		{"atanh", "0.5", "5.49306144334054845697622618461262852323745278911375e-1"},
+		{"atanh", "-0.9", "-1.47221948958322023000451371594392676861868963064956"},
+	}, nil)
+	checkEdges(t, []edge{
+		{"asinh", 0, "0"},
+		{"asinh", math.Inf(-1), "-Inf"},
+		{"acosh", 0.5, "NaN"},
+		{"acosh", 1, "0"},
+		{"atanh", 1, "+Inf"},
+		{"atanh", -1, "-Inf"},
+		{"atanh", 2, "NaN"},
+	})
+}
@@ -0,0 +1,22 @@
+package oracle
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCbrt(t *testing.T) {
+	checkValues(t, []value1{
+		{"cbrt", "2", "1.25992104989487316476721060727822835057025146470151"},
+		{"cbrt", "3", "1.44224957030740838232163831078010958839186925349935"},
+		{"cbrt", "-27", "-3"},
+	}, []value2{
+		{"hypot", "3", "4", "5"},
+		{"hypot", "1", "-2", "2.23606797749978969640917366873127623544061835961153"},
+	})
+	checkEdges(t, []edge{
+		{"cbrt", 0, "0"},
+		{"cbrt", -8, "-2"},
+		{"cbrt", math.Inf(-1), "-Inf"},
+	})
+}
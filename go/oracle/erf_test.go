@@ -0,0 +1,36 @@
+package oracle
+
+import (
+	"math"
+	"testing"
+)
+
+func TestErf(t *testing.T) {
+	checkValues(t, []value1{
+		{"erf", "1", "8.42700792949714869341220635082609259296066997966303e-1"},
+		{"erf", "-0.5", "-5.20499877813046537682746653891964528736451575757964e-1"},
+		{"erfc", "1", "1.57299207050285130658779364917390740703933002033697e-1"},
+		{"erfc", "3", "2.20904969985854413727761295823203798477070873992497e-5"},
+		{"erfinv", "0.5", "4.76936276204469873381418353643130559808969749059471e-1"},
+		{"erfinv", "-0.520499877813046537682746653891964528736451575757964", "-0.5"},
+		{"erfcinv", "0.5", "4.76936276204469873381418353643130559808969749059471e-1"},
+		{"erfcinv", "2.20904969985854413727761295823203798477070873992497e-5", "3"},
+	}, nil)
+	checkEdges(t, []edge{
+		{"erf", 0, "0"},
+		{"erf", math.Inf(-1), "-1"},
+		{"erfc", math.Inf(-1), "2"},
+		{"erfc", 100, "0"},
+		{"erfinv", 1, "+Inf"},
+		{"erfinv", -1, "-Inf"},
+		{"erfinv", 2, "NaN"},
+		{"erfcinv", 0, "+Inf"},
+		{"erfcinv", 2, "-Inf"},
+		{"erfcinv", 1, "0"},
+	})
+	checkBoundaries(t, []boundary{
+		// Erfc underflows to zero near 27.3.
+		{"erfc(27)", rounded(ref(Erfc, 27)), ref(Erfc, 27), 0.5},
+		{"erfc(28)", 0, ref(Erfc, 28), 0.5},
+	})
+}
@@ -0,0 +1,23 @@
+package oracle
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExpm1(t *testing.T) {
+	checkValues(t, []value1{
+		{"expm1", "1e-5", "1.00000500001666670833341666680555575396850198440256e-5"},
+		{"expm1", "-1", "-6.32120558828557678404476229838539132554188868968232e-1"},
+		{"log1p", "1e-5", "9.99995000033333083335333316666809522559534920534922e-6"},
+		{"log1p", "-0.5", "-6.93147180559945309417232121458176568075500134360255e-1"},
+	}, nil)
+	checkEdges(t, []edge{
+		{"expm1", 0, "0"},
+		{"expm1", math.Inf(-1), "-1"},
+		{"expm1", math.Inf(1), "+Inf"},
+		{"log1p", 0, "0"},
+		{"log1p", -1, "-Inf"},
+		{"log1p", -2, "NaN"},
+	})
+}
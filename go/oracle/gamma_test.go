@@ -0,0 +1,30 @@
+package oracle
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGamma(t *testing.T) {
+	checkValues(t, []value1{
+		{"gamma", "0.5", "1.77245385090551602729816748334114518279754945612239"},
+		{"gamma", "5", "24"},
+		// Gamma(-3/2) = 4*sqrt(pi)/3, and |Gamma(-5/2)| = 8*sqrt(pi)/15
+		{"gamma", "-1.5", "2.36327180120735470306422331112152691039673260816318"},
+		{"lgamma", "0.5", "5.72364942924700087071713675676529355823647406457656e-1"},
+		{"lgamma", "10", "1.28018274800814696112077178745667061642811492556632e1"},
+		{"lgamma", "-2.5", "-5.62437164976740506725945300976542841229441025528456e-2"},
+	}, nil)
+	checkEdges(t, []edge{
+		{"gamma", 0, "+Inf"},
+		{"gamma", -1, "NaN"},
+		{"gamma", math.Inf(1), "+Inf"},
+		{"lgamma", 1, "0"},
+		{"lgamma", -2, "+Inf"},
+	})
+	checkBoundaries(t, []boundary{
+		// Gamma overflows between 171.62 and 171.63.
+		{"gamma(171.6)", rounded(ref(Gamma, 171.6)), ref(Gamma, 171.6), 0.5},
+		{"gamma(171.7)", math.Inf(1), ref(Gamma, 171.7), 0},
+	})
+}
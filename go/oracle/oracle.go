@@ -0,0 +1,1155 @@
+// Package oracle computes elementary functions to several hundred bits with math/big.
+// The results serve as a reference for measuring the error of a floating-point
+// implementation in units in the last place (ULPs), which the identity tests of
+// ELEFUNT can only estimate.
+package oracle
+
+import (
+	"math"
+	"math/big"
+	"sync"
+
+	"golefunt/machar"
+)
+
+// DefaultPrec is the default precision of the reference values in bits.
+const DefaultPrec = 320
+
+// guard is the number of extra bits carried by intermediate results.
+const guard = 64
+
+// maxArg bounds the magnitude of arguments to Exp beyond which the result
+// is taken to overflow or underflow every supported floating-point format.
+const maxArg = 1 << 20
+
+// Func1 computes a function of one argument to prec bits.
+// A nil result stands for NaN.
+type Func1 func(x *big.Float, prec uint) *big.Float
+
+// Func2 computes a function of two arguments to prec bits.
+// A nil result stands for NaN.
+type Func2 func(x, y *big.Float, prec uint) *big.Float
+
+var funcs1 = map[string]Func1{
+	"exp":     Exp,
+	"log":     Log,
+	"log10":   Log10,
+	"expm1":   Expm1,
+	"log1p":   Log1p,
+	"sqrt":    Sqrt,
+	"cbrt":    Cbrt,
+	"sin":     Sin,
+	"cos":     Cos,
+	"tan":     Tan,
+	"asin":    Asin,
+	"acos":    Acos,
+	"atan":    Atan,
+	"sinh":    Sinh,
+	"cosh":    Cosh,
+	"tanh":    Tanh,
+	"asinh":   Asinh,
+	"acosh":   Acosh,
+	"atanh":   Atanh,
+	"gamma":   Gamma,
+	"lgamma":  Lgamma,
+	"erf":     Erf,
+	"erfc":    Erfc,
+	"erfinv":  Erfinv,
+	"erfcinv": Erfcinv,
+}
+
+var funcs2 = map[string]Func2{
+	"atan2": Atan2,
+	"hypot": Hypot,
+	"pow":   Pow,
+}
+
+// Lookup1 returns the reference for the function of one argument named name,
+// using the names of elefunt.MathFuncs.
+func Lookup1(name string) (Func1, bool) {
+	f, ok := funcs1[name]
+	return f, ok
+}
+
+// Lookup2 returns the reference for the function of two arguments named name.
+func Lookup2(name string) (Func2, bool) {
+	f, ok := funcs2[name]
+	return f, ok
+}
+
+func newFloat(prec uint) *big.Float {
+	return new(big.Float).SetPrec(prec)
+}
+
+func newInt(prec uint, i int64) *big.Float {
+	return newFloat(prec).SetInt64(i)
+}
+
+// round returns z rounded to prec bits.
+func round(z *big.Float, prec uint) *big.Float {
+	return newFloat(prec).Set(z)
+}
+
+// exponent returns the binary exponent of x, or 0 if x is zero.
+func exponent(x *big.Float) int {
+	if x.Sign() == 0 || x.IsInf() {
+		return 0
+	}
+	return x.MantExp(nil)
+}
+
+// cmpAbs compares |x| and |y|.
+func cmpAbs(x, y *big.Float) int {
+	return new(big.Float).Abs(x).Cmp(new(big.Float).Abs(y))
+}
+
+// extra returns the number of bits lost when a quantity of magnitude 2**e is reduced.
+func extra(e int) uint {
+	if e <= 0 {
+		return 0
+	}
+	return uint(e)
+}
+
+type cache struct {
+	mu      sync.Mutex
+	compute func(prec uint) *big.Float
+	values  map[uint]*big.Float
+}
+
+func (c *cache) get(prec uint) *big.Float {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.values[prec]
+	if !ok {
+		v = c.compute(prec)
+		c.values[prec] = v
+	}
+	return round(v, prec)
+}
+
+var (
+	piCache  = &cache{compute: computePi, values: make(map[uint]*big.Float)}
+	ln2Cache = &cache{compute: computeLn2, values: make(map[uint]*big.Float)}
+)
+
+// Pi returns pi to prec bits.
+func Pi(prec uint) *big.Float {
+	return piCache.get(prec)
+}
+
+// Ln2 returns the natural logarithm of 2 to prec bits.
+func Ln2(prec uint) *big.Float {
+	return ln2Cache.get(prec)
+}
+
+// computePi evaluates Machin's formula pi = 16*atan(1/5) - 4*atan(1/239).
+func computePi(prec uint) *big.Float {
+	wp := prec + guard
+	a := atanInv(5, wp)
+	a.Mul(a, newInt(wp, 16))
+	b := atanInv(239, wp)
+	b.Mul(b, newInt(wp, 4))
+	return round(a.Sub(a, b), prec)
+}
+
+// atanInv returns atan(1/n) by its Taylor series.
+func atanInv(n int64, prec uint) *big.Float {
+	nn := newInt(prec, n*n)
+	pow := newFloat(prec).Quo(newInt(prec, 1), newInt(prec, n))
+	sum := newFloat(prec).Set(pow)
+	term := newFloat(prec)
+	for k := int64(1); ; k++ {
+		pow.Quo(pow, nn)
+		term.Quo(pow, newInt(prec, 2*k+1))
+		if term.Sign() == 0 || exponent(sum)-exponent(term) > int(prec) {
+			break
+		}
+		if k%2 == 1 {
+			sum.Sub(sum, term)
+		} else {
+			sum.Add(sum, term)
+		}
+	}
+	return sum
+}
+
+// computeLn2 evaluates ln 2 = 2*atanh(1/3).
+func computeLn2(prec uint) *big.Float {
+	wp := prec + guard
+	t := newFloat(wp).Quo(newInt(wp, 1), newInt(wp, 3))
+	s := atanhSeries(t, wp)
+	return round(s.Mul(s, newInt(wp, 2)), prec)
+}
+
+// atanhSeries returns atanh(t) by its Taylor series for small |t|.
+func atanhSeries(t *big.Float, prec uint) *big.Float {
+	t2 := newFloat(prec).Mul(t, t)
+	pow := newFloat(prec).Set(t)
+	sum := newFloat(prec).Set(t)
+	term := newFloat(prec)
+	for k := int64(1); ; k++ {
+		pow.Mul(pow, t2)
+		term.Quo(pow, newInt(prec, 2*k+1))
+		if term.Sign() == 0 || exponent(sum)-exponent(term) > int(prec) {
+			break
+		}
+		sum.Add(sum, term)
+	}
+	return sum
+}
+
+// Exp returns e**x to prec bits.
+func Exp(x *big.Float, prec uint) *big.Float {
+	switch {
+	case x.IsInf() && x.Sign() > 0:
+		return newFloat(prec).SetInf(false)
+	case x.IsInf():
+		return newFloat(prec)
+	case x.Sign() == 0:
+		return newInt(prec, 1)
+	}
+	if cmpAbs(x, newInt(64, maxArg)) > 0 {
+		if x.Sign() > 0 {
+			return newFloat(prec).SetInf(false)
+		}
+		return newFloat(prec)
+	}
+
+	// Reduce x = k*ln2 + r with |r| <= ln2/2, then scale r by 2**-s.
+	const s = 16
+	wp := prec + guard + s + extra(exponent(x))
+	ln2 := Ln2(wp)
+	q := newFloat(wp).Quo(x, ln2)
+	k := nearest(q)
+	r := newFloat(wp).Mul(newFloat(wp).SetInt(k), ln2)
+	r.Sub(x, r)
+	r.SetMantExp(r, -s)
+
+	sum := newInt(wp, 1)
+	term := newInt(wp, 1)
+	for n := int64(1); ; n++ {
+		term.Mul(term, r)
+		term.Quo(term, newInt(wp, n))
+		if term.Sign() == 0 || exponent(sum)-exponent(term) > int(wp) {
+			break
+		}
+		sum.Add(sum, term)
+	}
+	for i := 0; i < s; i++ {
+		sum.Mul(sum, sum)
+	}
+	sum.SetMantExp(sum, int(k.Int64()))
+	return round(sum, prec)
+}
+
+// nearest returns q rounded to the nearest integer.
+func nearest(q *big.Float) *big.Int {
+	h := newFloat(q.Prec()).SetFloat64(0.5)
+	if q.Sign() < 0 {
+		h.Neg(h)
+	}
+	k, _ := newFloat(q.Prec()).Add(q, h).Int(nil)
+	return k
+}
+
+// Log returns the natural logarithm of x to prec bits.
+func Log(x *big.Float, prec uint) *big.Float {
+	switch {
+	case x.Sign() < 0:
+		return nil
+	case x.Sign() == 0:
+		return newFloat(prec).SetInf(true)
+	case x.IsInf():
+		return newFloat(prec).SetInf(false)
+	}
+
+	// Write x = m * 2**e with m in [sqrt(1/2), sqrt(2)).
+	wp := prec + guard
+	m := newFloat(wp)
+	e := x.MantExp(m)
+	if m.Cmp(newFloat(wp).SetFloat64(math.Sqrt2/2)) < 0 {
+		m.SetMantExp(m, 1)
+		e--
+	}
+
+	// log(m) = 2*atanh((m-1)/(m+1))
+	num := newFloat(wp).Sub(m, newInt(wp, 1))
+	den := newFloat(wp).Add(m, newInt(wp, 1))
+	t := num.Quo(num, den)
+	sum := atanhSeries(t, wp)
+	sum.Mul(sum, newInt(wp, 2))
+
+	if e != 0 {
+		wp2 := wp + extra(exponent(newInt(64, int64(e))))
+		l := Ln2(wp2)
+		l.Mul(l, newInt(wp2, int64(e)))
+		sum = newFloat(wp2).Add(l, sum)
+	}
+	return round(sum, prec)
+}
+
+// Log10 returns the decimal logarithm of x to prec bits.
+func Log10(x *big.Float, prec uint) *big.Float {
+	l := Log(x, prec+guard)
+	if l == nil || l.IsInf() {
+		return l
+	}
+	l.Quo(l, Log(newInt(prec+guard, 10), prec+guard))
+	return round(l, prec)
+}
+
+// Expm1 returns e**x - 1 to prec bits.
+func Expm1(x *big.Float, prec uint) *big.Float {
+	switch {
+	case x.Sign() == 0 || x.IsInf() && x.Sign() > 0:
+		return newFloat(prec).Set(x)
+	case x.IsInf():
+		return newInt(prec, -1)
+	}
+	wp := prec + guard
+	if cmpAbs(x, newInt(wp, 1)) < 0 {
+		// Taylor series avoids the cancellation in e**x - 1.
+		sum := newFloat(wp).Set(x)
+		term := newFloat(wp).Set(x)
+		for n := int64(2); ; n++ {
+			term.Mul(term, x)
+			term.Quo(term, newInt(wp, n))
+			if term.Sign() == 0 || exponent(sum)-exponent(term) > int(wp) {
+				break
+			}
+			sum.Add(sum, term)
+		}
+		return round(sum, prec)
+	}
+	e := Exp(x, wp)
+	return round(e.Sub(e, newInt(wp, 1)), prec)
+}
+
+// Log1p returns the natural logarithm of 1+x to prec bits.
+func Log1p(x *big.Float, prec uint) *big.Float {
+	minusOne := newInt(64, -1)
+	switch {
+	case x.Sign() == 0 || x.IsInf() && x.Sign() > 0:
+		return newFloat(prec).Set(x)
+	case x.Cmp(minusOne) < 0:
+		return nil
+	case x.Cmp(minusOne) == 0:
+		return newFloat(prec).SetInf(true)
+	}
+	wp := prec + guard
+	if cmpAbs(x, newFloat(wp).SetFloat64(0.5)) < 0 {
+		// log(1+x) = 2*atanh(x/(2+x)) avoids the cancellation in 1+x.
+		t := newFloat(wp).Add(x, newInt(wp, 2))
+		t.Quo(x, t)
+		s := atanhSeries(t, wp)
+		return round(s.Mul(s, newInt(wp, 2)), prec)
+	}
+	y := newFloat(wp+x.Prec()).Add(x, newInt(wp, 1))
+	return Log(y, prec)
+}
+
+// Sqrt returns the square root of x to prec bits.
+func Sqrt(x *big.Float, prec uint) *big.Float {
+	switch {
+	case x.Sign() < 0:
+		return nil
+	case x.Sign() == 0 || x.IsInf():
+		return newFloat(prec).Set(x)
+	}
+	return newFloat(prec).Sqrt(x)
+}
+
+// Cbrt returns the cube root of x to prec bits.
+func Cbrt(x *big.Float, prec uint) *big.Float {
+	if x.Sign() == 0 || x.IsInf() {
+		return newFloat(prec).Set(x)
+	}
+	if x.Sign() < 0 {
+		r := Cbrt(newFloat(x.Prec()).Neg(x), prec)
+		return r.Neg(r)
+	}
+
+	// Newton's iteration g = (2g + x/g**2)/3 from a float64 estimate,
+	// doubling the number of correct bits at each step.
+	wp := prec + guard
+	f, _ := x.Float64()
+	g := newFloat(wp).SetFloat64(math.Cbrt(f))
+	t := newFloat(wp)
+	for bits := uint(50); bits < 2*wp; bits *= 2 {
+		t.Mul(g, g)
+		t.Quo(x, t)
+		g.Add(g, g)
+		g.Add(g, t)
+		g.Quo(g, newInt(wp, 3))
+	}
+	return round(g, prec)
+}
+
+// Hypot returns sqrt(x*x + y*y) to prec bits.
+func Hypot(x, y *big.Float, prec uint) *big.Float {
+	if x.IsInf() || y.IsInf() {
+		return newFloat(prec).SetInf(false)
+	}
+	wp := prec + guard
+	s := newFloat(wp).Mul(x, x)
+	s.Add(s, newFloat(wp).Mul(y, y))
+	return Sqrt(s, prec)
+}
+
+// reduceHalfPi writes x = q*(pi/2) + r with |r| <= pi/4 and returns r and q mod 4.
+func reduceHalfPi(x *big.Float, prec uint) (*big.Float, int64) {
+	wp := prec + guard + extra(exponent(x))
+	halfPi := Pi(wp)
+	halfPi.SetMantExp(halfPi, -1)
+	q := nearest(newFloat(wp).Quo(x, halfPi))
+	r := newFloat(wp).Mul(newFloat(wp).SetInt(q), halfPi)
+	r.Sub(x, r)
+	return r, new(big.Int).Mod(q, big.NewInt(4)).Int64()
+}
+
+// sinCosSeries returns sin(r) and cos(r) by their Taylor series for |r| <= pi/4.
+func sinCosSeries(r *big.Float, prec uint) (sin, cos *big.Float) {
+	r2 := newFloat(prec).Mul(r, r)
+	sin = newFloat(prec).Set(r)
+	cos = newInt(prec, 1)
+	term := newFloat(prec).Set(r)
+	for n := int64(2); ; n += 2 {
+		// term holds (-1)**(n/2-1) * r**(n-1) / (n-1)!
+		term.Mul(term, r2)
+		term.Quo(term, newInt(prec, -n*(n+1)))
+		if term.Sign() == 0 || exponent(sin)-exponent(term) > int(prec) {
+			break
+		}
+		sin.Add(sin, term)
+	}
+	term = newInt(prec, 1)
+	for n := int64(1); ; n += 2 {
+		term.Mul(term, r2)
+		term.Quo(term, newInt(prec, -n*(n+1)))
+		if term.Sign() == 0 || exponent(cos)-exponent(term) > int(prec) {
+			break
+		}
+		cos.Add(cos, term)
+	}
+	return sin, cos
+}
+
+func sinCos(x *big.Float, prec uint) (sin, cos *big.Float) {
+	r, q := reduceHalfPi(x, prec)
+	s, c := sinCosSeries(r, r.Prec())
+	switch q {
+	case 1:
+		s, c = c, s.Neg(s)
+	case 2:
+		s, c = s.Neg(s), c.Neg(c)
+	case 3:
+		s, c = c.Neg(c), s
+	}
+	return s, c
+}
+
+// Sin returns the sine of x to prec bits.
+func Sin(x *big.Float, prec uint) *big.Float {
+	if x.IsInf() {
+		return nil
+	}
+	if x.Sign() == 0 {
+		return newFloat(prec).Set(x)
+	}
+	s, _ := sinCos(x, prec)
+	return round(s, prec)
+}
+
+// Cos returns the cosine of x to prec bits.
+func Cos(x *big.Float, prec uint) *big.Float {
+	if x.IsInf() {
+		return nil
+	}
+	_, c := sinCos(x, prec)
+	return round(c, prec)
+}
+
+// Tan returns the tangent of x to prec bits.
+func Tan(x *big.Float, prec uint) *big.Float {
+	if x.IsInf() {
+		return nil
+	}
+	if x.Sign() == 0 {
+		return newFloat(prec).Set(x)
+	}
+	s, c := sinCos(x, prec)
+	return round(s.Quo(s, c), prec)
+}
+
+// Atan returns the arctangent of x to prec bits.
+func Atan(x *big.Float, prec uint) *big.Float {
+	if x.Sign() == 0 {
+		return newFloat(prec).Set(x)
+	}
+	wp := prec + guard
+	if x.IsInf() {
+		p := Pi(wp)
+		p.SetMantExp(p, -1)
+		if x.Sign() < 0 {
+			p.Neg(p)
+		}
+		return round(p, prec)
+	}
+
+	neg := x.Sign() < 0
+	t := newFloat(wp).Abs(x)
+	inverted := t.Cmp(newInt(wp, 1)) > 0
+	if inverted {
+		t.Quo(newInt(wp, 1), t)
+	}
+
+	// Halve the argument with atan(t) = 2*atan(t/(1+sqrt(1+t*t))).
+	k := 0
+	small := newFloat(wp).SetMantExp(newInt(wp, 1), -8)
+	for t.Cmp(small) > 0 {
+		u := newFloat(wp).Mul(t, t)
+		u.Add(u, newInt(wp, 1))
+		u.Sqrt(u)
+		u.Add(u, newInt(wp, 1))
+		t.Quo(t, u)
+		k++
+	}
+
+	t2 := newFloat(wp).Mul(t, t)
+	pow := newFloat(wp).Set(t)
+	sum := newFloat(wp).Set(t)
+	term := newFloat(wp)
+	for n := int64(1); ; n++ {
+		pow.Mul(pow, t2)
+		term.Quo(pow, newInt(wp, 2*n+1))
+		if term.Sign() == 0 || exponent(sum)-exponent(term) > int(wp) {
+			break
+		}
+		if n%2 == 1 {
+			sum.Sub(sum, term)
+		} else {
+			sum.Add(sum, term)
+		}
+	}
+	sum.SetMantExp(sum, k)
+
+	if inverted {
+		p := Pi(wp)
+		p.SetMantExp(p, -1)
+		sum.Sub(p, sum)
+	}
+	if neg {
+		sum.Neg(sum)
+	}
+	return round(sum, prec)
+}
+
+// Atan2 returns the arctangent of y/x to prec bits, using the signs of both
+// arguments to determine the quadrant.
+func Atan2(y, x *big.Float, prec uint) *big.Float {
+	wp := prec + guard
+	switch {
+	case x.Sign() == 0 && y.Sign() == 0:
+		return newFloat(prec)
+	case x.Sign() == 0:
+		p := Pi(wp)
+		p.SetMantExp(p, -1)
+		if y.Sign() < 0 {
+			p.Neg(p)
+		}
+		return round(p, prec)
+	}
+	a := Atan(newFloat(wp).Quo(y, x), wp)
+	if x.Sign() < 0 {
+		if y.Sign() < 0 {
+			a.Sub(a, Pi(wp))
+		} else {
+			a.Add(a, Pi(wp))
+		}
+	}
+	return round(a, prec)
+}
+
+// Asin returns the arcsine of x to prec bits.
+func Asin(x *big.Float, prec uint) *big.Float {
+	wp := prec + guard
+	one := newInt(wp, 1)
+	switch cmpAbs(x, one) {
+	case 1:
+		return nil
+	case 0:
+		p := Pi(wp)
+		p.SetMantExp(p, -1)
+		if x.Sign() < 0 {
+			p.Neg(p)
+		}
+		return round(p, prec)
+	}
+	// asin(x) = atan(x/sqrt((1-x)*(1+x)))
+	d := newFloat(wp).Sub(one, x)
+	d.Mul(d, newFloat(wp).Add(one, x))
+	d.Sqrt(d)
+	return Atan(d.Quo(x, d), prec)
+}
+
+// Acos returns the arccosine of x to prec bits.
+func Acos(x *big.Float, prec uint) *big.Float {
+	wp := prec + guard
+	one := newInt(wp, 1)
+	switch {
+	case cmpAbs(x, one) > 0:
+		return nil
+	case x.Cmp(one) == 0:
+		return newFloat(prec)
+	case x.Cmp(newInt(wp, -1)) == 0:
+		return Pi(prec)
+	}
+	// acos(x) = 2*atan(sqrt((1-x)/(1+x)))
+	t := newFloat(wp).Sub(one, x)
+	t.Quo(t, newFloat(wp).Add(one, x))
+	t.Sqrt(t)
+	a := Atan(t, wp)
+	a.SetMantExp(a, 1)
+	return round(a, prec)
+}
+
+// expPair returns e**x and e**-x to prec bits.
+func expPair(x *big.Float, prec uint) (ep, em *big.Float) {
+	ep = Exp(x, prec)
+	em = Exp(newFloat(x.Prec()).Neg(x), prec)
+	return ep, em
+}
+
+// Sinh returns the hyperbolic sine of x to prec bits.
+func Sinh(x *big.Float, prec uint) *big.Float {
+	if x.Sign() == 0 || x.IsInf() {
+		return newFloat(prec).Set(x)
+	}
+	wp := prec + guard
+	if cmpAbs(x, newInt(wp, 1)) < 0 {
+		// Taylor series avoids the cancellation in (e**x - e**-x)/2.
+		x2 := newFloat(wp).Mul(x, x)
+		sum := newFloat(wp).Set(x)
+		term := newFloat(wp).Set(x)
+		for n := int64(2); ; n += 2 {
+			term.Mul(term, x2)
+			term.Quo(term, newInt(wp, n*(n+1)))
+			if term.Sign() == 0 || exponent(sum)-exponent(term) > int(wp) {
+				break
+			}
+			sum.Add(sum, term)
+		}
+		return round(sum, prec)
+	}
+	ep, em := expPair(x, wp)
+	ep.Sub(ep, em)
+	ep.SetMantExp(ep, -1)
+	return round(ep, prec)
+}
+
+// Cosh returns the hyperbolic cosine of x to prec bits.
+func Cosh(x *big.Float, prec uint) *big.Float {
+	if x.IsInf() {
+		return newFloat(prec).SetInf(false)
+	}
+	wp := prec + guard
+	ep, em := expPair(x, wp)
+	ep.Add(ep, em)
+	ep.SetMantExp(ep, -1)
+	return round(ep, prec)
+}
+
+// Tanh returns the hyperbolic tangent of x to prec bits.
+func Tanh(x *big.Float, prec uint) *big.Float {
+	if x.Sign() == 0 {
+		return newFloat(prec).Set(x)
+	}
+	wp := prec + guard
+	if x.IsInf() || cmpAbs(x, newInt(wp, int64(wp))) > 0 {
+		// |tanh(x)| rounds to 1 at every precision up to wp.
+		return newInt(prec, int64(x.Sign()))
+	}
+	s := Sinh(x, wp)
+	return round(s.Quo(s, Cosh(x, wp)), prec)
+}
+
+// Asinh returns the inverse hyperbolic sine of x to prec bits.
+func Asinh(x *big.Float, prec uint) *big.Float {
+	if x.Sign() == 0 || x.IsInf() {
+		return newFloat(prec).Set(x)
+	}
+	if x.Sign() < 0 {
+		r := Asinh(newFloat(x.Prec()).Neg(x), prec)
+		return r.Neg(r)
+	}
+
+	// asinh(x) = log1p(x + x**2/(1+sqrt(1+x**2))), which has no cancellation.
+	wp := prec + guard
+	x2 := newFloat(wp).Mul(x, x)
+	s := newFloat(wp).Add(x2, newInt(wp, 1))
+	s.Sqrt(s)
+	s.Add(s, newInt(wp, 1))
+	x2.Quo(x2, s)
+	x2.Add(x2, x)
+	return Log1p(x2, prec)
+}
+
+// Acosh returns the inverse hyperbolic cosine of x to prec bits.
+func Acosh(x *big.Float, prec uint) *big.Float {
+	one := newInt(64, 1)
+	switch c := x.Cmp(one); {
+	case c < 0:
+		return nil
+	case c == 0:
+		return newFloat(prec)
+	case x.IsInf():
+		return newFloat(prec).SetInf(false)
+	}
+
+	// acosh(x) = log1p((x-1) + sqrt((x-1)*(x+1))), with x-1 exact.
+	wp := prec + guard
+	d := newFloat(wp+x.Prec()).Sub(x, one)
+	s := newFloat(wp).Add(x, one)
+	s.Mul(s, d)
+	s.Sqrt(s)
+	s.Add(s, d)
+	return Log1p(s, prec)
+}
+
+// Atanh returns the inverse hyperbolic tangent of x to prec bits.
+func Atanh(x *big.Float, prec uint) *big.Float {
+	switch c := cmpAbs(x, newInt(64, 1)); {
+	case x.Sign() == 0:
+		return newFloat(prec).Set(x)
+	case c > 0:
+		return nil
+	case c == 0:
+		return newFloat(prec).SetInf(x.Sign() < 0)
+	}
+
+	// atanh(x) = log1p(2x/(1-x))/2, with 1-x exact.
+	wp := prec + guard
+	d := newFloat(wp+x.Prec()).Sub(newInt(64, 1), x)
+	t := newFloat(wp).Add(x, x)
+	t.Quo(t, d)
+	r := Log1p(t, wp)
+	r.SetMantExp(r, -1)
+	return round(r, prec)
+}
+
+// bernoulli holds the Bernoulli numbers B_0, B_1, ... computed so far.
+var bernoulli struct {
+	mu sync.Mutex
+	b  []*big.Rat
+}
+
+// bernoulli2k returns the Bernoulli number B_2k. The result is shared and
+// must not be modified.
+func bernoulli2k(k int) *big.Rat {
+	bernoulli.mu.Lock()
+	defer bernoulli.mu.Unlock()
+	b := bernoulli.b
+	for n := len(b); n <= 2*k; n++ {
+		// B_n = -(C(n+1,0)*B_0 + ... + C(n+1,n-1)*B_n-1)/(n+1), with B_0 = 1.
+		s := new(big.Rat)
+		if n == 0 {
+			s.SetInt64(1)
+		}
+		c := big.NewInt(1)
+		for j := 0; j < n; j++ {
+			if b[j].Sign() != 0 {
+				s.Add(s, new(big.Rat).Mul(new(big.Rat).SetInt(c), b[j]))
+			}
+			c.Mul(c, big.NewInt(int64(n+1-j)))
+			c.Quo(c, big.NewInt(int64(j+1)))
+		}
+		if n > 0 {
+			s.Mul(s, big.NewRat(-1, int64(n+1)))
+		}
+		b = append(b, s)
+	}
+	bernoulli.b = b
+	return b[2*k]
+}
+
+// stirling returns log(Gamma(y)) to prec bits by Stirling's series, for y of
+// at least prec/2, and the binary exponent of its largest term.
+func stirling(y *big.Float, prec uint) (*big.Float, int) {
+	// (y-1/2)*log(y) - y + log(2*pi)/2 + sum B_2k/(2k*(2k-1)*y**(2k-1))
+	l := newFloat(prec).Sub(y, newFloat(prec).SetFloat64(0.5))
+	l.Mul(l, Log(y, prec))
+	l.Sub(l, y)
+	mag := max(exponent(l), exponent(y))
+	c := Log(newFloat(prec).SetMantExp(Pi(prec), 1), prec)
+	l.Add(l, c.SetMantExp(c, -1))
+
+	y2 := newFloat(prec).Mul(y, y)
+	pow := newFloat(prec).Quo(newInt(prec, 1), y)
+	last := exponent(pow) + 1
+	for k := 1; ; k++ {
+		term := newFloat(prec).SetRat(bernoulli2k(k))
+		term.Quo(term, newInt(prec, int64(2*k*(2*k-1))))
+		term.Mul(term, pow)
+		// The series is asymptotic: stop before its terms grow again.
+		if term.Sign() == 0 || exponent(term) < mag-int(prec) || exponent(term) > last {
+			break
+		}
+		l.Add(l, term)
+		last = exponent(term)
+		pow.Quo(pow, y2)
+	}
+	return l, mag
+}
+
+// lgammaSum returns log|Gamma(x)| and whether Gamma(x) is negative, computed
+// with prec-bit arithmetic, and the binary exponent of the largest quantity
+// summed to get it, from which the bits lost to cancellation follow.
+func lgammaSum(x *big.Float, prec uint) (*big.Float, bool, int) {
+	one := newInt(64, 1)
+	if x.Sign() < 0 {
+		// Gamma(x)*Gamma(1-x) = pi/sin(pi*x), reducing x by the nearest
+		// integer k first so that sin(pi*x) keeps its accuracy near k.
+		k := nearest(newFloat(x.Prec() + 1).Set(x))
+		f := newFloat(x.Prec()).Sub(x, newFloat(x.Prec()).SetInt(k))
+		s := Sin(newFloat(prec).Mul(f, Pi(prec)), prec)
+		neg := (s.Sign() < 0) != (k.Bit(0) == 1)
+		s.Abs(s)
+		l := Log(s.Quo(Pi(prec), s), prec)
+		g, _, mag := lgammaSum(newFloat(prec+x.Prec()).Sub(one, x), prec)
+		mag = max(mag, exponent(l))
+		return l.Sub(l, g), neg, mag
+	}
+
+	// Gamma(x) = Gamma(x+m)/(x*(x+1)*...*(x+m-1)), with x+m large enough
+	// for Stirling's series.
+	y := newFloat(prec + x.Prec()).Set(x)
+	p := newInt(prec, 1)
+	for n := newInt(64, int64(prec/2)); y.Cmp(n) < 0; y.Add(y, one) {
+		p.Mul(p, y)
+	}
+	l, mag := stirling(y, prec)
+	if p.Cmp(one) != 0 {
+		lp := Log(p, prec)
+		mag = max(mag, exponent(lp))
+		l.Sub(l, lp)
+	}
+	return l, false, mag
+}
+
+// lgamma returns log|Gamma(x)| to prec bits and whether Gamma(x) is
+// negative, for finite x other than zero and the negative integers.
+func lgamma(x *big.Float, prec uint) (*big.Float, bool) {
+	if x.Cmp(newInt(64, 1)) == 0 || x.Cmp(newInt(64, 2)) == 0 {
+		return newFloat(prec), false
+	}
+
+	// Near the zeros of log|Gamma(x)| the sum cancels: carry as many more
+	// bits as it lost and try again.
+	wp := prec + guard
+	for {
+		l, neg, mag := lgammaSum(x, wp)
+		lost := mag - exponent(l)
+		if l.Sign() != 0 && int(wp)-lost >= int(prec+guard/2) {
+			return round(l, prec), neg
+		}
+		if l.Sign() == 0 {
+			lost = int(wp)
+		}
+		wp = prec + guard + uint(lost)
+	}
+}
+
+// Gamma returns the gamma function of x to prec bits.
+func Gamma(x *big.Float, prec uint) *big.Float {
+	switch {
+	case x.Sign() == 0:
+		return newFloat(prec).SetInf(x.Signbit())
+	case x.IsInf() && x.Sign() > 0:
+		return newFloat(prec).SetInf(false)
+	case x.IsInf(), x.Sign() < 0 && x.IsInt():
+		return nil
+	case x.Cmp(newInt(64, maxArg)) > 0:
+		return newFloat(prec).SetInf(false)
+	case x.Cmp(newInt(64, -maxArg)) < 0:
+		// |Gamma(x)| is below every representable number.
+		return newFloat(prec)
+	}
+
+	// Gamma(x) = exp(log|Gamma(x)|) needs log|Gamma(x)| to prec bits after
+	// its binary point.
+	wp := prec + guard
+	l, neg := lgamma(x, wp)
+	if e := extra(exponent(l)); e > 0 {
+		l, neg = lgamma(x, wp+e)
+	}
+	r := Exp(l, prec)
+	if neg {
+		r.Neg(r)
+	}
+	return r
+}
+
+// Lgamma returns the natural logarithm of |Gamma(x)| to prec bits.
+func Lgamma(x *big.Float, prec uint) *big.Float {
+	if x.IsInf() || x.Sign() <= 0 && x.IsInt() {
+		return newFloat(prec).SetInf(false)
+	}
+	l, _ := lgamma(x, prec)
+	return l
+}
+
+// maxErfc bounds the square of the arguments to Erfc beyond which the result,
+// below e**-maxErfc, is taken to underflow every supported floating-point format.
+const maxErfc = 1 << 11
+
+// erfSeries returns erf(x) to prec bits for x > 0 from
+// erf(x) = 2/sqrt(pi) * e**-x**2 * sum 2**n * x**(2n+1)/(1*3*...*(2n+1)),
+// whose terms are all positive.
+func erfSeries(x *big.Float, prec uint) *big.Float {
+	x2 := newFloat(prec).Mul(x, x)
+	x2.SetMantExp(x2, 1)
+	sum := newFloat(prec).Set(x)
+	term := newFloat(prec).Set(x)
+	for n := int64(1); ; n++ {
+		// The terms grow while 2n+1 < 2x**2, and never exceed the sum.
+		term.Mul(term, x2)
+		term.Quo(term, newInt(prec, 2*n+1))
+		if term.Sign() == 0 || exponent(sum)-exponent(term) > int(prec) {
+			break
+		}
+		sum.Add(sum, term)
+	}
+	e := newFloat(prec).Mul(x, x)
+	sum.Mul(sum, Exp(e.Neg(e), prec))
+	sum.Mul(sum, newInt(prec, 2))
+	return sum.Quo(sum, newFloat(prec).Sqrt(Pi(prec)))
+}
+
+// Erf returns the error function of x to prec bits.
+func Erf(x *big.Float, prec uint) *big.Float {
+	switch {
+	case x.Sign() == 0:
+		return newFloat(prec).Set(x)
+	case x.IsInf():
+		return newInt(prec, int64(x.Sign()))
+	case x.Sign() < 0:
+		r := Erf(newFloat(x.Prec()).Neg(x), prec)
+		return r.Neg(r)
+	}
+
+	// Once x**2 exceeds wp*ln2, 1-erf(x) < e**-x**2 is below 2**-wp and
+	// erf(x) rounds to 1.
+	wp := prec + guard
+	if x2, _ := newFloat(64).Mul(x, x).Float64(); x2 > float64(wp)*math.Ln2 {
+		return newInt(prec, 1)
+	}
+	return round(erfSeries(x, wp), prec)
+}
+
+// Erfc returns the complementary error function of x to prec bits.
+func Erfc(x *big.Float, prec uint) *big.Float {
+	switch {
+	case x.IsInf() && x.Sign() > 0:
+		return newFloat(prec)
+	case x.IsInf():
+		return newInt(prec, 2)
+	case x.Sign() <= 0:
+		// erfc(x) = 1 + erf(-x), with no cancellation.
+		r := Erf(newFloat(x.Prec()).Neg(x), prec+guard)
+		return round(r.Add(r, newInt(prec, 1)), prec)
+	}
+
+	// erfc(x) = 1 - erf(x) loses about x**2/ln2 bits to cancellation.
+	x2, _ := newFloat(64).Mul(x, x).Float64()
+	if x2 > maxErfc {
+		return newFloat(prec)
+	}
+	wp := prec + guard + uint(x2/math.Ln2)
+	r := erfSeries(x, wp)
+	return round(r.Sub(newInt(wp, 1), r), prec)
+}
+
+// Erfinv returns the inverse error function of y to prec bits.
+func Erfinv(y *big.Float, prec uint) *big.Float {
+	one := newInt(64, 1)
+	switch c := cmpAbs(y, one); {
+	case y.Sign() == 0:
+		return newFloat(prec).Set(y)
+	case c > 0:
+		return nil
+	case c == 0:
+		return newFloat(prec).SetInf(y.Sign() < 0)
+	case y.Sign() < 0:
+		r := Erfinv(newFloat(y.Prec()).Neg(y), prec)
+		return r.Neg(r)
+	case y.Cmp(newFloat(64).SetFloat64(0.5)) >= 0:
+		// erfinv(y) = erfcinv(1-y), with 1-y exact.
+		return Erfcinv(newFloat(y.Prec()).Sub(one, y), prec)
+	}
+
+	// Newton's method on erf(x) = y from a double precision estimate.
+	wp := prec + guard
+	f, _ := y.Float64()
+	x := newFloat(wp).SetFloat64(math.Erfinv(f))
+	return newton(x, prec, func(x *big.Float) *big.Float {
+		// (y - erf(x)) / erf'(x)
+		d := Erf(x, wp)
+		d.Sub(y, d)
+		return d.Quo(d, erfDeriv(x, wp))
+	})
+}
+
+// Erfcinv returns the inverse complementary error function of y to prec bits.
+func Erfcinv(y *big.Float, prec uint) *big.Float {
+	one := newInt(64, 1)
+	two := newInt(64, 2)
+	switch {
+	case y.Sign() < 0, y.Cmp(two) > 0:
+		return nil
+	case y.Sign() == 0:
+		return newFloat(prec).SetInf(false)
+	case y.Cmp(two) == 0:
+		return newFloat(prec).SetInf(true)
+	case y.Cmp(one) == 0:
+		return newFloat(prec)
+	case y.Cmp(one) > 0:
+		// erfcinv(y) = -erfcinv(2-y), with 2-y exact.
+		r := Erfcinv(newFloat(y.Prec()).Sub(two, y), prec)
+		return r.Neg(r)
+	}
+
+	// Newton's method on erfc(x) = y from a double precision estimate, or
+	// for y too small for math.Erfcinv, from x = sqrt(-log(y*x*sqrt(pi))).
+	wp := prec + guard
+	f, _ := y.Float64()
+	x0 := math.Erfcinv(f)
+	if f < 1e-16 || math.IsInf(x0, 0) {
+		l, _ := Log(y, 64).Float64()
+		x0 = math.Sqrt(-l)
+		for i := 0; i < 4; i++ {
+			x0 = math.Sqrt(-l - math.Log(x0*math.Sqrt(math.Pi)))
+		}
+	}
+	x := newFloat(wp).SetFloat64(x0)
+	return newton(x, prec, func(x *big.Float) *big.Float {
+		// (erfc(x) - y) / erf'(x)
+		d := Erfc(x, wp)
+		d.Sub(d, y)
+		return d.Quo(d, erfDeriv(x, wp))
+	})
+}
+
+// erfDeriv returns the derivative 2/sqrt(pi) * e**-x**2 of erf at x to prec bits.
+func erfDeriv(x *big.Float, prec uint) *big.Float {
+	e := newFloat(prec).Mul(x, x)
+	d := Exp(e.Neg(e), prec)
+	d.Mul(d, newInt(prec, 2))
+	return d.Quo(d, newFloat(prec).Sqrt(Pi(prec)))
+}
+
+// newton improves x by the steps returned by step until they no longer
+// change its first prec+guard/2 bits, and returns x rounded to prec bits.
+func newton(x *big.Float, prec uint, step func(x *big.Float) *big.Float) *big.Float {
+	for i := 0; i < 64; i++ {
+		d := step(x)
+		x.Add(x, d)
+		if d.Sign() == 0 || exponent(x)-exponent(d) > int(prec+guard/2) {
+			break
+		}
+	}
+	return round(x, prec)
+}
+
+// Pow returns x**y to prec bits, with the zero and infinite values that
+// IEEE 754 gives pow for infinite or zero arguments.
+func Pow(x, y *big.Float, prec uint) *big.Float {
+	one := newInt(prec, 1)
+	switch {
+	case y.Sign() == 0 || x.Cmp(one) == 0:
+		return one
+	case y.IsInf():
+		// |x|**±Inf is 1, zero or +Inf as |x| is 1, or on either side of it.
+		switch c := cmpAbs(x, one); {
+		case c == 0:
+			return one
+		case (c > 0) == (y.Sign() > 0):
+			return newFloat(prec).SetInf(false)
+		}
+		return newFloat(prec)
+	}
+	odd := false
+	if y.IsInt() {
+		i, _ := y.Int(nil)
+		odd = i.Bit(0) == 1
+	}
+	if x.Sign() == 0 || x.IsInf() {
+		// Zero or infinite, with the sign of x for an odd integer y.
+		r := newFloat(prec)
+		if (x.Sign() == 0) == (y.Sign() < 0) {
+			r.SetInf(false)
+		}
+		if x.Signbit() && odd {
+			r.Neg(r)
+		}
+		return r
+	}
+	if x.Sign() < 0 && !y.IsInt() {
+		return nil
+	}
+	neg := x.Sign() < 0 && odd
+
+	// x**y = exp(y*log|x|), carrying enough bits for the integer part of y*log|x|.
+	wp := prec + guard
+	l := Log(newFloat(wp).Abs(x), wp)
+	wp += extra(exponent(l) + exponent(y))
+	l = Log(newFloat(wp).Abs(x), wp)
+	l.Mul(l, y)
+	r := Exp(l, prec)
+	if neg {
+		r.Neg(r)
+	}
+	return r
+}
+
+// overflow returns the magnitude from which results round to infinity in
+// the floating-point format described by mp: XMAX plus half its ULP.
+func overflow(mp machar.Params) *big.Float {
+	_, e := math.Frexp(mp.XMax)
+	half := new(big.Float).SetMantExp(big.NewFloat(1), e-mp.IT-1)
+	return newFloat(uint(mp.IT)+2).Add(big.NewFloat(mp.XMax), half)
+}
+
+// ULPs returns the error of got in units in the last place of want, for the
+// floating-point format described by mp. A nil want stands for NaN.
+func ULPs(got float64, want *big.Float, mp machar.Params) float64 {
+	switch {
+	case want == nil && math.IsNaN(got):
+		return 0
+	case want == nil || math.IsNaN(got):
+		return math.Inf(1)
+	case math.IsInf(got, 0):
+		// An infinite result is exact if the reference rounds to it: it has the
+		// same sign and reaches XMAX plus half its ULP, where ties round to
+		// the infinity that follows XMAX's odd significand.
+		if (got > 0) == (want.Sign() > 0) && (want.IsInf() || cmpAbs(want, overflow(mp)) >= 0) {
+			return 0
+		}
+		return math.Inf(1)
+	case want.IsInf():
+		return math.Inf(1)
+	}
+
+	// Below XMin the spacing of the format stays that of XMin.
+	_, emin := math.Frexp(mp.XMin)
+	e := exponent(want)
+	if want.Sign() == 0 || e < emin {
+		e = emin
+	}
+	d := newFloat(want.Prec() + guard).SetFloat64(got)
+	d.Sub(d, want)
+	d.Abs(d)
+	d.SetMantExp(d, mp.IT-e)
+	u, _ := d.Float64()
+	return u
+}
@@ -0,0 +1,298 @@
+package oracle
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"golefunt/machar"
+)
+
+// parse returns s as a big.Float of DefaultPrec bits.
+func parse(t *testing.T, s string) *big.Float {
+	t.Helper()
+	x, ok := newFloat(DefaultPrec).SetString(s)
+	if !ok {
+		t.Fatalf("bad number %q", s)
+	}
+	return x
+}
+
+// value1 is the value of a function of one argument, and value2 that of a
+// function of two, to 51 significant digits, computed independently with
+// Python's decimal module.
+type (
+	value1 struct{ name, x, want string }
+	value2 struct{ name, x, y, want string }
+)
+
+var values1 = []value1{
+	{"exp", "1", "2.71828182845904523536028747135266249775724709369996"},
+	{"exp", "-10", "4.53999297624848515355915155605506102379180888665650e-5"},
+	{"log", "10", "2.30258509299404568401799145468436420760110148862877"},
+	{"log10", "2", "3.01029995663981195213738894724493026768189881462109e-1"},
+	{"sqrt", "2", "1.41421356237309504880168872420969807856967187537695"},
+	{"sin", "1", "8.41470984807896506652502321630298999622563060798371e-1"},
+	{"sin", "100", "-5.06365641109758793656557610459785432065032721290657e-1"},
+	{"cos", "1", "5.40302305868139717400936607442976603732310420617922e-1"},
+	{"tan", "1", "1.55740772465490223050697480745836017308725077238152"},
+	{"asin", "0.5", "5.23598775598298873077107230546583814032861566562518e-1"},
+	{"acos", "0.5", "1.04719755119659774615421446109316762806572313312504"},
+	{"atan", "0.5", "4.63647609000806116214256231461214402028537054286120e-1"},
+	{"sinh", "1", "1.17520119364380145688238185059560081515571798133410"},
+	{"cosh", "1", "1.54308063481524377847790562075706168260152911236586"},
+	{"tanh", "1", "7.61594155955764888119458282604793590412768597257937e-1"},
+}
+
+var values2 = []value2{
+	{"atan2", "1", "-1", "2.35619449019234492884698253745962716314787704953133"},
+	{"pow", "2", "0.5", "1.41421356237309504880168872420969807856967187537695"},
+	{"pow", "10", "0.3", "1.99526231496887960135245539673953555798627431540535"},
+	{"pow", "-2", "3", "-8"},
+}
+
+// agrees reports whether got agrees with want to the 150 bits that the
+// reference values carry comfortably.
+func agrees(got, want *big.Float) bool {
+	if want.Sign() == 0 {
+		return got.Sign() == 0
+	}
+	d := new(big.Float).SetPrec(DefaultPrec).Sub(got, want)
+	d.Quo(d, want)
+	return d.Sign() == 0 || exponent(d) < -150
+}
+
+func TestValues(t *testing.T) {
+	checkValues(t, values1, values2)
+}
+
+// checkValues checks the references against the values in v1 and v2.
+func checkValues(t *testing.T, v1 []value1, v2 []value2) {
+	t.Helper()
+	for _, v := range v1 {
+		f, ok := Lookup1(v.name)
+		if !ok {
+			t.Fatalf("no reference for %s", v.name)
+		}
+		got := f(parse(t, v.x), DefaultPrec)
+		if got == nil || !agrees(got, parse(t, v.want)) {
+			t.Errorf("%s(%s) = %v, want %s", v.name, v.x, got, v.want)
+		}
+	}
+	for _, v := range v2 {
+		f, ok := Lookup2(v.name)
+		if !ok {
+			t.Fatalf("no reference for %s", v.name)
+		}
+		got := f(parse(t, v.x), parse(t, v.y), DefaultPrec)
+		if got == nil || !agrees(got, parse(t, v.want)) {
+			t.Errorf("%s(%s, %s) = %v, want %s", v.name, v.x, v.y, got, v.want)
+		}
+	}
+}
+
+// edge is the value of a function at an edge case, with want "NaN"
+// standing for a nil result.
+type edge struct {
+	name string
+	x    float64
+	want string
+}
+
+var edges = []edge{
+	{"exp", 0, "1"},
+	{"exp", math.Inf(1), "+Inf"},
+	{"exp", math.Inf(-1), "0"},
+	{"exp", 1 << 21, "+Inf"},
+	{"exp", -1 << 21, "0"},
+	{"log", 0, "-Inf"},
+	{"log", -1, "NaN"},
+	{"log", math.Inf(1), "+Inf"},
+	{"log", 1, "0"},
+	{"sqrt", -1, "NaN"},
+	{"sqrt", math.Inf(1), "+Inf"},
+	{"sin", 0, "0"},
+	{"asin", 2, "NaN"},
+}
+
+func TestEdges(t *testing.T) {
+	checkEdges(t, edges)
+}
+
+// checkEdges checks the references at the edge cases es.
+func checkEdges(t *testing.T, es []edge) {
+	t.Helper()
+	for _, e := range es {
+		f, _ := Lookup1(e.name)
+		got := f(new(big.Float).SetFloat64(e.x), DefaultPrec)
+		var s string
+		if got == nil {
+			s = "NaN"
+		} else {
+			s = got.Text('g', 10)
+		}
+		if s != e.want {
+			t.Errorf("%s(%g) = %s, want %s", e.name, e.x, s, e.want)
+		}
+	}
+}
+
+func TestPowSpecial(t *testing.T) {
+	// The values of pow for infinite and zero arguments in IEEE 754, which
+	// are those of math.Pow.
+	inf, nan := math.Inf(1), math.NaN()
+	negZero := math.Copysign(0, -1)
+	tests := []struct {
+		x, y, want float64
+	}{
+		{2, inf, inf},
+		{2, -inf, 0},
+		{0.5, inf, 0},
+		{0.5, -inf, inf},
+		{-2, inf, inf},
+		{-0.5, -inf, inf},
+		{-1, inf, 1},
+		{-1, -inf, 1},
+		{1, inf, 1},
+		{1, -inf, 1},
+		{inf, 0.5, inf},
+		{inf, -2, 0},
+		{inf, 0, 1},
+		{-inf, 3, -inf},
+		{-inf, 2, inf},
+		{-inf, 0.5, inf},
+		{-inf, -3, negZero},
+		{-inf, -2, 0},
+		{0, -3, inf},
+		{negZero, -3, -inf},
+		{negZero, -2, inf},
+		{negZero, 3, negZero},
+		{negZero, 0.5, 0},
+		{0, 0, 1},
+		{-inf, inf, inf},
+		{0, -inf, inf},
+		{-8, 1.0 / 3, nan},
+	}
+	for _, tt := range tests {
+		got := Pow(new(big.Float).SetFloat64(tt.x), new(big.Float).SetFloat64(tt.y), DefaultPrec)
+		if want := math.Pow(tt.x, tt.y); !same(want, tt.want) {
+			t.Fatalf("math.Pow(%g, %g) = %g, the table says %g", tt.x, tt.y, want, tt.want)
+		}
+		var g float64
+		if got == nil {
+			g = nan
+		} else {
+			g, _ = got.Float64()
+		}
+		if !same(g, tt.want) {
+			t.Errorf("Pow(%g, %g) = %g, want %g", tt.x, tt.y, g, tt.want)
+		}
+	}
+}
+
+// same reports whether x and y are the same float64, telling zeros apart
+// by their signs.
+func same(x, y float64) bool {
+	if math.IsNaN(x) || math.IsNaN(y) {
+		return math.IsNaN(x) && math.IsNaN(y)
+	}
+	return x == y && math.Signbit(x) == math.Signbit(y)
+}
+
+// boundary is a float64 result near the overflow or underflow threshold,
+// with the largest error in ULPs it may have against the reference.
+type boundary struct {
+	desc string
+	got  float64
+	want *big.Float
+	max  float64
+}
+
+// ref returns f(x) to DefaultPrec bits.
+func ref(f Func1, x float64) *big.Float {
+	return f(new(big.Float).SetFloat64(x), DefaultPrec)
+}
+
+// rounded returns want correctly rounded to float64.
+func rounded(want *big.Float) float64 {
+	f, _ := want.Float64()
+	return f
+}
+
+// TestBoundaries checks ULPs at the overflow and underflow thresholds of
+// float64, where the oracle must agree with the format about which results
+// are representable.
+func TestBoundaries(t *testing.T) {
+	checkBoundaries(t, []boundary{
+		// Below log(XMAX) = 709.7827..., e**x is finite.
+		{"exp(709.78)", rounded(ref(Exp, 709.78)), ref(Exp, 709.78), 0.5},
+		// Above it, +Inf is the correctly rounded result.
+		{"exp(709.79)", math.Inf(1), ref(Exp, 709.79), 0},
+		// e**x rounds to the subnormals, measured in units of XMIN's spacing.
+		{"exp(-740)", rounded(ref(Exp, -740)), ref(Exp, -740), 0.5},
+		// Below log(2**-1075) = -745.1332..., e**x rounds to zero.
+		{"exp(-746)", 0, ref(Exp, -746), 0.5},
+	})
+
+	// An infinite result where the reference does not overflow is
+	// infinitely wrong.
+	if u := ULPs(math.Inf(1), ref(Exp, 709.78), machar.Float64()); !math.IsInf(u, 1) {
+		t.Errorf("ULPs(+Inf, exp(709.78)) = %g, want +Inf", u)
+	}
+}
+
+// checkBoundaries checks the errors in ULPs of the results bs.
+func checkBoundaries(t *testing.T, bs []boundary) {
+	t.Helper()
+	mp := machar.Float64()
+	for _, b := range bs {
+		if u := ULPs(b.got, b.want, mp); !(u <= b.max) {
+			t.Errorf("ULPs(%s) = %g, want at most %g", b.desc, u, b.max)
+		}
+	}
+}
+
+func TestULPs(t *testing.T) {
+	mp := machar.Float64()
+	one := big.NewFloat(1)
+	below := newFloat(DefaultPrec).Sub(one, new(big.Float).SetMantExp(one, -60))
+	// XMAX plus a quarter and a half of its ULP of 2**971.
+	xmax := big.NewFloat(math.MaxFloat64)
+	quarter := newFloat(DefaultPrec).Add(xmax, new(big.Float).SetMantExp(one, 969))
+	half := newFloat(DefaultPrec).Add(xmax, new(big.Float).SetMantExp(one, 970))
+	tests := []struct {
+		desc string
+		got  float64
+		want *big.Float
+		ulps float64
+	}{
+		{"1 vs 1", 1, one, 0},
+		// The spacing above 1 is twice that below it, so the next float64
+		// on either side of 1 is 1 ULP above and 1/2 ULP below.
+		{"1+eps vs 1", 1 + 0x1p-52, one, 1},
+		{"1-eps/2 vs 1", 1 - 0x1p-53, one, 0.5},
+		// Just below 1 the ULP is that of [1/2, 1).
+		{"1 vs 1-2**-60", 1, below, 0x1p-7},
+		{"-1 vs 1", -1, one, 0x1p53},
+		// Below XMIN the ULP stays that of XMIN.
+		{"2**-1074 vs 0", 0x1p-1074, new(big.Float), 1},
+		{"0 vs 2**-1074", 0, big.NewFloat(0x1p-1074), 1},
+		{"NaN vs NaN", math.NaN(), nil, 0},
+		{"NaN vs 1", math.NaN(), one, math.Inf(1)},
+		{"1 vs NaN", 1, nil, math.Inf(1)},
+		{"+Inf vs +Inf", math.Inf(1), new(big.Float).SetInf(false), 0},
+		{"-Inf vs +Inf", math.Inf(-1), new(big.Float).SetInf(false), math.Inf(1)},
+		{"XMAX vs +Inf", math.MaxFloat64, new(big.Float).SetInf(false), math.Inf(1)},
+		// Beyond XMAX, results round to XMAX until half its ULP above it.
+		{"XMAX vs XMAX+ulp/4", math.MaxFloat64, quarter, 0.25},
+		{"+Inf vs XMAX+ulp/4", math.Inf(1), quarter, math.Inf(1)},
+		{"+Inf vs XMAX+ulp/2", math.Inf(1), half, 0},
+		{"-Inf vs -(XMAX+ulp/2)", math.Inf(-1), new(big.Float).Neg(half), 0},
+		{"-Inf vs XMAX+ulp/2", math.Inf(-1), half, math.Inf(1)},
+	}
+	for _, tt := range tests {
+		if u := ULPs(tt.got, tt.want, mp); u != tt.ulps {
+			t.Errorf("ULPs(%s) = %g, want %g", tt.desc, u, tt.ulps)
+		}
+	}
+}
@@ -0,0 +1,626 @@
+// Package parser reads the printed output of the ELEFUNT test programs.
+//
+// It understands both the output of Cody's Fortran programs, with their
+// carriage control characters and E and D edit descriptors, and the classic
+// text printed by the Go ports, and turns either into elefunt.Report values
+// so that runs can be compared field by field or loaded as JSON. Printouts
+// from other compilers and machines are accepted as long as they keep the
+// wording of the original programs; the radix and precision are taken from
+// the THERE ARE ... SIGNIFICANT DIGITS lines.
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golefunt/elefunt"
+)
+
+var (
+	// A number printed by an E or D edit descriptor or by %E, including the
+	// Fortran form with the letter dropped from a three digit exponent.
+	// A D exponent needs its sign, so that constants such as 1.0D0 in the
+	// printed expressions are not taken for values.
+	reFloat = regexp.MustCompile(`[-+]?(?:\d+\.\d*|\.\d+)(?:[Ee][-+]?\d+|[Dd][-+]\d+|[-+]\d{3})|[-+]?Inf(?:inity)?|NaN`)
+	// Any number, including integers such as the Y interval of power.
+	reNumber = regexp.MustCompile(`[-+]?(?:\d+\.?\d*|\.\d+)(?:[Ee][-+]?\d+|[Dd][-+]\d+|[-+]\d{3})?|[-+]?Inf(?:inity)?|NaN`)
+
+	reTested   = regexp.MustCompile(`^(\d+) RANDOM ARGUMENTS WERE TESTED`)
+	reLarger   = regexp.MustCompile(`WAS LARGER\s*(\d+)`)
+	reAgreed   = regexp.MustCompile(`^AGREED\s*(\d+)`)
+	reSmaller  = regexp.MustCompile(`^WAS SMALLER\s*(\d+)`)
+	reDigits   = regexp.MustCompile(`^THERE ARE\s*(\d+) BASE\s*(\d+)`)
+	reIdentity = regexp.MustCompile(`THE IDENTITY\s+(.*?)\s+WILL BE TESTED`)
+	reLeading  = regexp.MustCompile(`^[0-9*]*([A-Z]+[0-9]*)`)
+	reDouble   = regexp.MustCompile(`\bD(ASIN|ACOS|ATAN2|ATAN|SINH|COSH|TANH|SIN|COS|TAN|COT|EXP|LOG10|LOG|SQRT)\b`)
+	reALog     = regexp.MustCompile(`\bALOG(10)?\b`)
+	reD0       = regexp.MustCompile(`(\d\.\d*)D0\b`)
+	reSpace    = regexp.MustCompile(`\s+`)
+	reName     = regexp.MustCompile(`[A-Z]+[0-9]*`)
+	reReturn   = regexp.MustCompile(`^RETURN\s+(\S+)`)
+)
+
+// programs maps the function named first in an identity to the test program.
+var programs = map[string]string{
+	"SIN": "sincos", "COS": "sincos",
+	"EXP": "exp",
+	"LOG": "log", "LOG10": "log",
+	"TAN": "tan", "COT": "tan",
+	"SQRT": "sqrt",
+	"ASIN": "asin", "ACOS": "asin",
+	"ATAN": "atan", "ATAN2": "atan",
+	"SINH": "sinh", "COSH": "sinh",
+	"TANH":  "tanh",
+	"EXPM1": "expm1",
+	"CBRT":  "cbrt",
+	"ASINH": "asinh",
+	"GAMMA": "gamma",
+	"ERF":   "erf",
+}
+
+// layouts holds, for each test program, the expressions the Go port records
+// for its special arguments and error returns, in the order it prints them,
+// and the expected behavior of each error return. They are the same in both
+// precisions; TestRoundTrip checks them against runs of the ports.
+var layouts = map[string]struct {
+	specials []string
+	errors   [][2]string // Expression and expected behavior
+}{
+	"sincos": {
+		specials: []string{
+			"(SIN(A+C)-SIN(A-C))/(C+C)", "SIN(BETA**(0.75*MINEXP))", "SIN(SQRT(BETAP)*(1-EPSNEG))",
+			"SIN(SQRT(BETAP))", "SIN(SQRT(BETAP)*(1+EPS))",
+		},
+		errors: [][2]string{{"SIN(BETAP)", "NO ERROR"}},
+	},
+	"exp": {
+		specials: []string{
+			"EXP(0.0) - 1.0", "EXP(FLOOR(LOG(XMIN)))", "EXP(FLOOR(LOG(XMAX)))", "EXP(X)",
+			"EXP(X/2)**2",
+		},
+		errors: [][2]string{{"EXP(X)", "UNDERFLOW"}, {"EXP(X)", "OVERFLOW"}},
+	},
+	"log": {
+		specials: []string{"LOG(1.0)", "LOG(XMIN)", "LOG(XMAX)"},
+		errors:   [][2]string{{"LOG(X)", "NaN"}, {"LOG(X)", "-Inf"}},
+	},
+	"tan": {
+		errors: [][2]string{{"TAN(PI/2)", "LARGE"}},
+	},
+	"sqrt": {
+		specials: []string{
+			"SQRT(XMIN)", "SQRT(1-EPSNEG)", "SQRT(1.0)", "SQRT(1+EPS)", "SQRT(XMAX)",
+		},
+		errors: [][2]string{{"SQRT(0.0)", "0.0"}, {"SQRT(-1.0)", "NaN"}},
+	},
+	"asin": {
+		specials: []string{"ASIN(0.0)", "ASIN(1.0)", "ACOS(0.0)", "ACOS(1.0)"},
+		errors:   [][2]string{{"ASIN(1.2)", "NaN"}},
+	},
+	"atan": {
+		specials: []string{
+			"ATAN(0.0)", "ATAN(1.0)", "ATAN2(1,1)", "ATAN2(1,0)", "ATAN2(0,1)", "ATAN2(-1,0)",
+		},
+		errors: [][2]string{{"ATAN(XMAX)", "PI/2"}, {"ATAN2(0,0)", "0.0"}},
+	},
+	"sinh": {
+		specials: []string{"SINH(0.0)", "COSH(0.0)"},
+		errors:   [][2]string{{"SINH(LOG(XMAX)+2)", "OVERFLOW"}},
+	},
+	"tanh": {
+		specials: []string{"TANH(0.0)", "TANH(20.0)", "TANH(-20.0)"},
+		errors:   [][2]string{{"TANH(XMAX)", "1.0"}},
+	},
+	"power": {
+		specials: []string{"1**0", "0**1", "2**2", "2**10", "10**2"},
+		errors:   [][2]string{{"0**0", "1.0"}, {"(-2)**3.5", "NaN"}, {"XMAX**2", "OVERFLOW"}},
+	},
+	"expm1": {
+		specials: []string{
+			"EXPM1(0.0)", "LOG1P(0.0)", "EXPM1(XMIN)", "LOG1P(XMIN)", "EXPM1(FLOOR(LOG(XMAX)))",
+			"LOG1P(XMAX)",
+		},
+		errors: [][2]string{
+			{"EXPM1(X)", "-1"}, {"EXPM1(X)", "OVERFLOW"}, {"LOG1P(X)", "NaN"}, {"LOG1P(X)", "-Inf"},
+		},
+	},
+	"cbrt": {
+		specials: []string{
+			"CBRT(0.0)", "CBRT(-8.0)", "CBRT(XMIN)", "CBRT(XMAX)", "HYPOT(3.0,4.0)",
+			"HYPOT(XMAX/2,XMAX/2)", "HYPOT(XMIN,XMIN)",
+		},
+		errors: [][2]string{{"HYPOT(XMAX,XMAX)", "OVERFLOW"}, {"HYPOT(INF,NAN)", "+Inf"}},
+	},
+	"asinh": {
+		specials: []string{
+			"ASINH(0.0)", "ACOSH(1.0)", "ATANH(0.0)", "ASINH(XMIN)", "ASINH(XMAX)", "ACOSH(XMAX)",
+		},
+		errors: [][2]string{
+			{"ACOSH(X)", "NaN"}, {"ATANH(X)", "+Inf"}, {"ATANH(X)", "-Inf"}, {"ATANH(X)", "NaN"},
+		},
+	},
+	"gamma": {
+		specials: []string{
+			"GAMMA(1.0)", "GAMMA(0.5)", "GAMMA(-0.5)", "GAMMA(-1.5)", "LGAMMA(-0.5)",
+			"SIGNGAM(-0.5)", "LGAMMA(1.0)", "LGAMMA(2.0)", "GAMMA(XMIN)", "GAMMA(XBIG)",
+			"LGAMMA(XLBIG)",
+		},
+		errors: [][2]string{
+			{"GAMMA(X)", "+Inf"}, {"GAMMA(X)", "NaN"}, {"GAMMA(X)", "OVERFLOW"},
+			{"LGAMMA(X)", "+Inf"}, {"LGAMMA(X)", "OVERFLOW"},
+		},
+	},
+	"erf": {
+		specials: []string{
+			"ERF(0.0)", "ERFC(0.0)", "ERFINV(0.0)", "ERFCINV(1.0)", "ERFINV(0.5)", "ERF(XMIN)",
+			"ERFINV(XMIN)", "ERFCINV(XMIN)", "ERFC(XU)", "ERFC(XU+ULP)", "XMIN", "XU",
+		},
+		errors: [][2]string{
+			{"ERFC(X)", "UNDERFLOW"}, {"ERFINV(X)", "+Inf"}, {"ERFINV(X)", "-Inf"},
+			{"ERFINV(X)", "NaN"}, {"ERFCINV(X)", "+Inf"}, {"ERFCINV(X)", "-Inf"},
+		},
+	},
+}
+
+// Normalize returns the canonical form of an identity or expression, so that
+// the names printed by the single and double precision Fortran programs and by
+// the Go ports compare equal: DEXP and ALOG become EXP and LOG, the D0
+// exponent is dropped from constants, and white space is removed.
+func Normalize(s string) string {
+	s = strings.ToUpper(s)
+	s = reDouble.ReplaceAllString(s, "$1")
+	s = reALog.ReplaceAllString(s, "LOG$1")
+	s = reD0.ReplaceAllString(s, "$1")
+	return reSpace.ReplaceAllString(s, "")
+}
+
+// program returns the test program whose first random argument test is identity.
+func program(identity string) string {
+	id := Normalize(identity)
+	if strings.HasPrefix(id, "X**") || strings.HasPrefix(id, "XSQ**") {
+		return "power"
+	}
+	if m := reLeading.FindStringSubmatch(id); m != nil {
+		return programs[m[1]]
+	}
+	return ""
+}
+
+const (
+	inRandom = iota
+	inSpecial
+	inErrors
+)
+
+// pending is an error return whose value has not been printed yet.
+type pending struct {
+	expr   string
+	expect string
+	args   []float64
+}
+
+type parser struct {
+	reps     []*elefunt.Report
+	rep      *elefunt.Report
+	res      *elefunt.Result
+	section  int
+	interval bool // Next line holds the interval of res
+	ulp      bool // Lines about the reference follow
+	check    string
+	err      *pending
+}
+
+// Parse reads the output of one or more test program runs from r and
+// returns a report for each run. The function of a report is inferred from
+// the identity of its first random argument test, and is empty if that is
+// not one of the identities of the ELEFUNT programs. The printed form of
+// each report is the text it was parsed from.
+func Parse(r io.Reader) ([]*elefunt.Report, error) {
+	var p parser
+	var gap bytes.Buffer // Lines read outside of a run
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		cur := p.rep
+		p.line(clean(sc.Text()))
+		rep := p.rep
+		if rep == nil {
+			rep = cur
+		}
+		if rep == nil {
+			fmt.Fprintln(&gap, sc.Text())
+			continue
+		}
+		gap.WriteTo(rep)
+		fmt.Fprintln(rep, sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	p.endReport()
+	if n := len(p.reps); n > 0 {
+		gap.WriteTo(p.reps[n-1])
+	}
+	if len(p.reps) == 0 {
+		return nil, fmt.Errorf("parser: no test results found")
+	}
+	return p.reps, nil
+}
+
+// clean removes form feeds, carriage returns and Fortran carriage control
+// characters from line and trims the surrounding blanks.
+func clean(line string) string {
+	line = strings.NewReplacer("\f", "", "\r", "").Replace(line)
+	if len(line) > 1 && strings.IndexByte("10+", line[0]) >= 0 && line[1] >= 'A' && line[1] <= 'Z' {
+		line = line[1:]
+	}
+	return strings.TrimSpace(line)
+}
+
+func (p *parser) line(t string) {
+	switch {
+	case t == "":
+		return
+	case strings.HasPrefix(t, "THIS CONCLUDES THE TESTS"):
+		p.endReport()
+		return
+	case strings.HasPrefix(t, "SPECIAL TESTS"):
+		p.endResult()
+		p.section = inSpecial
+		return
+	case strings.HasPrefix(t, "TEST OF ERROR RETURNS"):
+		p.endResult()
+		p.section = inErrors
+		return
+	case notRandom(t):
+		p.check = ""
+		return
+	case strings.HasPrefix(t, "TEST OF "):
+		p.startResult(collapse(strings.TrimPrefix(t, "TEST OF ")))
+		return
+	}
+	switch {
+	case p.res != nil:
+		p.random(t)
+	case p.rep == nil:
+	case p.section == inSpecial:
+		p.special(t)
+	case p.section == inErrors:
+		p.errorReturn(t)
+	}
+}
+
+// notRandom reports whether the heading t starts a part of the special tests.
+func notRandom(t string) bool {
+	return strings.HasPrefix(t, "TEST OF SPECIAL ARGUMENTS") || strings.HasPrefix(t, "TEST OF UNDERFLOW")
+}
+
+func (p *parser) startResult(identity string) {
+	p.endResult()
+	if p.rep != nil && p.section != inRandom {
+		p.endReport()
+	}
+	if p.rep == nil {
+		p.rep = &elefunt.Report{}
+		p.section = inRandom
+	}
+	p.res = &elefunt.Result{Identity: identity}
+	p.interval, p.ulp = false, false
+}
+
+func (p *parser) endResult() {
+	if p.res != nil {
+		p.rep.AddResult(*p.res)
+		p.res = nil
+	}
+}
+
+func (p *parser) endReport() {
+	p.endResult()
+	if p.rep == nil {
+		return
+	}
+	rep := p.rep
+	if len(rep.Tests) > 0 {
+		rep.Function = program(rep.Tests[0].Identity)
+		rep.Precision = precision(rep.Tests[0].IBeta, rep.Tests[0].IT)
+	}
+	// The exp and log programs count a negative error as LARGER
+	if rep.Function == "exp" || rep.Function == "log" {
+		for i := range rep.Tests {
+			rep.Tests[i].Larger, rep.Tests[i].Smaller = rep.Tests[i].Smaller, rep.Tests[i].Larger
+		}
+	}
+	layout(rep)
+	p.reps = append(p.reps, rep)
+	p.rep, p.err, p.check = nil, nil, ""
+	p.section = inRandom
+}
+
+// SetFunction sets the test program of rep, parsed from a printout whose
+// program could not be told from its identities, and gives its special
+// arguments and error returns the expressions of that program.
+func SetFunction(rep *elefunt.Report, function string) {
+	rep.Function = function
+	layout(rep)
+}
+
+// layout gives the special arguments and error returns of rep the
+// expressions and expected behaviors the Go port of its program records,
+// such as SQRT(-1.0) for the error return printed as SQRT WILL BE CALLED
+// WITH THE ARGUMENT -1.0, so that parsed reports match the reports of runs.
+// The printout must have the same special arguments, or error returns, as
+// the port, each naming the same function.
+func layout(rep *elefunt.Report) {
+	l, ok := layouts[rep.Function]
+	if !ok {
+		return
+	}
+	if same(rep.Specials, l.specials) {
+		for i := range rep.Specials {
+			rep.Specials[i].Expr = l.specials[i]
+		}
+	}
+	exprs := make([]string, len(l.errors))
+	for i, e := range l.errors {
+		exprs[i] = e[0]
+	}
+	if same(rep.Errors, exprs) {
+		for i := range rep.Errors {
+			rep.Errors[i].Expr, rep.Errors[i].Expect = l.errors[i][0], l.errors[i][1]
+		}
+	}
+}
+
+// same reports whether the parsed expressions ps are those of the program
+// given by want: as many, and each naming the first function or parameter
+// named in the one it stands for.
+func same(ps []elefunt.Special, want []string) bool {
+	if len(ps) != len(want) {
+		return false
+	}
+	for i, p := range ps {
+		if name := reName.FindString(want[i]); !strings.Contains(Normalize(p.Expr), name) {
+			return false
+		}
+	}
+	return true
+}
+
+// precision returns the precision of floating-point numbers with it base
+// ibeta digits, or "" if it is not one of the common single and double
+// formats: IEEE, VAX and IBM hexadecimal.
+func precision(ibeta, it int) string {
+	switch {
+	case ibeta == 2 && it == 24, ibeta == 16 && it == 6:
+		return elefunt.PrecisionSingle
+	case ibeta == 2 && (it == 53 || it == 56), ibeta == 16 && it == 14:
+		return elefunt.PrecisionDouble
+	}
+	return ""
+}
+
+// random parses a line of a random argument test.
+func (p *parser) random(t string) {
+	res := p.res
+	if m := reTested.FindStringSubmatch(t); m != nil {
+		res.N, _ = strconv.Atoi(m[1])
+		p.interval = true
+		return
+	}
+	if p.interval {
+		if i := strings.Index(t, "WHERE EPS ="); i >= 0 {
+			// (1-EPS,1+EPS), WHERE EPS = 3.9063E-03 in the Fortran log program
+			eps := first(t[i:])
+			res.A, res.B = 1-eps, 1+eps
+		} else if v := numbers(reNumber, t); len(v) >= 2 {
+			res.A, res.B = v[0], v[1]
+		}
+		p.interval = false
+		return
+	}
+	switch {
+	case strings.Contains(t, "WAS LARGER"):
+		res.Larger = atoi(reLarger, t)
+	case reAgreed.MatchString(t):
+		res.Agreed = atoi(reAgreed, t)
+	case reSmaller.MatchString(t):
+		res.Smaller = atoi(reSmaller, t)
+	case reDigits.MatchString(t):
+		m := reDigits.FindStringSubmatch(t)
+		res.IT, _ = strconv.Atoi(m[1])
+		res.IBeta, _ = strconv.Atoi(m[2])
+	case strings.HasPrefix(t, "THE MAXIMUM RELATIVE ERROR OF"):
+		res.MaxError = first(t)
+	case strings.HasPrefix(t, "THE MAXIMUM ERROR AGAINST THE REFERENCE WAS"):
+		p.ulp = true
+		res.ULPN = res.N
+		res.MaxULP = first(t)
+	case strings.HasPrefix(t, "OCCURRED FOR X"):
+		v := numbers(reFloat, t)
+		if len(v) == 0 {
+			return
+		}
+		if p.ulp {
+			res.MaxULPX = v[0]
+			if len(v) > 1 {
+				res.MaxULPY = v[1]
+			}
+			return
+		}
+		res.MaxX = v[0]
+		if len(v) > 1 {
+			res.MaxY = v[1]
+			res.Bivariate = true
+		}
+	case strings.HasPrefix(t, "THE ROOT MEAN SQUARE RELATIVE ERROR WAS"):
+		res.RMSError = first(t)
+	case strings.HasPrefix(t, "THE MEAN ERROR AGAINST THE REFERENCE WAS"):
+		res.MeanULP = first(t)
+	}
+}
+
+// special parses a line of the special tests.
+func (p *parser) special(t string) {
+	if m := reIdentity.FindStringSubmatch(t); m != nil {
+		p.check = identity(m[1])
+		return
+	}
+	if v, ok := row(t); ok {
+		if p.check != "" {
+			p.rep.AddCheck(p.check, v[0], v[len(v)-1])
+		}
+		return
+	}
+	if !reFloat.MatchString(t) {
+		return
+	}
+	p.check = ""
+	// ASIN(1.0) = 1.5708E+00 (should be PI/2 = 1.5708E+00)
+	if i := strings.Index(t, " (should be"); i >= 0 {
+		t = t[:i]
+	}
+	lhs, rhs := t, t
+	if i := strings.Index(t, "="); i >= 0 {
+		lhs, rhs = t[:i], t[strings.LastIndex(t, "=")+1:]
+		if !reFloat.MatchString(rhs) && !reNumber.MatchString(rhs) {
+			rhs = t
+		}
+	}
+	value := first(rhs)
+	var args []float64
+	if lhs != t {
+		args = numbers(reFloat, t[:strings.LastIndex(t, "=")])
+	}
+	p.rep.AddSpecial(expr(lhs), value, args...)
+}
+
+// errorReturn parses a line of the tests of error returns.
+func (p *parser) errorReturn(t string) {
+	switch {
+	case strings.Contains(t, "WILL BE CALLED WITH THE ARGUMENT"):
+		name := strings.Fields(t)[0]
+		if name == "THE" {
+			// THE FUNCTION DTANH WILL BE CALLED WITH THE ARGUMENT
+			name = strings.Fields(t)[2]
+		}
+		p.err = &pending{expr: name + "(X)", args: numbers(reFloat, t)}
+	case strings.Contains(t, "WILL BE COMPUTED"):
+		lhs := t[:strings.Index(t, "WILL BE COMPUTED")]
+		p.err = &pending{expr: expr(lhs), args: numbers(reFloat, lhs)}
+	case strings.Contains(t, "=") && p.err == nil:
+		// An error return printed on a single line, such as ATAN2(0,0) = 0
+		i := strings.LastIndex(t, "=")
+		p.err = &pending{expr: expr(t[:strings.Index(t, "=")]), args: numbers(reFloat, t[:i])}
+		p.endError(first(t[i+1:]))
+	case p.err == nil:
+	case strings.HasPrefix(t, "THIS SHOULD "):
+		p.err.expect = expectation(strings.TrimSuffix(strings.TrimPrefix(t, "THIS SHOULD "), "."))
+	case strings.Contains(t, "RETURNED THE VALUE"):
+		p.endError(first(t[strings.Index(t, "RETURNED THE VALUE")+len("RETURNED THE VALUE"):]))
+	case strings.Contains(t, "VALUE RETURNED IS"):
+		p.endError(first(t[strings.Index(t, "VALUE RETURNED IS")+len("VALUE RETURNED IS"):]))
+	case strings.Contains(t, "="):
+		p.endError(first(t[strings.LastIndex(t, "=")+1:]))
+	default:
+		if v, ok := row(t); ok {
+			p.err.args = append(p.err.args, v...)
+		}
+	}
+}
+
+func (p *parser) endError(value float64) {
+	p.rep.AddError(p.err.expr, p.err.expect, value, p.err.args...)
+	p.err = nil
+}
+
+// expectation returns the expected behavior of an error return printed as
+// THIS SHOULD s in the form the programs record it: the value of RETURN
+// NaN, or the first word of OVERFLOW or UNDERFLOW followed by a remark.
+// Other behaviors are kept as printed.
+func expectation(s string) string {
+	if m := reReturn.FindStringSubmatch(s); m != nil {
+		return m[1]
+	}
+	if f := strings.Fields(s); len(f) > 0 && (f[0] == "OVERFLOW" || f[0] == "UNDERFLOW") {
+		return f[0]
+	}
+	return s
+}
+
+// identity returns the identity of a special test printed as s, with the
+// blanks before commas and the comma ending the printed sentence dropped.
+func identity(s string) string {
+	s = strings.ReplaceAll(collapse(s), " ,", ",")
+	return strings.TrimSuffix(s, ",")
+}
+
+// expr returns s with the printed arguments replaced by X.
+func expr(s string) string {
+	return collapse(reFloat.ReplaceAllString(s, "X"))
+}
+
+// collapse trims s and reduces each run of blanks to one.
+func collapse(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// row returns the numbers of a line holding nothing but printed numbers.
+func row(t string) ([]float64, bool) {
+	fs := strings.Fields(t)
+	if len(fs) < 2 {
+		return nil, false
+	}
+	for _, f := range fs {
+		if reFloat.FindString(f) != f {
+			return nil, false
+		}
+	}
+	return numbers(reFloat, t), true
+}
+
+func numbers(re *regexp.Regexp, t string) []float64 {
+	var v []float64
+	for _, s := range re.FindAllString(t, -1) {
+		v = append(v, parseFloat(s))
+	}
+	return v
+}
+
+// first returns the first number in t, preferring one printed with an
+// exponent, or NaN if there is none.
+func first(t string) float64 {
+	if s := reFloat.FindString(t); s != "" {
+		return parseFloat(s)
+	}
+	if s := reNumber.FindString(t); s != "" {
+		return parseFloat(s)
+	}
+	return math.NaN()
+}
+
+func atoi(re *regexp.Regexp, t string) int {
+	n, _ := strconv.Atoi(re.FindStringSubmatch(t)[1])
+	return n
+}
+
+// parseFloat parses a printed number, restoring the E that Fortran drops
+// from three digit exponents. Out of range values, such as XMAX rounded up
+// when printed, become infinite.
+func parseFloat(s string) float64 {
+	s = strings.NewReplacer("D", "E", "d", "E", "e", "E").Replace(s)
+	if i := strings.LastIndexAny(s, "+-"); i > 0 && s[i-1] != 'E' {
+		s = s[:i] + "E" + s[i:]
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return math.NaN()
+	}
+	return v
+}
@@ -0,0 +1,384 @@
+package parser_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golefunt/elefunt"
+	"golefunt/parser"
+	"golefunt/suite"
+)
+
+// run runs the named test program and returns its report.
+func run(t *testing.T, name, precision string) *elefunt.Report {
+	t.Helper()
+	test, ok := suite.Lookup(name)
+	if !ok {
+		t.Fatalf("no test program %q", name)
+	}
+	rep := elefunt.NewReport(name, "", "")
+	test.Run(rep, precision, suite.Options{N: 200})
+	return rep
+}
+
+// near reports whether got is want printed with digits significant digits.
+func near(got, want float64, digits int) bool {
+	switch {
+	case math.IsNaN(want):
+		return math.IsNaN(got)
+	case math.IsInf(want, 0) || want == 0:
+		return got == want
+	}
+	return math.Abs(got-want) <= math.Abs(want)*math.Pow(10, float64(1-digits))
+}
+
+// roundTrip parses the printed form of rep and compares the result with
+// the values rep recorded, to the precision they are printed with.
+func roundTrip(t *testing.T, rep *elefunt.Report) {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := rep.Encode(&buf, elefunt.FormatText); err != nil {
+		t.Fatal(err)
+	}
+	reps, err := parser.Parse(&buf)
+	if err != nil {
+		t.Fatalf("%s: %v", rep.Function, err)
+	}
+	if len(reps) != 1 {
+		t.Fatalf("%s: parsed %d reports, want 1", rep.Function, len(reps))
+	}
+	got := reps[0]
+	if got.Function != rep.Function || got.Precision != rep.Precision {
+		t.Errorf("parsed %s %s, want %s %s", got.Precision, got.Function, rep.Precision, rep.Function)
+	}
+
+	if len(got.Tests) != len(rep.Tests) {
+		t.Fatalf("%s: parsed %d tests, want %d", rep.Function, len(got.Tests), len(rep.Tests))
+	}
+	for i, g := range got.Tests {
+		w := rep.Tests[i]
+		if g.Identity != w.Identity || g.N != w.N || g.IT != w.IT || g.IBeta != w.IBeta ||
+			g.Larger != w.Larger || g.Agreed != w.Agreed || g.Smaller != w.Smaller ||
+			g.Bivariate != w.Bivariate {
+			t.Errorf("%s test %d: parsed\n%+v\nwant\n%+v", rep.Function, i+1, g, w)
+			continue
+		}
+		if !near(g.A, w.A, 5) || !near(g.B, w.B, 5) || !near(g.MaxError, w.MaxError, 5) ||
+			!near(g.RMSError, w.RMSError, 5) || !near(g.MaxX, w.MaxX, 7) || !near(g.MaxY, w.MaxY, 7) {
+			t.Errorf("%s test %d: parsed\n%+v\nwant\n%+v", rep.Function, i+1, g, w)
+		}
+	}
+
+	if len(got.Checks) != len(rep.Checks) {
+		t.Fatalf("%s: parsed %d checks, want %d", rep.Function, len(got.Checks), len(rep.Checks))
+	}
+	for i, g := range got.Checks {
+		w := rep.Checks[i]
+		if g.Identity != w.Identity {
+			t.Errorf("%s check %d: parsed identity %q, want %q", rep.Function, i+1, g.Identity, w.Identity)
+		}
+		if len(g.Samples) != len(w.Samples) {
+			t.Errorf("%s check %q: parsed %d samples, want %d", rep.Function, w.Identity, len(g.Samples), len(w.Samples))
+			continue
+		}
+		for j, s := range g.Samples {
+			if !near(float64(s.X), float64(w.Samples[j].X), 8) || !near(float64(s.Value), float64(w.Samples[j].Value), 8) {
+				t.Errorf("%s check %q row %d: parsed %v, want %v", rep.Function, w.Identity, j+1, s, w.Samples[j])
+			}
+		}
+	}
+
+	compareSpecials(t, rep.Function+" special argument", got.Specials, rep.Specials)
+	compareSpecials(t, rep.Function+" error return", got.Errors, rep.Errors)
+}
+
+func compareSpecials(t *testing.T, what string, got, want []elefunt.Special) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("parsed %d %ss, want %d", len(got), what, len(want))
+		return
+	}
+	for i, g := range got {
+		w := want[i]
+		// Values are printed with at least 5 significant digits
+		if g.Expr != w.Expr || g.Expect != w.Expect || !near(float64(g.Value), float64(w.Value), 5) {
+			t.Errorf("%s %d: parsed %s = %v (%q), want %s = %v (%q)", what, i+1, g.Expr, g.Value, g.Expect, w.Expr, w.Value, w.Expect)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, test := range suite.Tests() {
+		roundTrip(t, run(t, test.Name, elefunt.PrecisionDouble))
+		roundTrip(t, run(t, test.Name, elefunt.PrecisionSingle))
+	}
+}
+
+func TestConcatenated(t *testing.T) {
+	var buf bytes.Buffer
+	for _, name := range []string{"exp", "power", "tan"} {
+		run(t, name, elefunt.PrecisionDouble).Encode(&buf, elefunt.FormatText)
+	}
+	reps, err := parser.Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, rep := range reps {
+		names = append(names, rep.Function)
+	}
+	if got := strings.Join(names, " "); got != "exp power tan" {
+		t.Errorf("parsed reports for %s, want exp power tan", got)
+	}
+}
+
+// failingReader returns some text and then an error.
+type failingReader struct {
+	r   io.Reader
+	err error
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	if err == io.EOF {
+		return n, f.err
+	}
+	return n, err
+}
+
+func TestMalformed(t *testing.T) {
+	errRead := errors.New("read failed")
+	var exp bytes.Buffer
+	run(t, "exp", elefunt.PrecisionDouble).Encode(&exp, elefunt.FormatText)
+
+	tests := []struct {
+		desc string
+		r    io.Reader
+		err  error // nil for any error
+	}{
+		{"empty", strings.NewReader(""), nil},
+		{"no results", strings.NewReader("THIS IS NOT AN ELEFUNT PRINTOUT\n\n THIS CONCLUDES THE TESTS\n"), nil},
+		{"long line", strings.NewReader("TEST OF EXP\n" + strings.Repeat("X", bufio.MaxScanTokenSize+1)), bufio.ErrTooLong},
+		{"read error", &failingReader{bytes.NewReader(exp.Bytes()), errRead}, errRead},
+	}
+	for _, tt := range tests {
+		reps, err := parser.Parse(tt.r)
+		if err == nil {
+			t.Errorf("%s: parsed %d reports, want an error", tt.desc, len(reps))
+			continue
+		}
+		if tt.err != nil && !errors.Is(err, tt.err) {
+			t.Errorf("%s: error %v, want %v", tt.desc, err, tt.err)
+		}
+	}
+}
+
+func TestTruncated(t *testing.T) {
+	var buf bytes.Buffer
+	run(t, "exp", elefunt.PrecisionDouble).Encode(&buf, elefunt.FormatText)
+	text := buf.String()
+
+	// A printout cut off during its second random argument test keeps the
+	// first test and the part of the second that was printed.
+	i := strings.Index(text, "TEST OF")
+	i += strings.Index(text[i+1:], "TEST OF") + 1
+	i += strings.Index(text[i:], "WAS LARGER")
+	reps, err := parser.Parse(strings.NewReader(text[:i]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reps) != 1 || len(reps[0].Tests) != 2 || reps[0].Function != "exp" {
+		t.Fatalf("parsed %d reports, want 1 report of exp with 2 tests", len(reps))
+	}
+	if r := reps[0].Tests[1]; r.N != 200 || r.Larger+r.Agreed+r.Smaller != 0 || r.IT != 0 {
+		t.Errorf("truncated test parsed as %+v", r)
+	}
+	if len(reps[0].Checks)+len(reps[0].Specials)+len(reps[0].Errors) != 0 {
+		t.Errorf("truncated printout has special tests")
+	}
+}
+
+func TestUnknownIdentity(t *testing.T) {
+	text := "TEST OF FOO(X) VS BAR(X)\n\n   2000 RANDOM ARGUMENTS WERE TESTED FROM THE INTERVAL\n" +
+		"      ( 0.0000E+00,  1.0000E+00)\n\n THIS CONCLUDES THE TESTS\n"
+	reps, err := parser.Parse(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reps) != 1 || reps[0].Function != "" || reps[0].Tests[0].N != 2000 || reps[0].Tests[0].B != 1 {
+		t.Errorf("parsed %+v", reps[0])
+	}
+}
+
+// fortran reads a printout of a Fortran program from testdata. The
+// printouts are laid out by the FORMAT statements of the programs as
+// gfortran prints them: carriage control characters in the first column,
+// E edit descriptors that drop the E from three digit exponents, and
+// Infinity and NaN for the values IEEE arithmetic returns.
+func fortran(t *testing.T, name string) string {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func parseOne(t *testing.T, text string) *elefunt.Report {
+	t.Helper()
+	reps, err := parser.Parse(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reps) != 1 {
+		t.Fatalf("parsed %d reports, want 1", len(reps))
+	}
+	return reps[0]
+}
+
+// want is the part of a parsed report the Fortran tests check.
+type want struct {
+	function string
+	tests    []elefunt.Result // Identity, A, B, counts, MaxError, MaxX and RMSError
+	check    string
+	specials []elefunt.Special
+	errors   []elefunt.Special
+}
+
+func checkFortran(t *testing.T, name string, rep *elefunt.Report, w want) {
+	t.Helper()
+	if rep.Function != w.function || rep.Precision != elefunt.PrecisionDouble {
+		t.Errorf("%s: parsed %s %s, want double %s", name, rep.Precision, rep.Function, w.function)
+	}
+	if len(rep.Tests) != len(w.tests) {
+		t.Fatalf("%s: parsed %d tests, want %d", name, len(rep.Tests), len(w.tests))
+	}
+	for i, g := range rep.Tests {
+		r := w.tests[i]
+		if g.Identity != r.Identity || g.N != 2000 || g.IBeta != 2 || g.IT != 53 ||
+			g.Larger != r.Larger || g.Agreed != r.Agreed || g.Smaller != r.Smaller ||
+			!near(g.A, r.A, 4) || !near(g.B, r.B, 4) || !near(g.MaxError, r.MaxError, 4) ||
+			!near(g.MaxX, r.MaxX, 6) || !near(g.RMSError, r.RMSError, 4) {
+			t.Errorf("%s test %d: parsed\n%+v\nwant\n%+v", name, i+1, g, r)
+		}
+	}
+	if len(rep.Checks) != 1 || rep.Checks[0].Identity != w.check || len(rep.Checks[0].Samples) != 5 {
+		t.Errorf("%s: parsed checks %+v, want 5 rows of %s", name, rep.Checks, w.check)
+	}
+	compare := func(what string, got, want []elefunt.Special) {
+		if len(got) != len(want) {
+			t.Errorf("%s: parsed %d %ss, want %d", name, len(got), what, len(want))
+			return
+		}
+		for i, g := range got {
+			w := want[i]
+			ok := g.Expr == w.Expr && g.Expect == w.Expect && near(float64(g.Value), float64(w.Value), 6) &&
+				len(g.Args) == len(w.Args)
+			for j := 0; ok && j < len(w.Args); j++ {
+				ok = near(float64(g.Args[j]), float64(w.Args[j]), 4)
+			}
+			if !ok {
+				t.Errorf("%s %s %d: parsed %+v, want %+v", name, what, i+1, g, w)
+			}
+		}
+	}
+	compare("special argument", rep.Specials, w.specials)
+	compare("error return", rep.Errors, w.errors)
+}
+
+func args(v ...float64) []elefunt.Float {
+	a := make([]elefunt.Float, len(v))
+	for i, x := range v {
+		a[i] = elefunt.Float(x)
+	}
+	return a
+}
+
+var (
+	inf = elefunt.Float(math.Inf(1))
+	nan = elefunt.Float(math.NaN())
+
+	// The exp and log programs print the count of negative errors as
+	// LARGER, which the reports record as Smaller.
+	dexp = want{
+		function: "exp",
+		tests: []elefunt.Result{
+			{Identity: "DEXP(X- 0.0625) VS DEXP(X)/DEXP( 0.0625)", A: -0.2841, B: 0.3466,
+				Larger: 317, Agreed: 1388, Smaller: 295, MaxError: 0.3489e-15, MaxX: 0.303765, RMSError: 0.9085e-16},
+			{Identity: "DEXP(X- 2.8125) VS DEXP(X)/DEXP( 2.8125)", A: -3.466, B: -670.3,
+				Larger: 311, Agreed: 1393, Smaller: 296, MaxError: 0.3472e-15, MaxX: -423.227, RMSError: 0.9033e-16},
+			{Identity: "DEXP(X- 2.8125) VS DEXP(X)/DEXP( 2.8125)", A: 6.931, B: 709.7,
+				Larger: 277, Agreed: 1358, Smaller: 365, MaxError: 0.3282e-15, MaxX: 531.293, RMSError: 0.9240e-16},
+		},
+		check: "DEXP(X)*DEXP(-X) = 1.0",
+		specials: []elefunt.Special{
+			{Expr: "EXP(0.0) - 1.0", Value: 0},
+			{Expr: "EXP(FLOOR(LOG(XMIN)))", Args: args(-708), Value: 0.330755e-307},
+			{Expr: "EXP(FLOOR(LOG(XMAX)))", Args: args(709), Value: 0.821841e308},
+			{Expr: "EXP(X)", Args: args(354.5), Value: 0.906554e154},
+			{Expr: "EXP(X/2)**2", Args: args(177.25), Value: 0.906554e154},
+		},
+		errors: []elefunt.Special{
+			{Expr: "EXP(X)", Args: args(-0.6704e154), Value: 0, Expect: "UNDERFLOW"},
+			{Expr: "EXP(X)", Args: args(0.6704e154), Value: inf, Expect: "OVERFLOW"},
+		},
+	}
+	dlog = want{
+		function: "log",
+		tests: []elefunt.Result{
+			{Identity: "DLOG(X) VS T.S. EXPANSION OF DLOG(1+Y)", A: 1 - 0.3906e-2, B: 1 + 0.3906e-2,
+				Larger: 67, Agreed: 912, Smaller: 1021, MaxError: 0.1377e-15, MaxX: 1.00014, RMSError: 0.4401e-16},
+			{Identity: "DLOG(X) VS DLOG(17X/16)-DLOG(17/16)", A: 0.7071, B: 0.9375,
+				Larger: 436, Agreed: 868, Smaller: 696, MaxError: 0.2168e-14, MaxX: 0.970054, RMSError: 0.2495e-15},
+			{Identity: "DLOG10(X) VS DLOG10(11X/10)-DLOG10(11/10)", A: 0.3162, B: 0.9,
+				Larger: 1497, Agreed: 470, Smaller: 33, MaxError: 0.2630e-12, MaxX: 0.800046, RMSError: 0.1022e-13},
+			{Identity: "DLOG(X*X) VS 2 * LOG(X)", A: 16, B: 240,
+				Larger: 668, Agreed: 738, Smaller: 594, MaxError: 0.5912e-15, MaxX: 131.996, RMSError: 0.1603e-15},
+		},
+		check: "DLOG(X) = -DLOG(1/X)",
+		specials: []elefunt.Special{
+			{Expr: "LOG(1.0)", Value: 0},
+			{Expr: "LOG(XMIN)", Args: args(0.2225074e-307), Value: -708.3964},
+			{Expr: "LOG(XMAX)", Args: args(0.1797693e309), Value: 709.7827},
+		},
+		errors: []elefunt.Special{
+			{Expr: "LOG(X)", Args: args(-2), Value: nan, Expect: "NaN"},
+			{Expr: "LOG(X)", Args: args(0), Value: -inf, Expect: "-Inf"},
+		},
+	}
+)
+
+func TestFortran(t *testing.T) {
+	checkFortran(t, "dexp", parseOne(t, fortran(t, "dexp.out")), dexp)
+	checkFortran(t, "dlog", parseOne(t, fortran(t, "dlog.out")), dlog)
+}
+
+func TestFortranDExponents(t *testing.T) {
+	// Some compilers print double precision values with a D exponent
+	d := strings.NewReplacer("E+", "D+", "E-", "D-").Replace(fortran(t, "dexp.out"))
+	if !strings.Contains(d, "0.3489D-15") {
+		t.Fatal("no D exponents in the printout")
+	}
+	checkFortran(t, "dexp with D exponents", parseOne(t, d), dexp)
+}
+
+func TestFortranConcatenated(t *testing.T) {
+	// Form feeds and carriage returns, as a printout file may have them
+	text := strings.ReplaceAll(fortran(t, "dexp.out")+"\f"+fortran(t, "dlog.out"), "\n", "\r\n")
+	reps, err := parser.Parse(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reps) != 2 {
+		t.Fatalf("parsed %d reports, want 2", len(reps))
+	}
+	checkFortran(t, "dexp", reps[0], dexp)
+	checkFortran(t, "dlog", reps[1], dlog)
+}
@@ -0,0 +1,211 @@
+package plot
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+)
+
+// Dimensions of a heatmap, in pixels.
+const (
+	mapSize    = 600 // Side of the plotting area
+	mapRight   = 130 // Room for the color bar
+	barWidth   = 20
+	pngMinSize = 512 // Smallest longer side of a PNG heatmap
+)
+
+// stops are the colors of the scale from the smallest value to the largest,
+// evenly spaced, after the viridis scale.
+var stops = []color.RGBA{
+	{68, 1, 84, 255},
+	{59, 82, 139, 255},
+	{33, 145, 140, 255},
+	{94, 201, 98, 255},
+	{253, 231, 37, 255},
+}
+
+// offScale is the color of non-finite values.
+var offScale = color.RGBA{0, 0, 0, 255}
+
+// Heatmap is a chart of values over a rectangle divided into NX by NY
+// cells. Values[j*NX+i] is the value of the i-th cell from XA in the j-th
+// row from YA.
+type Heatmap struct {
+	Title  string
+	XLabel string
+	YLabel string
+	Label  string // Name of the values, shown by the color bar
+	XA, XB float64
+	YA, YB float64
+	NX, NY int
+	Values []float64
+	Log    bool // Color the values by their logarithm; values at or below zero take the lowest color
+}
+
+// scale maps the values of a heatmap to colors.
+type scale struct {
+	lo, hi float64 // Range of the transformed values
+	log    bool
+}
+
+func (h Heatmap) scale() scale {
+	s := scale{math.Inf(1), math.Inf(-1), h.Log}
+	for _, v := range h.Values {
+		if t := s.transform(v); finite(t) {
+			s.lo, s.hi = math.Min(s.lo, t), math.Max(s.hi, t)
+		}
+	}
+	if s.lo > s.hi {
+		s.lo, s.hi = 0, 1
+	}
+	s.lo, s.hi = widen(s.lo, s.hi)
+	return s
+}
+
+// transform returns the value of v on the color axis, NaN for values that
+// have no color of the scale.
+func (s scale) transform(v float64) float64 {
+	switch {
+	case !finite(v):
+		return math.NaN()
+	case !s.log:
+		return v
+	case v <= 0:
+		return math.Inf(-1)
+	}
+	return math.Log10(v)
+}
+
+func (s scale) color(v float64) color.RGBA {
+	t := s.transform(v)
+	switch {
+	case math.IsNaN(t):
+		return offScale
+	case math.IsInf(t, -1):
+		return stops[0]
+	}
+	return ramp((t - s.lo) / (s.hi - s.lo))
+}
+
+// ramp returns the color at the fraction f of the scale.
+func ramp(f float64) color.RGBA {
+	f = math.Max(0, math.Min(1, f)) * float64(len(stops)-1)
+	i := min(int(f), len(stops)-2)
+	f -= float64(i)
+	a, b := stops[i], stops[i+1]
+	mix := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + f*(float64(b)-float64(a))))
+	}
+	return color.RGBA{mix(a.R, b.R), mix(a.G, b.G), mix(a.B, b.B), 255}
+}
+
+// ticks returns round values of the color axis; powers of ten for a
+// logarithmic scale wide enough to hold two of them.
+func (s scale) ticks() []float64 {
+	if !s.log || s.hi-s.lo < 1 {
+		return ticks(s.lo, s.hi)
+	}
+	var ts []float64
+	for e := math.Ceil(s.lo); e <= s.hi; e++ {
+		ts = append(ts, e)
+	}
+	return ts
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// WriteHeatmapSVG writes h as an SVG chart with axes and a color bar.
+func WriteHeatmapSVG(w io.Writer, h Heatmap) error {
+	var b bytes.Buffer
+	s := h.scale()
+	left, top := float64(marginLeft), float64(headerSize+marginTop)
+	right, bottom := left+mapSize, top+mapSize
+	wd, ht := int(right)+mapRight, int(bottom)+marginBelow
+	f := frame{h.XA, h.XB, h.YA, h.YB}
+	px := func(x float64) float64 { return left + (x-f.x0)/(f.x1-f.x0)*mapSize }
+	py := func(y float64) float64 { return bottom - (y-f.y0)/(f.y1-f.y0)*mapSize }
+
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="monospace" font-size="11">`+"\n",
+		wd, ht, wd, ht)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="white"/>`+"\n", wd, ht)
+	fmt.Fprintf(&b, `<text x="%d" y="24" font-size="15" font-weight="bold">%s</text>`+"\n", marginLeft, escape(h.Title))
+
+	// Cells, drawn a little oversized so that no seams show between them
+	cw, ch := mapSize/float64(h.NX), mapSize/float64(h.NY)
+	b.WriteString(`<g shape-rendering="crispEdges">` + "\n")
+	for j := 0; j < h.NY; j++ {
+		for i := 0; i < h.NX; i++ {
+			fmt.Fprintf(&b, `<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f" fill="%s"/>`+"\n",
+				left+float64(i)*cw, bottom-float64(j+1)*ch, cw+0.5, ch+0.5, hex(s.color(h.Values[j*h.NX+i])))
+		}
+	}
+	b.WriteString("</g>\n")
+
+	// Axes
+	for _, t := range ticks(math.Min(f.x0, f.x1), math.Max(f.x0, f.x1)) {
+		x := px(t)
+		fmt.Fprintf(&b, `<line x1="%.2f" y1="%g" x2="%.2f" y2="%g" stroke="black"/>`+"\n", x, bottom, x, bottom+5)
+		fmt.Fprintf(&b, `<text x="%.2f" y="%g" text-anchor="middle">%s</text>`+"\n", x, bottom+17, label(t))
+	}
+	for _, t := range ticks(math.Min(f.y0, f.y1), math.Max(f.y0, f.y1)) {
+		y := py(t)
+		fmt.Fprintf(&b, `<line x1="%g" y1="%.2f" x2="%g" y2="%.2f" stroke="black"/>`+"\n", left-5, y, left, y)
+		fmt.Fprintf(&b, `<text x="%g" y="%.2f" text-anchor="end">%s</text>`+"\n", left-8, y+4, label(t))
+	}
+	fmt.Fprintf(&b, `<rect x="%g" y="%g" width="%d" height="%d" fill="none" stroke="black"/>`+"\n", left, top, mapSize, mapSize)
+	fmt.Fprintf(&b, `<text x="%g" y="%g" text-anchor="middle">%s</text>`+"\n", (left+right)/2, bottom+37, escape(h.XLabel))
+	fmt.Fprintf(&b, `<text x="15" y="%g" text-anchor="middle" transform="rotate(-90 15 %g)">%s</text>`+"\n",
+		(top+bottom)/2, (top+bottom)/2, escape(h.YLabel))
+
+	// Color bar
+	bx := right + 25
+	b.WriteString(`<defs><linearGradient id="scale" x1="0" y1="1" x2="0" y2="0">`)
+	for i, c := range stops {
+		fmt.Fprintf(&b, `<stop offset="%g" stop-color="%s"/>`, float64(i)/float64(len(stops)-1), hex(c))
+	}
+	b.WriteString("</linearGradient></defs>\n")
+	fmt.Fprintf(&b, `<rect x="%g" y="%g" width="%d" height="%d" fill="url(#scale)" stroke="black"/>`+"\n", bx, top, barWidth, mapSize)
+	for _, t := range s.ticks() {
+		y := bottom - (t-s.lo)/(s.hi-s.lo)*mapSize
+		v := t
+		if s.log {
+			v = math.Pow(10, t)
+		}
+		fmt.Fprintf(&b, `<line x1="%g" y1="%.2f" x2="%g" y2="%.2f" stroke="black"/>`+"\n", bx+barWidth, y, bx+barWidth+5, y)
+		fmt.Fprintf(&b, `<text x="%g" y="%.2f">%s</text>`+"\n", bx+barWidth+8, y+4, label(v))
+	}
+	fmt.Fprintf(&b, `<text x="%g" y="%g" text-anchor="middle" transform="rotate(-90 %g %g)">%s</text>`+"\n",
+		bx-8, (top+bottom)/2, bx-8, (top+bottom)/2, escape(h.Label))
+	fmt.Fprintf(&b, `<rect x="%g" y="%g" width="10" height="10" fill="%s"/>`+"\n", bx, bottom+10, hex(offScale))
+	fmt.Fprintf(&b, `<text x="%g" y="%g">NON-FINITE</text>`+"\n", bx+14, bottom+19)
+
+	b.WriteString("</svg>\n")
+	_, err := b.WriteTo(w)
+	return err
+}
+
+// WriteHeatmapPNG writes the cells of h as a PNG image, with y increasing
+// upward, each cell a square of pixels so that the image is at least 512
+// pixels on its longer side. The image has no axes or labels.
+func WriteHeatmapPNG(w io.Writer, h Heatmap) error {
+	s := h.scale()
+	k := max(1, (pngMinSize+max(h.NX, h.NY)-1)/max(h.NX, h.NY))
+	img := image.NewRGBA(image.Rect(0, 0, h.NX*k, h.NY*k))
+	for j := 0; j < h.NY; j++ {
+		for i := 0; i < h.NX; i++ {
+			c := s.color(h.Values[j*h.NX+i])
+			for py := (h.NY - 1 - j) * k; py < (h.NY-j)*k; py++ {
+				for px := i * k; px < (i+1)*k; px++ {
+					img.SetRGBA(px, py, c)
+				}
+			}
+		}
+	}
+	return png.Encode(w, img)
+}
@@ -0,0 +1,133 @@
+package plot
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		h      Heatmap
+		lo, hi float64
+		ticks  []float64
+	}{
+		{Heatmap{Values: []float64{0, 0.5, 1, math.NaN()}}, 0, 1, []float64{0, 0.2, 0.4, 0.6, 0.8, 1}},
+		{Heatmap{Values: []float64{3, 3}}, 1.5, 4.5, []float64{2, 3, 4}},
+		{Heatmap{Values: []float64{math.NaN(), math.Inf(1)}}, 0, 1, []float64{0, 0.2, 0.4, 0.6, 0.8, 1}},
+		{Heatmap{Values: []float64{0.01, 0, -1, 1000}, Log: true}, -2, 3, []float64{-2, -1, 0, 1, 2, 3}},
+		{Heatmap{Values: []float64{2, 5}, Log: true}, math.Log10(2), math.Log10(5), nil},
+	}
+	for _, tt := range tests {
+		s := tt.h.scale()
+		if math.Abs(s.lo-tt.lo) > 1e-12 || math.Abs(s.hi-tt.hi) > 1e-12 {
+			t.Errorf("scale of %g: %g to %g, want %g to %g", tt.h.Values, s.lo, s.hi, tt.lo, tt.hi)
+		}
+		if tt.ticks == nil {
+			// Too narrow for two powers of ten: linear ticks of the logarithm.
+			tt.ticks = ticks(s.lo, s.hi)
+		}
+		got := s.ticks()
+		if len(got) != len(tt.ticks) {
+			t.Errorf("ticks of %g = %g, want %g", tt.h.Values, got, tt.ticks)
+			continue
+		}
+		for i, v := range got {
+			if math.Abs(v-tt.ticks[i]) > 1e-9 {
+				t.Errorf("ticks of %g = %g, want %g", tt.h.Values, got, tt.ticks)
+				break
+			}
+		}
+	}
+}
+
+func TestColor(t *testing.T) {
+	s := scale{lo: -2, hi: 2, log: true}
+	tests := []struct {
+		v    float64
+		want color.RGBA
+	}{
+		{0.01, stops[0]},
+		{100, stops[len(stops)-1]},
+		{1, stops[2]},
+		{1e-9, stops[0]}, // Below the scale
+		{1e9, stops[len(stops)-1]},
+		{0, stops[0]},
+		{-3, stops[0]},
+		{math.NaN(), offScale},
+		{math.Inf(1), offScale},
+		{math.Inf(-1), offScale},
+	}
+	for _, tt := range tests {
+		if got := s.color(tt.v); got != tt.want {
+			t.Errorf("color of %g = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+	if got, want := hex(stops[0]), "#440154"; got != want {
+		t.Errorf("hex(%v) = %s, want %s", stops[0], got, want)
+	}
+}
+
+// testHeatmap returns a 3 by 2 heatmap whose values grow along x, with
+// one non-finite cell in the upper row.
+func testHeatmap() Heatmap {
+	return Heatmap{
+		Title: `pow <x> & "y"`, XLabel: "X", YLabel: "Y", Label: "ULPs",
+		XA: 0, XB: 3, YA: -1, YB: 1, NX: 3, NY: 2,
+		Values: []float64{0, 1, 2, 0, math.NaN(), 2},
+	}
+}
+
+func TestWriteHeatmapSVG(t *testing.T) {
+	h := testHeatmap()
+	var b bytes.Buffer
+	if err := WriteHeatmapSVG(&b, h); err != nil {
+		t.Fatal(err)
+	}
+	wellFormed(t, b.Bytes())
+	svg := b.String()
+	for c, want := range map[color.RGBA]int{stops[0]: 2, stops[2]: 1, stops[4]: 2} {
+		if n := strings.Count(svg, `fill="`+hex(c)+`"`); n != want {
+			t.Errorf("%d cells of color %s, want %d", n, hex(c), want)
+		}
+	}
+	// The non-finite cell and the legend.
+	if n := strings.Count(svg, `fill="`+hex(offScale)+`"`); n != 2 {
+		t.Errorf("%d shapes of color %s, want 2", n, hex(offScale))
+	}
+	if strings.Contains(svg, "NaN") || strings.Contains(svg, "Inf") {
+		t.Errorf("non-finite coordinates in the heatmap:\n%s", svg)
+	}
+}
+
+func TestWriteHeatmapPNG(t *testing.T) {
+	h := testHeatmap()
+	var b bytes.Buffer
+	if err := WriteHeatmapPNG(&b, h); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Cells of 171 pixels make the 3 cells wide image at least 512 pixels.
+	k := 171
+	if r := img.Bounds(); r.Dx() != 3*k || r.Dy() != 2*k {
+		t.Fatalf("image of %d by %d pixels, want %d by %d", r.Dx(), r.Dy(), 3*k, 2*k)
+	}
+	s := h.scale()
+	for j := 0; j < h.NY; j++ {
+		for i := 0; i < h.NX; i++ {
+			want := s.color(h.Values[j*h.NX+i])
+			// Row j from YA is drawn upward from the bottom of the image.
+			for _, p := range [][2]int{{i * k, (h.NY - 1 - j) * k}, {(i+1)*k - 1, (h.NY-j)*k - 1}} {
+				if got := color.RGBAModel.Convert(img.At(p[0], p[1])); got != want {
+					t.Errorf("cell (%d, %d) at %v has color %v, want %v", i, j, p, got, want)
+				}
+			}
+		}
+	}
+}
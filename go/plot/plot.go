@@ -0,0 +1,207 @@
+// Package plot draws charts of errors as self-contained SVG documents,
+// using only the standard library, so they can be kept as CI artifacts or
+// opened in any browser. Heatmaps can also be written as PNG images.
+package plot
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+)
+
+// MaxPoints is the largest number of points of a panel drawn one by one.
+// Panels with more points are drawn as the range of the values in each
+// column of pixels, which keeps the document small for any number of points.
+const MaxPoints = 4000
+
+// Dimensions of a chart, in pixels.
+const (
+	width       = 900
+	panelHeight = 300
+	headerSize  = 40
+	marginLeft  = 90
+	marginRight = 20
+	marginTop   = 30
+	marginBelow = 45
+)
+
+// Panel is a chart of values against an argument.
+type Panel struct {
+	Title  string
+	XLabel string
+	YLabel string
+	A, B   float64   // Interval of the argument; the axis is widened to show every point
+	X, Y   []float64 // Points; those with a non-finite value are left out and counted
+	Guides []float64 // Values marked by dashed lines, such as the spacing of the format
+}
+
+// WriteSVG writes a chart of the panels, one above the other, under title.
+func WriteSVG(w io.Writer, title string, panels []Panel) error {
+	var b bytes.Buffer
+	height := headerSize + len(panels)*panelHeight
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="monospace" font-size="11">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="white"/>`+"\n", width, height)
+	fmt.Fprintf(&b, `<text x="%d" y="24" font-size="15" font-weight="bold">%s</text>`+"\n", marginLeft, escape(title))
+	for i, p := range panels {
+		fmt.Fprintf(&b, `<g transform="translate(0,%d)">`+"\n", headerSize+i*panelHeight)
+		p.write(&b)
+		b.WriteString("</g>\n")
+	}
+	b.WriteString("</svg>\n")
+	_, err := b.WriteTo(w)
+	return err
+}
+
+// frame maps values to pixels within the plotting area of a panel.
+type frame struct {
+	x0, x1, y0, y1 float64 // Range of the values
+}
+
+func (f frame) px(x float64) float64 {
+	return marginLeft + (x-f.x0)/(f.x1-f.x0)*(width-marginLeft-marginRight)
+}
+
+func (f frame) py(y float64) float64 {
+	return panelHeight - marginBelow - (y-f.y0)/(f.y1-f.y0)*(panelHeight-marginTop-marginBelow)
+}
+
+func (p Panel) write(b *bytes.Buffer) {
+	// Range of the points, widened to the interval and to zero
+	f := frame{p.A, p.B, 0, 0}
+	if f.x0 > f.x1 {
+		f.x0, f.x1 = f.x1, f.x0
+	}
+	offScale := 0
+	for i, x := range p.X {
+		y := p.Y[i]
+		if !finite(x) || !finite(y) {
+			offScale++
+			continue
+		}
+		f.x0, f.x1 = math.Min(f.x0, x), math.Max(f.x1, x)
+		f.y0, f.y1 = math.Min(f.y0, y), math.Max(f.y1, y)
+	}
+	for _, g := range p.Guides {
+		f.y0, f.y1 = math.Min(f.y0, g), math.Max(f.y1, g)
+	}
+	f.x0, f.x1 = widen(f.x0, f.x1)
+	f.y0, f.y1 = widen(f.y0, f.y1)
+	f.y0, f.y1 = f.y0-(f.y1-f.y0)*0.05, f.y1+(f.y1-f.y0)*0.05
+
+	left, right := float64(marginLeft), float64(width-marginRight)
+	top, bottom := float64(marginTop), float64(panelHeight-marginBelow)
+	title := p.Title
+	if offScale > 0 {
+		title += fmt.Sprintf(" (%d non-finite values not shown)", offScale)
+	}
+	fmt.Fprintf(b, `<text x="%g" y="%g" font-size="12">%s</text>`+"\n", left, top-10, escape(title))
+
+	// Grid and axes
+	for _, t := range ticks(f.x0, f.x1) {
+		x := f.px(t)
+		fmt.Fprintf(b, `<line x1="%.2f" y1="%g" x2="%.2f" y2="%g" stroke="#e0e0e0"/>`+"\n", x, top, x, bottom)
+		fmt.Fprintf(b, `<text x="%.2f" y="%g" text-anchor="middle">%s</text>`+"\n", x, bottom+15, label(t))
+	}
+	for _, t := range ticks(f.y0, f.y1) {
+		y := f.py(t)
+		fmt.Fprintf(b, `<line x1="%g" y1="%.2f" x2="%g" y2="%.2f" stroke="#e0e0e0"/>`+"\n", left, y, right, y)
+		fmt.Fprintf(b, `<text x="%g" y="%.2f" text-anchor="end">%s</text>`+"\n", left-5, y+4, label(t))
+	}
+	fmt.Fprintf(b, `<rect x="%g" y="%g" width="%g" height="%g" fill="none" stroke="black"/>`+"\n", left, top, right-left, bottom-top)
+	fmt.Fprintf(b, `<text x="%g" y="%g" text-anchor="middle">%s</text>`+"\n", (left+right)/2, bottom+35, escape(p.XLabel))
+	fmt.Fprintf(b, `<text x="15" y="%g" text-anchor="middle" transform="rotate(-90 15 %g)">%s</text>`+"\n",
+		(top+bottom)/2, (top+bottom)/2, escape(p.YLabel))
+	if f.y0 < 0 && f.y1 > 0 {
+		fmt.Fprintf(b, `<line x1="%g" y1="%.2f" x2="%g" y2="%.2f" stroke="gray"/>`+"\n", left, f.py(0), right, f.py(0))
+	}
+	for _, g := range p.Guides {
+		fmt.Fprintf(b, `<line x1="%g" y1="%.2f" x2="%g" y2="%.2f" stroke="firebrick" stroke-dasharray="4 3"/>`+"\n",
+			left, f.py(g), right, f.py(g))
+	}
+
+	// Points, or the range of the points in each column of pixels
+	if len(p.X)-offScale <= MaxPoints {
+		b.WriteString(`<g fill="steelblue">` + "\n")
+		for i, x := range p.X {
+			if y := p.Y[i]; finite(x) && finite(y) {
+				fmt.Fprintf(b, `<circle cx="%.2f" cy="%.2f" r="1.5"/>`+"\n", f.px(x), f.py(y))
+			}
+		}
+		b.WriteString("</g>\n")
+		return
+	}
+	cols := int(right - left)
+	lo := make([]float64, cols)
+	hi := make([]float64, cols)
+	for c := range lo {
+		lo[c], hi[c] = math.Inf(1), math.Inf(-1)
+	}
+	for i, x := range p.X {
+		y := p.Y[i]
+		if !finite(x) || !finite(y) {
+			continue
+		}
+		c := min(int(f.px(x)-left), cols-1)
+		lo[c], hi[c] = math.Min(lo[c], y), math.Max(hi[c], y)
+	}
+	b.WriteString(`<path stroke="steelblue" fill="none" d="`)
+	for c := range lo {
+		if lo[c] > hi[c] {
+			continue
+		}
+		x := left + float64(c) + 0.5
+		fmt.Fprintf(b, "M%.1f %.2fV%.2f", x, f.py(hi[c]), f.py(lo[c])+0.5)
+	}
+	b.WriteString(`"/>` + "\n")
+}
+
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// widen returns an interval around lo and hi that is not empty.
+func widen(lo, hi float64) (float64, float64) {
+	if lo < hi {
+		return lo, hi
+	}
+	d := math.Abs(lo)
+	if d == 0 {
+		d = 1
+	}
+	return lo - d/2, hi + d/2
+}
+
+// ticks returns about five round values between lo and hi.
+func ticks(lo, hi float64) []float64 {
+	step := math.Pow(10, math.Floor(math.Log10((hi-lo)/5)))
+	switch r := (hi - lo) / 5 / step; {
+	case r > 5:
+		step *= 10
+	case r > 2:
+		step *= 5
+	case r > 1:
+		step *= 2
+	}
+	var ts []float64
+	for i := math.Ceil(lo / step); i*step <= hi; i++ {
+		ts = append(ts, i*step)
+	}
+	return ts
+}
+
+// label formats a tick value.
+func label(v float64) string {
+	if v != 0 && (math.Abs(v) < 1e-3 || math.Abs(v) >= 1e5) {
+		return fmt.Sprintf("%.3g", v)
+	}
+	return fmt.Sprintf("%.4g", v)
+}
+
+func escape(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
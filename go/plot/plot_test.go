@@ -0,0 +1,128 @@
+package plot
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestTicks(t *testing.T) {
+	tests := []struct {
+		lo, hi float64
+		want   []float64
+	}{
+		{0, 1, []float64{0, 0.2, 0.4, 0.6, 0.8, 1}},
+		{0, 100, []float64{0, 20, 40, 60, 80, 100}},
+		{-3, 7, []float64{-2, 0, 2, 4, 6}},
+		{0, 0.37, []float64{0, 0.1, 0.2, 0.3}},
+		{-0.5, 0.5, []float64{-0.4, -0.2, 0, 0.2, 0.4}},
+		{1e-17, 5e-16, []float64{1e-16, 2e-16, 3e-16, 4e-16, 5e-16}},
+		{-2.5e6, -1e6, []float64{-2.5e6, -2e6, -1.5e6, -1e6}},
+	}
+	for _, tt := range tests {
+		got := ticks(tt.lo, tt.hi)
+		if len(got) != len(tt.want) {
+			t.Errorf("ticks(%g, %g) = %g, want %g", tt.lo, tt.hi, got, tt.want)
+			continue
+		}
+		for i, v := range got {
+			if math.Abs(v-tt.want[i]) > 1e-9*(tt.hi-tt.lo) {
+				t.Errorf("ticks(%g, %g) = %g, want %g", tt.lo, tt.hi, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestWiden(t *testing.T) {
+	tests := []struct {
+		lo, hi, wlo, whi float64
+	}{
+		{1, 2, 1, 2},
+		{0, 0, -0.5, 0.5},
+		{4, 4, 2, 6},
+		{-2, -2, -3, -1},
+	}
+	for _, tt := range tests {
+		if lo, hi := widen(tt.lo, tt.hi); lo != tt.wlo || hi != tt.whi {
+			t.Errorf("widen(%g, %g) = %g, %g, want %g, %g", tt.lo, tt.hi, lo, hi, tt.wlo, tt.whi)
+		}
+	}
+}
+
+func TestNonFinite(t *testing.T) {
+	p := Panel{
+		Title: "errors",
+		A:     0, B: 4,
+		X: []float64{0, 1, math.NaN(), 3, 4, math.Inf(1)},
+		Y: []float64{0.5, math.Inf(-1), 1, 0.25, math.NaN(), 0},
+	}
+	var b bytes.Buffer
+	if err := WriteSVG(&b, "chart", []Panel{p}); err != nil {
+		t.Fatal(err)
+	}
+	svg := b.String()
+	if !strings.Contains(svg, "errors (4 non-finite values not shown)") {
+		t.Errorf("the title does not count 4 non-finite values:\n%s", svg)
+	}
+	if n := strings.Count(svg, "<circle"); n != 2 {
+		t.Errorf("drew %d points, want 2", n)
+	}
+	if strings.Contains(svg, "NaN") || strings.Contains(svg, "Inf") {
+		t.Errorf("non-finite coordinates in the chart:\n%s", svg)
+	}
+}
+
+// wellFormed checks that doc is a well-formed XML document with an svg root.
+func wellFormed(t *testing.T, doc []byte) {
+	t.Helper()
+	d := xml.NewDecoder(bytes.NewReader(doc))
+	root := ""
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("malformed SVG: %v\n%s", err, doc)
+		}
+		if se, ok := tok.(xml.StartElement); ok && root == "" {
+			root = se.Name.Local
+		}
+	}
+	if root != "svg" {
+		t.Errorf("root element %q, want svg", root)
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	// A small panel is drawn point by point, a large one as ranges.
+	small := Panel{Title: `exp <x> & "y"`, XLabel: "X", YLabel: "ULPs", A: -1, B: 1, Guides: []float64{0.5}}
+	large := Panel{Title: "pow", A: 0, B: 1}
+	for i := 0; i < 50; i++ {
+		small.X = append(small.X, float64(i)/25-1)
+		small.Y = append(small.Y, math.Sin(float64(i)))
+	}
+	for i := 0; i <= MaxPoints; i++ {
+		large.X = append(large.X, float64(i)/MaxPoints)
+		large.Y = append(large.Y, -float64(i%7))
+	}
+	var b bytes.Buffer
+	if err := WriteSVG(&b, "errors of <f>", []Panel{small, large, {Title: "empty"}}); err != nil {
+		t.Fatal(err)
+	}
+	wellFormed(t, b.Bytes())
+	svg := b.String()
+	if n := strings.Count(svg, "<circle"); n != len(small.X) {
+		t.Errorf("drew %d points, want %d", n, len(small.X))
+	}
+	if n := strings.Count(svg, "<path"); n != 1 {
+		t.Errorf("drew %d ranges, want 1", n)
+	}
+	if !strings.Contains(svg, "stroke-dasharray") {
+		t.Errorf("no guide line")
+	}
+}
@@ -2,6 +2,23 @@
 // original elefunt REN function (Algorithm 266 by Pike and Hill).
 package random
 
+// DefaultSeed is the seed of the ELEFUNT test programs.
+const DefaultSeed = 100001
+
+// modulus is the modulus of the generator; its states are 1 to modulus-1.
+const modulus = 2796203
+
+// MaxSeed is the largest seed of NewWithSeed and Seed. Seeds from 1 to
+// MaxSeed are the states of the generator; others give numbers outside
+// (0, 1), or zero ever after.
+const MaxSeed = modulus - 1
+
+// ValidSeed reports whether seed is a state of the generator, from 1 to
+// MaxSeed.
+func ValidSeed(seed int) bool {
+	return seed >= 1 && seed <= MaxSeed
+}
+
 // Generator is a simple linear congruential random number generator.
 type Generator struct {
 	iy int
@@ -9,37 +26,53 @@ type Generator struct {
 
 // New creates a new random number generator with the default seed.
 func New() *Generator {
-	return &Generator{iy: 100001}
+	return &Generator{iy: DefaultSeed}
 }
 
-// NewWithSeed creates a new random number generator with a custom seed.
+// NewWithSeed creates a new random number generator with a custom seed,
+// which must be a valid one.
 func NewWithSeed(seed int) *Generator {
 	return &Generator{iy: seed}
 }
 
+// NewStream creates a generator for stream number stream of seed. The
+// generator starts from a state derived from both by a SplitMix64 hash, so
+// work split among generators of different streams draws unrelated numbers
+// and can be repeated exactly.
+func NewStream(seed int, stream uint64) *Generator {
+	z := uint64(seed)*0x9e3779b97f4a7c15 + stream
+	for i := 0; i < 2; i++ {
+		z += 0x9e3779b97f4a7c15
+		z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+		z = (z ^ z>>27) * 0x94d049bb133111eb
+		z ^= z >> 31
+	}
+	return &Generator{iy: int(z%(modulus-1)) + 1}
+}
+
 // Float64 returns a random float64 uniformly distributed over (0, 1).
 // This is the double precision version (equivalent to Fortran REN).
 func (g *Generator) Float64() float64 {
 	g.iy = g.iy * 125
-	g.iy = g.iy - (g.iy/2796203)*2796203
+	g.iy = g.iy - (g.iy/modulus)*modulus
 	// Double precision version includes additional factor for better distribution
-	return float64(g.iy) / 2796203.0 * (1.0 + 1.0e-6 + 1.0e-12)
+	return float64(g.iy) / modulus * (1.0 + 1.0e-6 + 1.0e-12)
 }
 
 // Float32 returns a random float32 uniformly distributed over (0, 1).
 // This is the single precision version (equivalent to Fortran REN).
 func (g *Generator) Float32() float32 {
 	g.iy = g.iy * 125
-	g.iy = g.iy - (g.iy/2796203)*2796203
-	return float32(g.iy) / 2796203.0
+	g.iy = g.iy - (g.iy/modulus)*modulus
+	return float32(g.iy) / modulus
 }
 
 // Reset resets the generator to its initial state.
 func (g *Generator) Reset() {
-	g.iy = 100001
+	g.iy = DefaultSeed
 }
 
-// Seed sets a new seed value.
+// Seed sets a new seed value, which must be a valid one.
 func (g *Generator) Seed(seed int) {
 	g.iy = seed
 }
@@ -0,0 +1,39 @@
+package random
+
+import "testing"
+
+func TestValidSeed(t *testing.T) {
+	tests := []struct {
+		seed int
+		want bool
+	}{
+		{1, true},
+		{DefaultSeed, true},
+		{MaxSeed, true},
+		{0, false},
+		{-5, false},
+		{modulus, false},
+		{3 * modulus, false},
+	}
+	for _, tt := range tests {
+		if got := ValidSeed(tt.seed); got != tt.want {
+			t.Errorf("ValidSeed(%d) = %v, want %v", tt.seed, got, tt.want)
+		}
+	}
+}
+
+func TestFloat64(t *testing.T) {
+	// Every valid seed, the smallest and largest included, gives numbers
+	// in (0, 1) that do not get stuck.
+	for _, seed := range []int{1, DefaultSeed, MaxSeed} {
+		g := NewWithSeed(seed)
+		prev := -1.0
+		for i := 0; i < 1000; i++ {
+			x := g.Float64()
+			if x <= 0 || x >= 1 || x == prev {
+				t.Fatalf("seed %d: number %d is %g after %g", seed, i, x, prev)
+			}
+			prev = x
+		}
+	}
+}
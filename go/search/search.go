@@ -0,0 +1,374 @@
+// Package search looks for arguments at which a function has large errors.
+//
+// The random argument tests report the largest error among a few thousand
+// arguments. A search starts from the worst of them and refines each against
+// the math/big reference: it walks to neighbouring floating-point values,
+// descends towards the peak of the error one coordinate at a time with ever
+// smaller steps, and makes random jumps accepted in the manner of simulated
+// annealing. The hardest arguments found are returned as a ranked list of
+// cases.
+package search
+
+import (
+	"math"
+	"math/big"
+	"sort"
+	"sync"
+
+	"golefunt/elefunt"
+	"golefunt/machar"
+	"golefunt/oracle"
+	"golefunt/random"
+)
+
+// Defaults used when Options leaves a field zero.
+const (
+	DefaultEvals = 4000
+	DefaultCases = 10
+)
+
+// walk is the number of neighbouring values on each side of a point
+// examined by a walk.
+const walk = 8
+
+// neighbourhood is the base 2 logarithm of the number of largest jumps
+// within which two cases are taken to be the same hard argument.
+const neighbourhood = 4
+
+// Options controls a search.
+type Options struct {
+	Evals int  // Evaluations of the function from each starting argument, or DefaultEvals if zero
+	Cases int  // Largest number of cases returned, or DefaultCases if zero
+	Seed  int  // Seed of the random jumps, or the ELEFUNT seed if zero
+	Prec  uint // Precision of the reference in bits, or oracle.DefaultPrec if zero
+}
+
+func (opts Options) evals() int {
+	if opts.Evals <= 0 {
+		return DefaultEvals
+	}
+	return opts.Evals
+}
+
+func (opts Options) cases() int {
+	if opts.Cases <= 0 {
+		return DefaultCases
+	}
+	return opts.Cases
+}
+
+func (opts Options) seed() int {
+	if opts.Seed == 0 {
+		return random.DefaultSeed
+	}
+	return opts.Seed
+}
+
+func (opts Options) prec() uint {
+	if opts.Prec == 0 {
+		return oracle.DefaultPrec
+	}
+	return opts.Prec
+}
+
+// Case is an argument at which a function has a large error.
+type Case struct {
+	X    elefunt.Float  `json:"x"`
+	Y    *elefunt.Float `json:"y,omitempty"` // Second argument of a function of two arguments
+	Got  elefunt.Float  `json:"got"`         // Value of the function under test
+	Want elefunt.Float  `json:"want"`        // Reference value rounded to the precision of the function
+	ULP  elefunt.Float  `json:"ulp"`         // Error in ULPs
+	From elefunt.Float  `json:"from_ulp"`    // Error at the random argument the search started from
+}
+
+// Func1 searches for arguments at which f, a function of one argument in
+// the precision of T, differs most from exact. It starts from each argument
+// in starts and returns the worst cases found, largest error first, each the
+// worst of its neighbourhood of arguments.
+func Func1[T elefunt.Real](f func(T) T, exact oracle.Func1, starts []T, opts Options) []Case {
+	prec := opts.prec()
+	ps := make([]point[T], len(starts))
+	for i, x := range starts {
+		ps[i] = point[T]{x: x}
+	}
+	return run(1, ps, opts, func(p point[T]) (T, *big.Float) {
+		return f(p.x), exact(big.NewFloat(float64(p.x)), prec)
+	})
+}
+
+// Func2 is like Func1 for a function of two arguments, starting from the
+// argument pairs in starts.
+func Func2[T elefunt.Real](f func(T, T) T, exact oracle.Func2, starts [][2]T, opts Options) []Case {
+	prec := opts.prec()
+	ps := make([]point[T], len(starts))
+	for i, xy := range starts {
+		ps[i] = point[T]{x: xy[0], y: xy[1]}
+	}
+	return run(2, ps, opts, func(p point[T]) (T, *big.Float) {
+		return f(p.x, p.y), exact(big.NewFloat(float64(p.x)), big.NewFloat(float64(p.y)), prec)
+	})
+}
+
+// point is an argument of the function under test.
+type point[T elefunt.Real] struct {
+	x, y T
+}
+
+// step returns p with coordinate c moved by d floating-point values, and
+// whether that is a finite value.
+func (p point[T]) step(c int, d int64) (point[T], bool) {
+	v := &p.x
+	if c == 1 {
+		v = &p.y
+	}
+	k := elefunt.Ordinal(*v) + d
+	if k < elefunt.Ordinal(-maxValue[T]()) || k > elefunt.Ordinal(maxValue[T]()) {
+		return p, false
+	}
+	*v = elefunt.FromOrdinal[T](k)
+	return p, true
+}
+
+// found is a point at which the function was evaluated.
+type found[T elefunt.Real] struct {
+	p         point[T]
+	got, want T
+	ulp       float64
+	from      float64 // Error at the starting argument
+}
+
+// run searches from each of starts on its own goroutine, with its own random
+// stream, and merges the cases found.
+func run[T elefunt.Real](dim int, starts []point[T], opts Options, eval func(point[T]) (T, *big.Float)) []Case {
+	mp := elefunt.Params[T]()
+	measure := func(p point[T]) found[T] {
+		got, want := eval(p)
+		u := oracle.ULPs(float64(got), want, mp)
+		return found[T]{p: p, got: got, want: elefunt.RoundBig[T](want), ulp: u}
+	}
+
+	k := opts.cases()
+	results := make([][]found[T], len(starts))
+	var wg sync.WaitGroup
+	for i, p := range starts {
+		wg.Add(1)
+		go func(i int, p point[T]) {
+			defer wg.Done()
+			s := &searcher[T]{
+				dim:     dim,
+				mp:      mp,
+				measure: measure,
+				rng:     random.NewStream(opts.seed(), uint64(i)),
+				evals:   opts.evals(),
+				k:       k,
+			}
+			start := s.eval(p)
+			s.search(start)
+			for j := range s.best {
+				s.best[j].from = start.ulp
+			}
+			results[i] = s.best
+		}(i, p)
+	}
+	wg.Wait()
+
+	// Rank every case found, keeping only the worst of each neighbourhood:
+	// the points found from one start lie within a few jumps and walks of
+	// each other, and a list of them says no more than the worst.
+	var fs []found[T]
+	for _, r := range results {
+		fs = append(fs, r...)
+	}
+	sort.SliceStable(fs, func(a, b int) bool { return worse(fs[a], fs[b]) })
+	var kept []point[T]
+	var all []Case
+	for _, f := range fs {
+		if len(all) == k {
+			break
+		}
+		if near(f.p, kept, int64(1)<<(mp.IT/2+neighbourhood)) {
+			continue
+		}
+		kept = append(kept, f.p)
+		c := Case{
+			X:    elefunt.Float(f.p.x),
+			Got:  elefunt.Float(f.got),
+			Want: elefunt.Float(f.want),
+			ULP:  elefunt.Float(f.ulp),
+			From: elefunt.Float(f.from),
+		}
+		if dim == 2 {
+			y := elefunt.Float(f.p.y)
+			c.Y = &y
+		}
+		all = append(all, c)
+	}
+	return all
+}
+
+// near reports whether p is within d floating-point values of one of ps in
+// each coordinate.
+func near[T elefunt.Real](p point[T], ps []point[T], d int64) bool {
+	for _, q := range ps {
+		dx := elefunt.Ordinal(p.x) - elefunt.Ordinal(q.x)
+		dy := elefunt.Ordinal(p.y) - elefunt.Ordinal(q.y)
+		if -d <= dx && dx <= d && -d <= dy && dy <= d {
+			return true
+		}
+	}
+	return false
+}
+
+// worse reports whether a ranks before b: by larger error, then by
+// smaller argument.
+func worse[T elefunt.Real](a, b found[T]) bool {
+	if a.ulp != b.ulp {
+		return a.ulp > b.ulp
+	}
+	if a.p.x != b.p.x {
+		return a.p.x < b.p.x
+	}
+	return a.p.y < b.p.y
+}
+
+// searcher refines one starting argument.
+type searcher[T elefunt.Real] struct {
+	dim     int
+	mp      machar.Params
+	measure func(point[T]) found[T]
+	rng     *random.Generator
+	evals   int // Evaluations left
+	k       int
+	best    []found[T] // Worst distinct points evaluated, largest error first
+}
+
+// eval measures the error at p and keeps p among the worst points.
+func (s *searcher[T]) eval(p point[T]) found[T] {
+	s.evals--
+	f := s.measure(p)
+	for _, b := range s.best {
+		if b.p == p {
+			return f
+		}
+	}
+	i := sort.Search(len(s.best), func(i int) bool { return worse(f, s.best[i]) })
+	if i < s.k {
+		if len(s.best) < s.k {
+			s.best = append(s.best, found[T]{})
+		}
+		copy(s.best[i+1:], s.best[i:])
+		s.best[i] = f
+	}
+	return f
+}
+
+// scale returns the base 2 logarithm of the largest jump, in
+// floating-point values, made from a starting argument: half the digits of
+// the significand, so the search stays near the argument it refines.
+func (s *searcher[T]) scale() int {
+	return s.mp.IT / 2
+}
+
+// search refines start: a walk to the worst of its neighbours, a descent
+// towards the peak of the error with steps halving from the coarsest scale
+// down to single values, then random jumps for the remaining evaluations.
+func (s *searcher[T]) search(start found[T]) {
+	cur := s.walk(start)
+	cur = s.descend(cur)
+	s.anneal(cur)
+}
+
+// walk moves from cur to the worst of the values within walk of it in each
+// coordinate, for as long as that improves the error.
+func (s *searcher[T]) walk(cur found[T]) found[T] {
+	for s.evals > 0 {
+		next := cur
+		for c := 0; c < s.dim; c++ {
+			for d := int64(-walk); d <= walk && s.evals > 0; d++ {
+				q, ok := cur.p.step(c, d)
+				if d == 0 || !ok {
+					continue
+				}
+				if f := s.eval(q); f.ulp > next.ulp {
+					next = f
+				}
+			}
+		}
+		if next.p == cur.p {
+			break
+		}
+		cur = next
+	}
+	return cur
+}
+
+// descend climbs to a peak of the error around cur by coordinate descent:
+// with steps halving from the largest jump down to a single value, it moves
+// cur in each coordinate to the point a step to either side if the error
+// there is larger.
+func (s *searcher[T]) descend(cur found[T]) found[T] {
+	for e := s.scale(); e >= 0 && s.evals > 0; e-- {
+		d := int64(1) << e
+		for c := 0; c < s.dim; c++ {
+			next := cur
+			for _, q := range []int64{-d, d} {
+				if s.evals <= 0 {
+					break
+				}
+				if p, ok := cur.p.step(c, q); ok {
+					if f := s.eval(p); f.ulp > next.ulp {
+						next = f
+					}
+				}
+			}
+			cur = next
+		}
+	}
+	return cur
+}
+
+// anneal spends the remaining evaluations on random jumps of up to
+// 2**scale values from cur. A jump to a larger error is always taken; one to
+// a smaller error is taken with a probability that falls as the search
+// cools. Every new worst point is followed by a walk.
+func (s *searcher[T]) anneal(cur found[T]) {
+	total := float64(s.evals)
+	best := cur.ulp
+	for s.evals > 0 {
+		c := 0
+		if s.dim == 2 && s.rng.Float64() < 0.5 {
+			c = 1
+		}
+		span := math.Exp2(float64(s.scale()) * s.rng.Float64())
+		d := int64(span*s.rng.Float64()) + 1
+		if s.rng.Float64() < 0.5 {
+			d = -d
+		}
+		q, ok := cur.p.step(c, d)
+		if !ok {
+			s.evals--
+			continue
+		}
+		f := s.eval(q)
+
+		// The temperature falls from a tenth of the error to zero
+		temp := 0.1 * math.Max(best, 0.5) * float64(s.evals) / total
+		if f.ulp > cur.ulp || (temp > 0 && s.rng.Float64() < math.Exp((f.ulp-cur.ulp)/temp)) {
+			cur = f
+		}
+		if f.ulp > best {
+			best = f.ulp
+			cur = s.walk(f)
+			best = math.Max(best, cur.ulp)
+		}
+	}
+}
+
+// maxValue returns the largest finite value of T.
+func maxValue[T elefunt.Real]() T {
+	if elefunt.Precision[T]() == elefunt.PrecisionSingle {
+		return T(float32(math.MaxFloat32))
+	}
+	m := math.MaxFloat64
+	return T(m)
+}
@@ -0,0 +1,70 @@
+package search
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"golefunt/elefunt"
+	"golefunt/oracle"
+)
+
+func TestStep(t *testing.T) {
+	// Steps cross zero through -0 and stop short of infinity.
+	tests := []struct {
+		x    float64
+		d    int64
+		want float64
+		ok   bool
+	}{
+		{1, 1, 1 + 0x1p-52, true},
+		{1, -1, 1 - 0x1p-53, true},
+		{0x1p-1074, -1, 0, true},
+		{0, -1, math.Copysign(0, -1), true},
+		{math.Copysign(0, -1), -1, -0x1p-1074, true},
+		{math.MaxFloat64, 1, math.MaxFloat64, false},
+		{-math.MaxFloat64, -1, -math.MaxFloat64, false},
+	}
+	for _, tt := range tests {
+		p, ok := point[float64]{x: tt.x}.step(0, tt.d)
+		if ok != tt.ok || p.x != tt.want || math.Signbit(p.x) != math.Signbit(tt.want) {
+			t.Errorf("step(%g, %d) = %g, %v, want %g, %v", tt.x, tt.d, p.x, ok, tt.want, tt.ok)
+		}
+	}
+	if p, _ := (point[float32]{x: 1}).step(0, 1); p.x != 1+0x1p-23 {
+		t.Errorf("float32 step(1, 1) = %g, want 1+2**-23", p.x)
+	}
+}
+
+func TestDistinctCases(t *testing.T) {
+	exact, _ := oracle.Lookup1("exp")
+	starts := []float64{-476.5, -0.28, 0.31, 0.346, 413.4, 531.3}
+	cases := Func1(math.Exp, exact, starts, Options{Evals: 500, Cases: 20})
+	if len(cases) == 0 || len(cases) > len(starts) {
+		t.Fatalf("found %d cases from %d starts", len(cases), len(starts))
+	}
+	// A search never ends worse than it started, and the worst case improves
+	// on every start.
+	mp := elefunt.Params[float64]()
+	worst := 0.0
+	for _, x := range starts {
+		worst = math.Max(worst, oracle.ULPs(math.Exp(x), exact(big.NewFloat(x), oracle.DefaultPrec), mp))
+	}
+	if top := float64(cases[0].ULP); top <= worst {
+		t.Errorf("worst case has an error of %g ULPs, want more than the %g of the worst start", top, worst)
+	}
+	d := int64(1) << (mp.IT/2 + neighbourhood)
+	for i, a := range cases {
+		if i > 0 && a.ULP > cases[i-1].ULP {
+			t.Errorf("case %d has a larger error than case %d", i+1, i)
+		}
+		if a.ULP < a.From {
+			t.Errorf("case %d has an error of %g ULPs, less than the %g it started from", i+1, float64(a.ULP), float64(a.From))
+		}
+		for _, b := range cases[:i] {
+			if n := elefunt.Ordinal(float64(a.X)) - elefunt.Ordinal(float64(b.X)); -d <= n && n <= d {
+				t.Errorf("cases at %v and %v are %d values apart", float64(a.X), float64(b.X), n)
+			}
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package suite
+
+import (
+	"fmt"
+	"math"
+
+	"golefunt/elefunt"
+	"golefunt/random"
+)
+
+// Asinh tests Asinh/Acosh/Atanh in the precision of T, recording the results
+// in rep. There is no ELEFUNT program for these functions; the tests follow
+// the structure of sinh.f and tanh.f by W.J. Cody, comparing each function
+// with its logarithmic form where that form loses no accuracy.
+func Asinh[T elefunt.Real](rep *elefunt.Report, fs *elefunt.Funcs[T], opts Options) {
+	rep.Precision = elefunt.Precision[T]()
+
+	// Get machine parameters
+	mp := params[T](opts)
+	rng := opts.random()
+
+	// Functions under test
+	asinh := fs.Func1("asinh")
+	acosh := fs.Func1("acosh")
+	atanh := fs.Func1("atanh")
+	cosh := fs.Func1("cosh")
+	log := fs.Func1("log")
+	log1p := fs.Func1("log1p")
+	sqrt := fs.Func1("sqrt")
+
+	// Errors in ULPs against the reference, when enabled
+	asinhULPs := ulps1[T](opts, mp, "asinh")
+	acoshULPs := ulps1[T](opts, mp, "acosh")
+	atanhULPs := ulps1[T](opts, mp, "atanh")
+
+	beta := T(mp.IBeta)
+	one := T(1.0)
+	two := T(2.0)
+	half := T(0.5)
+	zero := T(0.0)
+
+	// Past BETA**(IT/2), X*X+1 rounds to X*X, but X*X does not yet overflow
+	a := one
+	b := T(16.0)
+	c := T(math.Pow(float64(beta), float64(mp.IT/2)))
+	n := opts.samples()
+
+	// Random argument accuracy tests
+	for j := 1; j <= 5; j++ {
+		lo, hi := interval(opts, j, a, b)
+		acc := randomTest(opts, mp, rng, j, n, lo, hi, func(acc *elefunt.Accumulator, rng *random.Generator, xl, del T) {
+			x := del*elefunt.Random[T](rng) + xl
+
+			var z, zz T
+			if j <= 2 {
+				// Test ASINH(X) vs LOG(X+SQRT(X*X+1)), X at least 1 so
+				// that the logarithm is not near zero
+				z = asinh(x)
+				asinhULPs(acc, x, z)
+				zz = log(x + sqrt(x*x+one))
+			} else if j == 3 {
+				// Test ATANH(X) vs LOG1P(2X/(1-X))/2, purifying X so
+				// that 1-X is exact
+				y := one - x
+				x = one - y
+				z = atanh(x)
+				atanhULPs(acc, x, z)
+				zz = log1p((x+x)/y) * half
+			} else if j == 4 {
+				// Test ATANH(X) vs ATANH(1/2)+ATANH((X-1/2)/(1-X/2)),
+				// where X-1/2 and 1-X/2 are exact. LOG1P(2X/(1-X))/2
+				// is how many libraries compute ATANH(X) here, and for
+				// X below 3/4 the second ATANH is well conditioned
+				z = atanh(x)
+				atanhULPs(acc, x, z)
+				zz = atanh(half) + atanh((x-half)/(one-x*half))
+			} else {
+				// Test ACOSH(COSH(X)) vs X, X at least 1 so that the
+				// error of COSH(X) is not magnified
+				y := cosh(x)
+				z = acosh(y)
+				acoshULPs(acc, y, z)
+				zz = x
+			}
+
+			acc.Add(float64(x), float64(z), float64(zz))
+		})
+
+		res := acc.Result()
+
+		if j <= 2 {
+			res.Identity = "ASINH(X) VS LOG(X+SQRT(X*X+1))"
+		} else if j == 3 {
+			res.Identity = "ATANH(X) VS LOG1P(2X/(1-X))/2"
+		} else if j == 4 {
+			res.Identity = "ATANH(X) VS ATANH(1/2)+ATANH((X-1/2)/(1-X/2))"
+		} else {
+			res.Identity = "ACOSH(COSH(X)) VS X"
+		}
+		res.WriteHeading(rep)
+
+		if j <= 2 {
+			fmt.Fprintf(rep, " ASINH(X) WAS LARGER %6d TIMES,\n", res.Larger)
+		} else if j <= 4 {
+			fmt.Fprintf(rep, " ATANH(X) WAS LARGER %6d TIMES,\n", res.Larger)
+		} else {
+			fmt.Fprintf(rep, " ACOSH(Y) WAS LARGER %6d TIMES,\n", res.Larger)
+		}
+		fmt.Fprintf(rep, "             AGREED %6d TIMES, AND\n", res.Agreed)
+		fmt.Fprintf(rep, "         WAS SMALLER %6d TIMES.\n\n", res.Smaller)
+		res.WriteErrors(rep)
+		rep.AddResult(res)
+
+		switch j {
+		case 1:
+			a, b = b, c
+		case 2:
+			a, b = -half, half
+		case 3:
+			a, b = half, 0.75
+		case 4:
+			a, b = one, 10.0
+		}
+	}
+
+	// Special tests
+	fmt.Fprintln(rep, "\nSPECIAL TESTS")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY  ASINH(-X) = -ASINH(X)  WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         F(X) + F(-X)")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng) * 10.0
+		z := asinh(x) + asinh(-x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("ASINH(-X) = -ASINH(X)", float64(x), float64(z))
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY  ATANH(-X) = -ATANH(X)  WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         F(X) + F(-X)")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng)
+		z := atanh(x) + atanh(-x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("ATANH(-X) = -ATANH(X)", float64(x), float64(z))
+	}
+
+	betap := T(math.Pow(float64(beta), float64(mp.IT)))
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY ASINH(X) = X , X SMALL, WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         X - F(X)")
+
+	x := elefunt.Random[T](rng) / betap
+	for i := 1; i <= 5; i++ {
+		z := x - asinh(x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("ASINH(X) = X, X SMALL", float64(x), float64(z))
+		x = x / beta
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY ATANH(X) = X , X SMALL, WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         X - F(X)")
+
+	x = elefunt.Random[T](rng) / betap
+	for i := 1; i <= 5; i++ {
+		z := x - atanh(x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("ATANH(X) = X, X SMALL", float64(x), float64(z))
+		x = x / beta
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " TEST OF SPECIAL ARGUMENTS")
+	fmt.Fprintln(rep)
+
+	y := asinh(zero)
+	fmt.Fprintf(rep, " ASINH(0.0) = %.7E\n", y)
+	rep.AddSpecial("ASINH(0.0)", float64(y))
+
+	y = acosh(one)
+	fmt.Fprintf(rep, " ACOSH(1.0) = %.7E\n", y)
+	rep.AddSpecial("ACOSH(1.0)", float64(y))
+
+	y = atanh(zero)
+	fmt.Fprintf(rep, " ATANH(0.0) = %.7E\n", y)
+	rep.AddSpecial("ATANH(0.0)", float64(y))
+
+	x = T(mp.XMin)
+	y = asinh(x)
+	fmt.Fprintf(rep, " ASINH(XMIN) = ASINH(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("ASINH(XMIN)", float64(y), float64(x))
+
+	x = T(mp.XMax)
+	y = asinh(x)
+	fmt.Fprintf(rep, " ASINH(XMAX) = ASINH(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("ASINH(XMAX)", float64(y), float64(x))
+
+	y = acosh(x)
+	fmt.Fprintf(rep, " ACOSH(XMAX) = ACOSH(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("ACOSH(XMAX)", float64(y), float64(x))
+
+	// Test of error returns
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "TEST OF ERROR RETURNS")
+	fmt.Fprintln(rep)
+
+	x = half
+	fmt.Fprintf(rep, " ACOSH WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD RETURN NaN")
+	fmt.Fprintln(rep)
+	y = acosh(x)
+	fmt.Fprintf(rep, " ACOSH RETURNED THE VALUE %.4E\n\n", y)
+	rep.AddError("ACOSH(X)", "NaN", float64(y), float64(x))
+
+	x = one
+	fmt.Fprintf(rep, " ATANH WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD RETURN +Inf")
+	fmt.Fprintln(rep)
+	y = atanh(x)
+	fmt.Fprintf(rep, " ATANH RETURNED THE VALUE %v\n\n", y)
+	rep.AddError("ATANH(X)", "+Inf", float64(y), float64(x))
+
+	x = -one
+	fmt.Fprintf(rep, " ATANH WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD RETURN -Inf")
+	fmt.Fprintln(rep)
+	y = atanh(x)
+	fmt.Fprintf(rep, " ATANH RETURNED THE VALUE %v\n\n", y)
+	rep.AddError("ATANH(X)", "-Inf", float64(y), float64(x))
+
+	x = two
+	fmt.Fprintf(rep, " ATANH WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD RETURN NaN")
+	fmt.Fprintln(rep)
+	y = atanh(x)
+	fmt.Fprintf(rep, " ATANH RETURNED THE VALUE %.4E\n\n", y)
+	rep.AddError("ATANH(X)", "NaN", float64(y), float64(x))
+
+	fmt.Fprintln(rep, " THIS CONCLUDES THE TESTS")
+}
@@ -0,0 +1,162 @@
+package suite
+
+import (
+	"fmt"
+	"math"
+
+	"golefunt/elefunt"
+	"golefunt/random"
+)
+
+// Atan tests Atan/Atan2 in the precision of T, recording the results in rep.
+// It is a port of the elefunt atan.f and datan.f test programs by W.J. Cody.
+func Atan[T elefunt.Real](rep *elefunt.Report, fs *elefunt.Funcs[T], opts Options) {
+	rep.Precision = elefunt.Precision[T]()
+
+	// Get machine parameters
+	mp := params[T](opts)
+	rng := opts.random()
+
+	// Functions under test
+	atan := fs.Func1("atan")
+	atan2 := fs.Func2("atan2")
+
+	// Errors in ULPs against the reference, when enabled
+	atanULPs := ulps1[T](opts, mp, "atan")
+	atan2ULPs := ulps2[T](opts, mp, "atan2")
+
+	beta := T(mp.IBeta)
+	one := T(1.0)
+	zero := T(0.0)
+	two := T(2.0)
+
+	a := T(-0.0625)
+	b := T(0.0625)
+	n := opts.samples()
+
+	// Random argument accuracy tests
+	for j := 1; j <= 4; j++ {
+		lo, hi := interval(opts, j, a, b)
+		acc := randomTest(opts, mp, rng, j, n, lo, hi, func(acc *elefunt.Accumulator, rng *random.Generator, xl, del T) {
+			x := del*elefunt.Random[T](rng) + xl
+
+			var z, zz, w T
+			if j <= 2 {
+				// Test ATAN(X) using identity
+				z = atan(x)
+				atanULPs(acc, x, z)
+				// For reduction: ATAN(X) = 2*ATAN(X/(1+SQRT(1+X*X)))
+				y := x / (one + T(math.Sqrt(float64(one+x*x))))
+				zz = two * atan(y)
+				w = one
+				if z != zero {
+					w = (z - zz) / z
+				}
+			} else {
+				// Test ATAN2 identity
+				y := one
+				z = atan2(x, y)
+				atan2ULPs(acc, x, y, z)
+				zz = atan(x / y)
+				w = one
+				if z != zero {
+					w = (z - zz) / z
+				}
+			}
+
+			acc.AddError(float64(x), float64(w))
+		})
+
+		res := acc.Result()
+
+		if j <= 2 {
+			res.Identity = "ATAN(X) IDENTITY"
+		} else {
+			res.Identity = "ATAN2(X,1) VS ATAN(X)"
+		}
+		res.WriteHeading(rep)
+		elefunt.WriteCounts(rep, "ATAN(X)", res.Larger, res.Agreed, res.Smaller)
+		res.WriteErrors(rep)
+		rep.AddResult(res)
+
+		a = one
+		b = two
+	}
+
+	// Special tests
+	fmt.Fprintln(rep, "\nSPECIAL TESTS")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY   ATAN(-X) = -ATAN(X)   WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         F(X) + F(-X)")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng) * 5.0
+		z := atan(x) + atan(-x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("ATAN(-X) = -ATAN(X)", float64(x), float64(z))
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY ATAN(X) = X , X SMALL, WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         X - F(X)")
+
+	betap := T(math.Pow(float64(beta), float64(mp.IT)))
+	x := elefunt.Random[T](rng) / betap
+
+	for i := 1; i <= 5; i++ {
+		z := x - atan(x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("ATAN(X) = X, X SMALL", float64(x), float64(z))
+		x = x / beta
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " TEST OF SPECIAL ARGUMENTS")
+	fmt.Fprintln(rep)
+
+	x = zero
+	y := atan(x)
+	fmt.Fprintf(rep, " ATAN(0.0) = %.7E\n", y)
+	rep.AddSpecial("ATAN(0.0)", float64(y))
+
+	x = one
+	y = atan(x)
+	fmt.Fprintf(rep, " ATAN(1.0) = %.17E (should be PI/4 = %.17E)\n", y, math.Pi/4)
+	rep.AddSpecial("ATAN(1.0)", float64(y))
+
+	y = atan2(one, one)
+	fmt.Fprintf(rep, " ATAN2(1,1) = %.17E (should be PI/4 = %.17E)\n", y, math.Pi/4)
+	rep.AddSpecial("ATAN2(1,1)", float64(y))
+
+	y = atan2(one, zero)
+	fmt.Fprintf(rep, " ATAN2(1,0) = %.17E (should be PI/2 = %.17E)\n", y, math.Pi/2)
+	rep.AddSpecial("ATAN2(1,0)", float64(y))
+
+	y = atan2(zero, one)
+	fmt.Fprintf(rep, " ATAN2(0,1) = %.17E\n", y)
+	rep.AddSpecial("ATAN2(0,1)", float64(y))
+
+	y = atan2(-one, zero)
+	fmt.Fprintf(rep, " ATAN2(-1,0) = %.17E (should be -PI/2 = %.17E)\n", y, -math.Pi/2)
+	rep.AddSpecial("ATAN2(-1,0)", float64(y))
+
+	// Test of error returns
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "TEST OF ERROR RETURNS")
+	fmt.Fprintln(rep)
+
+	x = T(mp.XMax)
+	fmt.Fprintf(rep, " ATAN WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	y = atan(x)
+	fmt.Fprintf(rep, " ATAN RETURNED THE VALUE %.17E (should be near PI/2)\n\n", y)
+	rep.AddError("ATAN(XMAX)", "PI/2", float64(y), float64(x))
+
+	y = atan2(zero, zero)
+	fmt.Fprintf(rep, " ATAN2(0,0) = %v\n", y)
+	rep.AddError("ATAN2(0,0)", "0.0", float64(y))
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THIS CONCLUDES THE TESTS")
+}
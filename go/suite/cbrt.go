@@ -0,0 +1,264 @@
+package suite
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"golefunt/elefunt"
+	"golefunt/oracle"
+	"golefunt/random"
+)
+
+// Cbrt tests Cbrt/Hypot in the precision of T, recording the results in rep.
+// There is no ELEFUNT program for these functions; the tests follow the
+// structure of sqrt.f and power.f by W.J. Cody, and take the arguments of
+// Hypot down to XMIN and up to XMAX, where computing SQRT(X*X+Y*Y)
+// directly underflows or overflows.
+func Cbrt[T elefunt.Real](rep *elefunt.Report, fs *elefunt.Funcs[T], opts Options) {
+	rep.Precision = elefunt.Precision[T]()
+
+	// Get machine parameters
+	mp := params[T](opts)
+	rng := opts.random()
+
+	// Functions under test
+	cbrt := fs.Func1("cbrt")
+	hypot := fs.Func2("hypot")
+	sqrt := fs.Func1("sqrt")
+
+	// Errors in ULPs against the reference, when enabled
+	cbrtULPs := ulps1[T](opts, mp, "cbrt")
+	hypotULPs := ulps2[T](opts, mp, "hypot")
+
+	beta := T(mp.IBeta)
+	one := T(1.0)
+	two := T(2.0)
+	three := T(3.0)
+	zero := T(0.0)
+	eight := T(8.0)
+
+	// A number of at most IT/3 digits has an exact cube, and one of
+	// IT-D digits, where 27 has D, an exact product with 27
+	bb := float64(mp.IBeta)
+	digits3 := float64(mp.IT / 3)
+	digits27 := float64(mp.IT) - math.Ceil(math.Log(28)/math.Log(bb))
+
+	a := one / eight
+	b := one
+	n := opts.samples()
+
+	// Random argument accuracy tests
+	for j := 1; j <= 6; j++ {
+		lo, hi := interval(opts, j, a, b)
+		acc := randomTest(opts, mp, rng, j, n, lo, hi, func(acc *elefunt.Accumulator, rng *random.Generator, xl, del T) {
+			x := del*elefunt.Random[T](rng) + xl
+
+			var y, z, zz T
+			switch {
+			case j == 1:
+				// Test CBRT(X)**3 vs X, purifying X so that it is the
+				// exact cube of Y
+				y = chop(T(math.Cbrt(float64(x))), bb, digits3)
+				x = y * y * y
+				z = cbrt(x)
+				cbrtULPs(acc, x, z)
+				zz = y
+			case j == 2:
+				// Test CBRT(X) vs 3*CBRT(X/27), purifying X so that
+				// it is exactly 27 times Y
+				y = chop(x/27.0, bb, digits27)
+				x = y * 27.0
+				z = cbrt(x)
+				cbrtULPs(acc, x, z)
+				zz = three * cbrt(y)
+			case j == 3:
+				// Test CBRT(X) vs 2*CBRT(X/8). With BETA a power of 2
+				// the identity only checks the scaling of the argument,
+				// so X/8 is taken below XMIN
+				y = x / eight
+				x = y * eight
+				z = cbrt(x)
+				cbrtULPs(acc, x, z)
+				zz = two * cbrt(y)
+			case j == 4:
+				// Test HYPOT(X,Y) vs S*SQRT((X/S)**2+(Y/S)**2), with
+				// S a power of BETA near the larger of X and Y
+				y = (hi-lo)*elefunt.Random[T](rng) + lo
+				z = hypot(x, y)
+				hypotULPs(acc, x, y, z)
+				s := T(math.Pow(float64(beta), math.Ceil(math.Log(float64(max(x, y)))/math.Log(float64(beta)))))
+				u, v := x/s, y/s
+				zz = s * sqrt(u*u+v*v)
+			default:
+				// Test HYPOT(X,Y) vs the correctly rounded SQRT(X*X+Y*Y)
+				y = (hi-lo)*elefunt.Random[T](rng) + lo
+				z = hypot(x, y)
+				hypotULPs(acc, x, y, z)
+				zz = elefunt.RoundBig[T](oracle.Hypot(big.NewFloat(float64(x)), big.NewFloat(float64(y)), opts.prec()))
+			}
+
+			w := one
+			if z != zero {
+				w = (z - zz) / z
+			}
+
+			if j <= 3 {
+				acc.AddError(float64(x), float64(w))
+			} else {
+				acc.AddError2(float64(x), float64(y), float64(w))
+			}
+		})
+
+		res := acc.Result()
+
+		switch {
+		case j == 1:
+			res.Identity = "CBRT(X)**3 VS X"
+		case j == 2:
+			res.Identity = "CBRT(X) VS 3*CBRT(X/27)"
+		case j == 3:
+			res.Identity = "CBRT(X) VS 2*CBRT(X/8)"
+		case j == 4:
+			res.Identity = "HYPOT(X,Y) VS S*SQRT((X/S)**2+(Y/S)**2)"
+		default:
+			res.Identity = "HYPOT(X,Y) VS SQRT(X*X+Y*Y) ROUNDED"
+		}
+		fmt.Fprintf(rep, "\nTEST OF %s\n\n", res.Identity)
+		fmt.Fprintf(rep, "%7d RANDOM ARGUMENTS WERE TESTED FROM THE INTERVAL\n", n)
+		if j <= 3 {
+			fmt.Fprintf(rep, "      (%.4E, %.4E)\n\n", lo, hi)
+			fmt.Fprintf(rep, " CBRT(X) WAS LARGER %6d TIMES,\n", res.Larger)
+		} else {
+			fmt.Fprintf(rep, "      X AND Y IN (%.4E, %.4E)\n\n", lo, hi)
+			fmt.Fprintf(rep, " HYPOT(X,Y) WAS LARGER %6d TIMES,\n", res.Larger)
+		}
+		fmt.Fprintf(rep, "            AGREED %6d TIMES, AND\n", res.Agreed)
+		fmt.Fprintf(rep, "        WAS SMALLER %6d TIMES.\n\n", res.Smaller)
+		res.WriteErrors(rep)
+		rep.AddResult(res)
+
+		switch j {
+		case 1:
+			a, b = one, 27.0
+		case 2:
+			a, b = T(mp.XMin), eight*T(mp.XMin)
+		case 3:
+			a, b = zero, one
+		case 4:
+			a, b = T(mp.XMax)/(two*two), T(mp.XMax)/two
+		case 5:
+			a, b = T(mp.XMin), two*two*T(mp.XMin)
+		}
+	}
+
+	// Special tests
+	fmt.Fprintln(rep, "\nSPECIAL TESTS")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY  CBRT(-X) = -CBRT(X)  WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         F(X) + F(-X)")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng) * 10.0
+		z := cbrt(x) + cbrt(-x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("CBRT(-X) = -CBRT(X)", float64(x), float64(z))
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY  HYPOT(X,Y) = HYPOT(Y,-X)  WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X              Y         F(X,Y) - F(Y,-X)")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng)
+		y := elefunt.Random[T](rng) * 10.0
+		z := hypot(x, y) - hypot(y, -x)
+		fmt.Fprintf(rep, "  %.7E  %.7E  %.7E\n", x, y, z)
+		rep.AddCheck("HYPOT(X,Y) = HYPOT(Y,-X)", float64(x), float64(z))
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY  HYPOT(X,0) = ABS(X)  WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         F(X,0) - ABS(X)")
+
+	x := -T(mp.XMax)
+	for i := 1; i <= 5; i++ {
+		z := hypot(x, zero) + x
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("HYPOT(X,0) = ABS(X)", float64(x), float64(z))
+		x = x * T(math.Sqrt(mp.XMin))
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " TEST OF SPECIAL ARGUMENTS")
+	fmt.Fprintln(rep)
+
+	y := cbrt(zero)
+	fmt.Fprintf(rep, " CBRT(0.0) = %.7E\n", y)
+	rep.AddSpecial("CBRT(0.0)", float64(y))
+
+	y = cbrt(-eight)
+	fmt.Fprintf(rep, " CBRT(-8.0) = %.17E\n", y)
+	rep.AddSpecial("CBRT(-8.0)", float64(y))
+
+	x = T(mp.XMin)
+	y = cbrt(x)
+	fmt.Fprintf(rep, " CBRT(XMIN) = CBRT(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("CBRT(XMIN)", float64(y), float64(x))
+
+	x = T(mp.XMax)
+	y = cbrt(x)
+	fmt.Fprintf(rep, " CBRT(XMAX) = CBRT(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("CBRT(XMAX)", float64(y), float64(x))
+
+	y = hypot(3.0, 4.0)
+	fmt.Fprintf(rep, " HYPOT(3.0,4.0) = %.17E\n", y)
+	rep.AddSpecial("HYPOT(3.0,4.0)", float64(y))
+
+	x = T(mp.XMax) / two
+	y = hypot(x, x)
+	fmt.Fprintf(rep, " HYPOT(XMAX/2,XMAX/2) = HYPOT(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("HYPOT(XMAX/2,XMAX/2)", float64(y), float64(x))
+
+	x = T(mp.XMin)
+	y = hypot(x, x)
+	fmt.Fprintf(rep, " HYPOT(XMIN,XMIN) = HYPOT(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("HYPOT(XMIN,XMIN)", float64(y), float64(x))
+
+	// Test of error returns
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "TEST OF ERROR RETURNS")
+	fmt.Fprintln(rep)
+
+	x = T(mp.XMax)
+	fmt.Fprintf(rep, " HYPOT(XMAX,XMAX) WILL BE COMPUTED\n")
+	fmt.Fprintln(rep, " THIS SHOULD OVERFLOW")
+	y = hypot(x, x)
+	fmt.Fprintf(rep, " HYPOT(XMAX,XMAX) = %v\n\n", y)
+	rep.AddError("HYPOT(XMAX,XMAX)", "OVERFLOW", float64(y), float64(x), float64(x))
+
+	x = T(math.Inf(1))
+	fmt.Fprintf(rep, " HYPOT(INF,NAN) WILL BE COMPUTED\n")
+	fmt.Fprintln(rep, " THIS SHOULD RETURN +Inf")
+	y = hypot(x, T(math.NaN()))
+	fmt.Fprintf(rep, " HYPOT(INF,NAN) = %v\n\n", y)
+	rep.AddError("HYPOT(INF,NAN)", "+Inf", float64(y), float64(x), math.NaN())
+
+	fmt.Fprintln(rep, " THIS CONCLUDES THE TESTS")
+}
+
+// chop returns v truncated to d significant base beta digits.
+func chop[T elefunt.Real](v T, beta, d float64) T {
+	if v == 0 {
+		return v
+	}
+	f := math.Abs(float64(v))
+	s := math.Pow(beta, d-math.Ceil(math.Log(f)/math.Log(beta)))
+	for f*s >= math.Pow(beta, d) {
+		s /= beta
+	}
+	return T(math.Trunc(float64(v)*s) / s)
+}
@@ -0,0 +1,197 @@
+package suite
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"golefunt/random"
+)
+
+// Config holds run options read from a file, so that sample counts, seeds
+// and intervals can be changed without recompiling. The settings of a test
+// program override those given for every program.
+type Config struct {
+	N     int                   `json:"n,omitempty"`    // Number of random arguments per interval
+	Seed  int                   `json:"seed,omitempty"` // Seed of the random number generator
+	Tests map[string]TestConfig `json:"tests,omitempty"`
+}
+
+// TestConfig holds the run options of one test program.
+type TestConfig struct {
+	N         int              `json:"n,omitempty"`
+	Seed      int              `json:"seed,omitempty"`
+	Intervals map[int]Interval `json:"intervals,omitempty"` // Keyed by the 1-based index of the random argument test
+}
+
+// LoadConfig reads a Config in JSON form from the file named path.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var c Config
+	if err := dec.Decode(&c); err != nil {
+		return nil, fmt.Errorf("suite: %s: %v", path, err)
+	}
+	if c.N < 0 {
+		return nil, fmt.Errorf("suite: %s: invalid sample count %d", path, c.N)
+	}
+	if err := checkSeed(c.Seed); err != nil {
+		return nil, fmt.Errorf("suite: %s: %v", path, err)
+	}
+	for name, tc := range c.Tests {
+		test, ok := Lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("suite: %s: unknown test %q", path, name)
+		}
+		if tc.N < 0 {
+			return nil, fmt.Errorf("suite: %s: %s: invalid sample count %d", path, name, tc.N)
+		}
+		if err := checkSeed(tc.Seed); err != nil {
+			return nil, fmt.Errorf("suite: %s: %s: %v", path, name, err)
+		}
+		for j, iv := range tc.Intervals {
+			if err := test.checkInterval(j, iv); err != nil {
+				return nil, fmt.Errorf("suite: %s: %s: %v", path, name, err)
+			}
+		}
+	}
+	return &c, nil
+}
+
+// checkSeed returns an error if seed is neither zero, for the ELEFUNT
+// seed, nor a state of the random number generator.
+func checkSeed(seed int) error {
+	if seed != 0 && !random.ValidSeed(seed) {
+		return fmt.Errorf("invalid seed %d, want 1 to %d", seed, random.MaxSeed)
+	}
+	return nil
+}
+
+// check returns an error if iv cannot replace the interval of test j.
+func (iv Interval) check(j int) error {
+	switch {
+	case j < 1:
+		return fmt.Errorf("invalid test number %d", j)
+	case math.IsNaN(iv.A) || math.IsInf(iv.A, 0) || math.IsNaN(iv.B) || math.IsInf(iv.B, 0):
+		return fmt.Errorf("test %d: interval (%v, %v) is not finite", j, iv.A, iv.B)
+	case !(iv.A < iv.B):
+		return fmt.Errorf("test %d: interval (%v, %v) is empty", j, iv.A, iv.B)
+	}
+	return nil
+}
+
+// checkInterval returns an error if iv cannot replace the interval of
+// random argument test j of t.
+func (t Test) checkInterval(j int, iv Interval) error {
+	if err := iv.check(j); err != nil {
+		return err
+	}
+	if n := t.RandomTests; j > n {
+		return fmt.Errorf("test %d out of range, %s has %d random argument tests", j, t.Name, n)
+	}
+	return nil
+}
+
+// Options returns opts with the settings of c for the named test program
+// applied over it.
+func (c *Config) Options(name string, opts Options) Options {
+	if c.N != 0 {
+		opts.N = c.N
+	}
+	if c.Seed != 0 {
+		opts.Seed = c.Seed
+	}
+	tc := c.Tests[name]
+	if tc.N != 0 {
+		opts.N = tc.N
+	}
+	if tc.Seed != 0 {
+		opts.Seed = tc.Seed
+	}
+	return opts.WithIntervals(tc.Intervals)
+}
+
+// SetN sets the number of random arguments of every test program to n,
+// overriding the settings of each program.
+func (c *Config) SetN(n int) {
+	c.N = n
+	for name, tc := range c.Tests {
+		tc.N = 0
+		c.Tests[name] = tc
+	}
+}
+
+// SetSeed sets the seed of every test program to seed, overriding the
+// settings of each program.
+func (c *Config) SetSeed(seed int) {
+	c.Seed = seed
+	for name, tc := range c.Tests {
+		tc.Seed = 0
+		c.Tests[name] = tc
+	}
+}
+
+// SetInterval replaces the interval of random argument test j of the named
+// test program with iv. It returns an error if the program has no such test
+// or iv is not finite.
+func (c *Config) SetInterval(name string, j int, iv Interval) error {
+	test, ok := Lookup(name)
+	if !ok {
+		return fmt.Errorf("unknown test %q", name)
+	}
+	if err := test.checkInterval(j, iv); err != nil {
+		return err
+	}
+	if c.Tests == nil {
+		c.Tests = make(map[string]TestConfig)
+	}
+	tc := c.Tests[name]
+	ivs := make(map[int]Interval, len(tc.Intervals)+1)
+	for k, v := range tc.Intervals {
+		ivs[k] = v
+	}
+	ivs[j] = iv
+	tc.Intervals = ivs
+	c.Tests[name] = tc
+	return nil
+}
+
+// ParseInterval parses an interval replacement of the form [name:]test=a,b,
+// such as exp:1=-0.01,0.01 for the first random argument test of exp. The
+// name of the test program is empty if s does not give one.
+func ParseInterval(s string) (string, int, Interval, error) {
+	invalid := fmt.Errorf("invalid interval %q, want [name:]test=a,b", s)
+	name, js, ok := strings.Cut(s, ":")
+	if !ok {
+		name, js = "", s
+	}
+	name = strings.TrimSpace(name)
+	js, ab, ok1 := strings.Cut(js, "=")
+	as, bs, ok2 := strings.Cut(ab, ",")
+	if !ok1 || !ok2 {
+		return "", 0, Interval{}, invalid
+	}
+	j, err1 := strconv.Atoi(strings.TrimSpace(js))
+	a, err2 := strconv.ParseFloat(strings.TrimSpace(as), 64)
+	b, err3 := strconv.ParseFloat(strings.TrimSpace(bs), 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return "", 0, Interval{}, invalid
+	}
+	iv := Interval{a, b}
+	if name == "" {
+		return name, j, iv, iv.check(j)
+	}
+	test, ok := Lookup(name)
+	if !ok {
+		return "", 0, Interval{}, fmt.Errorf("interval %q: unknown test %q", s, name)
+	}
+	return name, j, iv, test.checkInterval(j, iv)
+}
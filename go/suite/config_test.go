@@ -0,0 +1,134 @@
+package suite_test
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golefunt/suite"
+)
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		s    string
+		name string
+		j    int
+		iv   suite.Interval
+		err  string // Part of the error, if any
+	}{
+		{"exp:1=-0.01,0.01", "exp", 1, suite.Interval{A: -0.01, B: 0.01}, ""},
+		{" exp : 3 = -10 , -1 ", "exp", 3, suite.Interval{A: -10, B: -1}, ""},
+		{"2=0,1", "", 2, suite.Interval{A: 0, B: 1}, ""},
+		// Without a name the number of tests is not known
+		{"9=0,1", "", 9, suite.Interval{A: 0, B: 1}, ""},
+
+		{"exp:0=0,1", "", 0, suite.Interval{}, "invalid test number 0"},
+		{"-1=0,1", "", 0, suite.Interval{}, "invalid test number -1"},
+		{"exp:4=0,1", "", 0, suite.Interval{}, "test 4 out of range, exp has 3"},
+		{"gamma:7=0,1", "", 0, suite.Interval{}, "test 7 out of range, gamma has 6"},
+		{"nosuch:1=0,1", "", 0, suite.Interval{}, `unknown test "nosuch"`},
+		{"exp:1=inf,1", "", 0, suite.Interval{}, "is not finite"},
+		{"exp:1=0,NaN", "", 0, suite.Interval{}, "is not finite"},
+		{"exp:1=1,1", "", 0, suite.Interval{}, "test 1: interval (1, 1) is empty"},
+		{"exp:2=1,-1", "", 0, suite.Interval{}, "test 2: interval (1, -1) is empty"},
+		{"1=0,1e400", "", 0, suite.Interval{}, "want [name:]test=a,b"},
+		{"exp:1=0", "", 0, suite.Interval{}, "want [name:]test=a,b"},
+		{"exp:1=0,1,2", "", 0, suite.Interval{}, "want [name:]test=a,b"},
+		{"exp:1", "", 0, suite.Interval{}, "want [name:]test=a,b"},
+		{"exp=0,1", "", 0, suite.Interval{}, "want [name:]test=a,b"},
+		{"exp:x=0,1", "", 0, suite.Interval{}, "want [name:]test=a,b"},
+		{"exp:1=a,b", "", 0, suite.Interval{}, "want [name:]test=a,b"},
+	}
+	for _, tt := range tests {
+		name, j, iv, err := suite.ParseInterval(tt.s)
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("ParseInterval(%q): %v", tt.s, err)
+		case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+			t.Errorf("ParseInterval(%q): error %v, want one containing %q", tt.s, err, tt.err)
+		case err == nil && (name != tt.name || j != tt.j || iv != tt.iv):
+			t.Errorf("ParseInterval(%q) = %q, %d, %v, want %q, %d, %v", tt.s, name, j, iv, tt.name, tt.j, tt.iv)
+		}
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		desc string
+		json string
+		err  string // Part of the error, if any
+	}{
+		{"valid", `{"n": 10, "seed": 3, "tests": {"exp": {"n": 5, "intervals": {"3": {"a": -1, "b": 1}}}}}`, ""},
+		{"empty", `{}`, ""},
+		{"syntax", `{"n": 10,}`, "invalid character"},
+		{"unknown field", `{"count": 10}`, `unknown field "count"`},
+		{"negative n", `{"n": -1}`, "invalid sample count -1"},
+		{"negative seed", `{"seed": -5}`, "invalid seed -5"},
+		{"seed of zero state", `{"seed": 2796203}`, "invalid seed 2796203"},
+		{"invalid test seed", `{"tests": {"exp": {"seed": 5592406}}}`, "exp: invalid seed 5592406"},
+		{"unknown test", `{"tests": {"nosuch": {}}}`, `unknown test "nosuch"`},
+		{"negative test n", `{"tests": {"exp": {"n": -2}}}`, "exp: invalid sample count -2"},
+		{"test 0", `{"tests": {"exp": {"intervals": {"0": {"a": 0, "b": 1}}}}}`, "exp: invalid test number 0"},
+		{"test out of range", `{"tests": {"exp": {"intervals": {"4": {"a": 0, "b": 1}}}}}`, "exp: test 4 out of range"},
+		{"reversed interval", `{"tests": {"exp": {"intervals": {"2": {"a": 1, "b": -1}}}}}`, "exp: test 2: interval (1, -1) is empty"},
+		{"test not a number", `{"tests": {"exp": {"intervals": {"x": {"a": 0, "b": 1}}}}}`, "cannot unmarshal"},
+		{"bound beyond float64", `{"tests": {"exp": {"intervals": {"1": {"a": 0, "b": 1e400}}}}}`, "cannot unmarshal"},
+	}
+	dir := t.TempDir()
+	for _, tt := range tests {
+		path := filepath.Join(dir, "config.json")
+		if err := os.WriteFile(path, []byte(tt.json), 0o666); err != nil {
+			t.Fatal(err)
+		}
+		c, err := suite.LoadConfig(path)
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("%s: %v", tt.desc, err)
+		case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+			t.Errorf("%s: error %v, want one containing %q", tt.desc, err, tt.err)
+		case tt.err != "" && !strings.HasPrefix(err.Error(), "suite: "+path+": "):
+			t.Errorf("%s: error %q does not name the file", tt.desc, err)
+		case err == nil && c == nil:
+			t.Errorf("%s: no configuration", tt.desc)
+		}
+	}
+
+	if _, err := suite.LoadConfig(filepath.Join(dir, "missing.json")); !os.IsNotExist(err) {
+		t.Errorf("missing file: error %v, want one that it does not exist", err)
+	}
+}
+
+func TestSetInterval(t *testing.T) {
+	c := &suite.Config{}
+	if err := c.SetInterval("exp", 2, suite.Interval{A: -10, B: -1}); err != nil {
+		t.Fatal(err)
+	}
+	opts := c.Options("exp", suite.Options{})
+	if want := map[int]suite.Interval{2: {A: -10, B: -1}}; !reflect.DeepEqual(opts.Intervals, want) {
+		t.Errorf("intervals %v, want %v", opts.Intervals, want)
+	}
+
+	for _, tt := range []struct {
+		name string
+		j    int
+		iv   suite.Interval
+		err  string
+	}{
+		{"exp", 0, suite.Interval{A: 0, B: 1}, "invalid test number 0"},
+		{"exp", 4, suite.Interval{A: 0, B: 1}, "test 4 out of range"},
+		{"exp", 1, suite.Interval{A: math.Inf(-1), B: 1}, "is not finite"},
+		{"exp", 1, suite.Interval{A: 0, B: math.NaN()}, "is not finite"},
+		{"exp", 1, suite.Interval{A: 1, B: 0}, "is empty"},
+		{"nosuch", 1, suite.Interval{A: 0, B: 1}, `unknown test "nosuch"`},
+	} {
+		if err := c.SetInterval(tt.name, tt.j, tt.iv); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("SetInterval(%q, %d, %v): error %v, want one containing %q", tt.name, tt.j, tt.iv, err, tt.err)
+		}
+	}
+	if len(c.Tests) != 1 || len(c.Tests["exp"].Intervals) != 1 {
+		t.Errorf("invalid intervals were set: %+v", c.Tests)
+	}
+}
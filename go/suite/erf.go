@@ -0,0 +1,339 @@
+package suite
+
+import (
+	"fmt"
+	"math"
+
+	"golefunt/elefunt"
+	"golefunt/random"
+)
+
+// Erf tests Erf/Erfc/Erfinv/Erfcinv in the precision of T, recording the
+// results in rep. There is no ELEFUNT program for these functions; the tests
+// follow the methodology of W.J. Cody's tests of the special functions,
+// comparing ERF(X) with its Taylor series for small X and ERFC(X) with its
+// continued fraction for large X, and report where ERFC(X) underflows.
+func Erf[T elefunt.Real](rep *elefunt.Report, fs *elefunt.Funcs[T], opts Options) {
+	rep.Precision = elefunt.Precision[T]()
+
+	// Get machine parameters
+	mp := params[T](opts)
+	rng := opts.random()
+
+	// Functions under test
+	erf := fs.Func1("erf")
+	erfc := fs.Func1("erfc")
+	erfinv := fs.Func1("erfinv")
+	erfcinv := fs.Func1("erfcinv")
+	exp := fs.Func1("exp")
+
+	// Errors in ULPs against the reference, when enabled
+	erfULPs := ulps1[T](opts, mp, "erf")
+	erfcULPs := ulps1[T](opts, mp, "erfc")
+	erfinvULPs := ulps1[T](opts, mp, "erfinv")
+	erfcinvULPs := ulps1[T](opts, mp, "erfcinv")
+
+	beta := T(mp.IBeta)
+	one := T(1.0)
+	two := T(2.0)
+	half := T(0.5)
+	zero := T(0.0)
+	c := T(2 / math.Sqrt(math.Pi))
+	rpi := T(1 / math.Sqrt(math.Pi))
+
+	// A number of at most IT/2 digits has an exact square. Past XU, ERFC(X)
+	// is below XMIN; 2*IT terms of its continued fraction reach full
+	// precision for X of at least 4
+	bb := float64(mp.IBeta)
+	digits2 := float64(mp.IT / 2)
+	xu := T(erfcUnderflow(mp.XMin))
+	terms := 2 * mp.IT
+
+	a := zero
+	b := half
+	n := opts.samples()
+
+	// Random argument accuracy tests
+	for j := 1; j <= 6; j++ {
+		lo, hi := interval(opts, j, a, b)
+		acc := randomTest(opts, mp, rng, j, n, lo, hi, func(acc *elefunt.Accumulator, rng *random.Generator, xl, del T) {
+			x := del*elefunt.Random[T](rng) + xl
+
+			var z, zz T
+			switch j {
+			case 1:
+				// Test ERF(X) vs 2/SQRT(PI)*(X - X**3/3 + X**5/10 - ...),
+				// summed until the terms no longer change it
+				z = erf(x)
+				erfULPs(acc, x, z)
+				x2 := x * x
+				term, sum := x, x
+				for k := 1; ; k++ {
+					term = -term * x2 / T(k)
+					t := term / T(2*k+1)
+					if sum+t == sum {
+						break
+					}
+					sum = sum + t
+				}
+				zz = c * sum
+			case 2:
+				// Test ERFC(X) vs 1-ERF(X), where ERF(X) is negative or
+				// below about 0.52 and the subtraction loses no accuracy
+				z = erfc(x)
+				erfcULPs(acc, x, z)
+				zz = one - erf(x)
+			case 3:
+				// Test ERFINV(ERF(X)) vs X
+				y := erf(x)
+				z = erfinv(y)
+				erfinvULPs(acc, y, z)
+				zz = x
+			case 4:
+				// Test ERFCINV(ERFC(X)) vs X
+				y := erfc(x)
+				z = erfcinv(y)
+				erfcinvULPs(acc, y, z)
+				zz = x
+			default:
+				// Test ERFC(X) vs E**(-X*X)/SQRT(PI)/(X+(1/2)/(X+1/(X+(3/2)/(X+...)))),
+				// purifying X so that X*X is exact
+				x = chop(x, bb, digits2)
+				z = erfc(x)
+				erfcULPs(acc, x, z)
+				f := x
+				for k := terms; k >= 1; k-- {
+					f = x + T(k)*half/f
+				}
+				zz = exp(-x*x) * rpi / f
+			}
+
+			acc.Add(float64(x), float64(z), float64(zz))
+		})
+
+		res := acc.Result()
+
+		switch j {
+		case 1:
+			res.Identity = "ERF(X) VS TAYLOR SERIES"
+		case 2:
+			res.Identity = "ERFC(X) VS 1-ERF(X)"
+		case 3:
+			res.Identity = "ERFINV(ERF(X)) VS X"
+		case 4:
+			res.Identity = "ERFCINV(ERFC(X)) VS X"
+		default:
+			res.Identity = "ERFC(X) VS CONTINUED FRACTION"
+		}
+		res.WriteHeading(rep)
+
+		switch j {
+		case 1:
+			fmt.Fprintf(rep, " ERF(X) WAS LARGER %6d TIMES,\n", res.Larger)
+		case 3:
+			fmt.Fprintf(rep, " ERFINV(Y) WAS LARGER %6d TIMES,\n", res.Larger)
+		case 4:
+			fmt.Fprintf(rep, " ERFCINV(Y) WAS LARGER %6d TIMES,\n", res.Larger)
+		default:
+			fmt.Fprintf(rep, " ERFC(X) WAS LARGER %6d TIMES,\n", res.Larger)
+		}
+		fmt.Fprintf(rep, "             AGREED %6d TIMES, AND\n", res.Agreed)
+		fmt.Fprintf(rep, "         WAS SMALLER %6d TIMES.\n\n", res.Smaller)
+		res.WriteErrors(rep)
+		rep.AddResult(res)
+
+		switch j {
+		case 1:
+			a, b = -two, half
+		case 2:
+			a, b = zero, one
+		case 3:
+			a, b = one, 4.0
+		case 4:
+			a, b = 4.0, 8.0
+		case 5:
+			a, b = xu*half, xu
+		}
+	}
+
+	// Special tests
+	fmt.Fprintln(rep, "\nSPECIAL TESTS")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY  ERF(-X) = -ERF(X)  WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         F(X) + F(-X)")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng) * 4.0
+		z := erf(x) + erf(-x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("ERF(-X) = -ERF(X)", float64(x), float64(z))
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY  ERFC(-X) = 2-ERFC(X)  WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X      F(X) + F(-X) - 2")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng) * 2.0
+		z := erfc(x) + erfc(-x) - two
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("ERFC(-X) = 2-ERFC(X)", float64(x), float64(z))
+	}
+
+	betap := T(math.Pow(float64(beta), float64(mp.IT)))
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY ERF(X) = 2X/SQRT(PI) , X SMALL, WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X      2X/SQRT(PI) - F(X)")
+
+	x := elefunt.Random[T](rng) / betap
+	for i := 1; i <= 5; i++ {
+		z := c*x - erf(x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("ERF(X) = 2X/SQRT(PI), X SMALL", float64(x), float64(z))
+		x = x / beta
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " TEST OF SPECIAL ARGUMENTS")
+	fmt.Fprintln(rep)
+
+	y := erf(zero)
+	fmt.Fprintf(rep, " ERF(0.0) = %.7E\n", y)
+	rep.AddSpecial("ERF(0.0)", float64(y))
+
+	y = erfc(zero)
+	fmt.Fprintf(rep, " ERFC(0.0) = %.7E\n", y)
+	rep.AddSpecial("ERFC(0.0)", float64(y))
+
+	y = erfinv(zero)
+	fmt.Fprintf(rep, " ERFINV(0.0) = %.7E\n", y)
+	rep.AddSpecial("ERFINV(0.0)", float64(y))
+
+	y = erfcinv(one)
+	fmt.Fprintf(rep, " ERFCINV(1.0) = %.7E\n", y)
+	rep.AddSpecial("ERFCINV(1.0)", float64(y))
+
+	y = erfinv(half)
+	fmt.Fprintf(rep, " ERFINV(0.5) = %.17E\n", y)
+	rep.AddSpecial("ERFINV(0.5)", float64(y))
+
+	x = T(mp.XMin)
+	y = erf(x)
+	fmt.Fprintf(rep, " ERF(XMIN) = ERF(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("ERF(XMIN)", float64(y), float64(x))
+
+	y = erfinv(x)
+	fmt.Fprintf(rep, " ERFINV(XMIN) = ERFINV(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("ERFINV(XMIN)", float64(y), float64(x))
+
+	y = erfcinv(x)
+	fmt.Fprintf(rep, " ERFCINV(XMIN) = ERFCINV(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("ERFCINV(XMIN)", float64(y), float64(x))
+
+	// Find the consecutive arguments between which ERFC(X) falls below
+	// XMIN by bisection
+	xmin := T(mp.XMin)
+	lo, hi := one, T(math.Sqrt(-math.Log(mp.XMin)))+one
+	for {
+		mid := lo + (hi-lo)*half
+		if mid <= lo || mid >= hi {
+			break
+		}
+		if erfc(mid) >= xmin {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " TEST OF UNDERFLOW OF ERFC FOR LARGE ARGUMENT.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE FOLLOWING TWO LINES SHOW THE CONSECUTIVE ARGUMENTS BETWEEN")
+	fmt.Fprintln(rep, " WHICH ERFC(X) FALLS BELOW XMIN.  THE LAST TWO GIVE XMIN AND THE")
+	fmt.Fprintln(rep, " ARGUMENT WHERE THE ASYMPTOTIC EXPANSION OF ERFC(X) EQUALS XMIN.")
+
+	y = erfc(lo)
+	fmt.Fprintf(rep, "\n      ERFC(%.16E) = %.16E\n", lo, y)
+	rep.AddSpecial("ERFC(XU)", float64(y), float64(lo))
+	y = erfc(hi)
+	fmt.Fprintf(rep, "\n      ERFC(%.16E) = %.16E\n", hi, y)
+	rep.AddSpecial("ERFC(XU+ULP)", float64(y), float64(hi))
+	fmt.Fprintf(rep, "\n      XMIN = %.6E\n", xmin)
+	rep.AddSpecial("XMIN", float64(xmin))
+	fmt.Fprintf(rep, "\n      XU = %.6E\n", xu)
+	rep.AddSpecial("XU", float64(xu))
+
+	// Test of error returns
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "TEST OF ERROR RETURNS")
+	fmt.Fprintln(rep)
+
+	x = T(mp.XMax)
+	fmt.Fprintf(rep, " ERFC WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD UNDERFLOW")
+	fmt.Fprintln(rep)
+	y = erfc(x)
+	fmt.Fprintf(rep, " ERFC RETURNED THE VALUE %.4E\n\n", y)
+	rep.AddError("ERFC(X)", "UNDERFLOW", float64(y), float64(x))
+
+	x = one
+	fmt.Fprintf(rep, " ERFINV WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD RETURN +Inf")
+	fmt.Fprintln(rep)
+	y = erfinv(x)
+	fmt.Fprintf(rep, " ERFINV RETURNED THE VALUE %v\n\n", y)
+	rep.AddError("ERFINV(X)", "+Inf", float64(y), float64(x))
+
+	x = -one
+	fmt.Fprintf(rep, " ERFINV WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD RETURN -Inf")
+	fmt.Fprintln(rep)
+	y = erfinv(x)
+	fmt.Fprintf(rep, " ERFINV RETURNED THE VALUE %v\n\n", y)
+	rep.AddError("ERFINV(X)", "-Inf", float64(y), float64(x))
+
+	x = two
+	fmt.Fprintf(rep, " ERFINV WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD RETURN NaN")
+	fmt.Fprintln(rep)
+	y = erfinv(x)
+	fmt.Fprintf(rep, " ERFINV RETURNED THE VALUE %.4E\n\n", y)
+	rep.AddError("ERFINV(X)", "NaN", float64(y), float64(x))
+
+	x = zero
+	fmt.Fprintf(rep, " ERFCINV WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD RETURN +Inf")
+	fmt.Fprintln(rep)
+	y = erfcinv(x)
+	fmt.Fprintf(rep, " ERFCINV RETURNED THE VALUE %v\n\n", y)
+	rep.AddError("ERFCINV(X)", "+Inf", float64(y), float64(x))
+
+	x = two
+	fmt.Fprintf(rep, " ERFCINV WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD RETURN -Inf")
+	fmt.Fprintln(rep)
+	y = erfcinv(x)
+	fmt.Fprintf(rep, " ERFCINV RETURNED THE VALUE %v\n\n", y)
+	rep.AddError("ERFCINV(X)", "-Inf", float64(y), float64(x))
+
+	fmt.Fprintln(rep, " THIS CONCLUDES THE TESTS")
+}
+
+// erfcUnderflow returns the argument at which the asymptotic expansion
+// E**(-X*X)/(X*SQRT(PI))*(1-1/(2X*X)) of ERFC(X) equals xmin.
+func erfcUnderflow(xmin float64) float64 {
+	l := math.Log(xmin)
+	x := math.Sqrt(-l)
+	for i := 0; i < 20; i++ {
+		u := 1 / (2 * x * x)
+		f := -x*x - math.Log(x*math.Sqrt(math.Pi)) + math.Log1p(-u) - l
+		df := -2*x - 1/x + 2*u/x/(1-u)
+		x -= f / df
+	}
+	return x
+}
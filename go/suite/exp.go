@@ -0,0 +1,144 @@
+package suite
+
+import (
+	"fmt"
+	"math"
+
+	"golefunt/elefunt"
+	"golefunt/random"
+)
+
+// Exp tests Exp in the precision of T, recording the results in rep.
+// It is a port of the elefunt exp.f and dexp.f test programs by W.J. Cody.
+func Exp[T elefunt.Real](rep *elefunt.Report, fs *elefunt.Funcs[T], opts Options) {
+	rep.Precision = elefunt.Precision[T]()
+
+	// Get machine parameters
+	mp := params[T](opts)
+	rng := opts.random()
+
+	// Functions under test
+	exp := fs.Func1("exp")
+
+	// Errors in ULPs against the reference, when enabled
+	expULPs := ulps1[T](opts, mp, "exp")
+
+	beta := T(mp.IBeta)
+	one := T(1.0)
+	two := T(2.0)
+	ten := T(10.0)
+	zero := T(0.0)
+	v := T(0.0625)
+	a := two
+	b := T(math.Log(float64(a))) * 0.5
+	a = -b + v
+	d := T(math.Log(0.9 * mp.XMax))
+	n := opts.samples()
+
+	// Random argument accuracy tests
+	for j := 1; j <= 3; j++ {
+		lo, hi := interval(opts, j, a, b)
+		id := elefunt.ExpShift[T]()
+		if j != 1 {
+			id = elefunt.ExpShiftLarge[T](mp.IBeta)
+		}
+		acc := randomTest(opts, mp, rng, j, n, lo, hi, func(acc *elefunt.Accumulator, rng *random.Generator, xl, del T) {
+			x, y, z, zz := id.Eval(exp, del*elefunt.Random[T](rng)+xl)
+			expULPs(acc, y, z)
+
+			// The error is that of EXP(X)/EXP(V) against EXP(X-V)
+			w := one
+			if z != zero {
+				w = (zz - z) / z
+			}
+			acc.AddError(float64(x), float64(w))
+		})
+
+		res := acc.Result()
+		res.Identity = id.Name
+
+		res.WriteHeading(rep)
+		// The error is measured against EXP(X-V), larger when it is negative
+		elefunt.WriteCounts(rep, "EXP(X-V)", res.Smaller, res.Agreed, res.Larger)
+		res.WriteErrors(rep)
+		rep.AddResult(res)
+
+		if j != 2 {
+			a = -ten * b
+			b = T(math.Log(4.0 * mp.XMin * math.Pow(float64(beta), float64(mp.IT))))
+		} else {
+			a = -two * a
+			b = ten * a
+			if b < d {
+				b = d
+			}
+		}
+	}
+
+	// Special tests
+	fmt.Fprintln(rep, "\nSPECIAL TESTS")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY  EXP(X)*EXP(-X) = 1.0  WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         F(X)*F(-X) - 1")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng) * beta
+		y := -x
+		z := exp(x)*exp(y) - one
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("EXP(X)*EXP(-X) = 1.0", float64(x), float64(z))
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " TEST OF SPECIAL ARGUMENTS")
+	fmt.Fprintln(rep)
+
+	x := zero
+	y := exp(x) - one
+	fmt.Fprintf(rep, " EXP(0.0) - 1.0 = %.7E\n", y)
+	rep.AddSpecial("EXP(0.0) - 1.0", float64(y))
+
+	x = T(math.Floor(math.Log(mp.XMin)))
+	y = exp(x)
+	fmt.Fprintf(rep, " EXP(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("EXP(FLOOR(LOG(XMIN)))", float64(y), float64(x))
+
+	x = T(math.Floor(math.Log(mp.XMax)))
+	y = exp(x)
+	fmt.Fprintf(rep, " EXP(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("EXP(FLOOR(LOG(XMAX)))", float64(y), float64(x))
+
+	x = x / two
+	v = x / two
+	y = exp(x)
+	z := exp(v)
+	z = z * z
+	fmt.Fprintf(rep, "\n IF EXP(%.6E) = %.6E IS NOT ABOUT\n", x, y)
+	fmt.Fprintf(rep, " EXP(%.6E)**2 = %.6E THERE IS AN ARG RED ERROR\n", v, z)
+	rep.AddSpecial("EXP(X)", float64(y), float64(x))
+	rep.AddSpecial("EXP(X/2)**2", float64(z), float64(x))
+
+	// Test of error returns
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "TEST OF ERROR RETURNS")
+	fmt.Fprintln(rep)
+
+	x = -one / T(math.Sqrt(mp.XMin))
+	fmt.Fprintf(rep, " EXP WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD UNDERFLOW")
+	fmt.Fprintln(rep)
+	y = exp(x)
+	fmt.Fprintf(rep, " EXP RETURNED THE VALUE %.4E\n\n", y)
+	rep.AddError("EXP(X)", "UNDERFLOW", float64(y), float64(x))
+
+	x = -x
+	fmt.Fprintf(rep, " EXP WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD OVERFLOW")
+	fmt.Fprintln(rep)
+	y = exp(x)
+	fmt.Fprintf(rep, " EXP RETURNED THE VALUE %.4E\n\n", y)
+	rep.AddError("EXP(X)", "OVERFLOW", float64(y), float64(x))
+
+	fmt.Fprintln(rep, " THIS CONCLUDES THE TESTS")
+}
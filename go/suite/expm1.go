@@ -0,0 +1,232 @@
+package suite
+
+import (
+	"fmt"
+	"math"
+
+	"golefunt/elefunt"
+	"golefunt/random"
+)
+
+// Expm1 tests Expm1/Log1p in the precision of T, recording the results in rep.
+// There is no ELEFUNT program for these functions; the tests follow the
+// structure of exp.f and alog.f by W.J. Cody, with identities that keep
+// their accuracy for arguments near zero, where e**x - 1 and log(1+x)
+// computed directly lose it.
+func Expm1[T elefunt.Real](rep *elefunt.Report, fs *elefunt.Funcs[T], opts Options) {
+	rep.Precision = elefunt.Precision[T]()
+
+	// Get machine parameters
+	mp := params[T](opts)
+	rng := opts.random()
+
+	// Functions under test
+	expm1 := fs.Func1("expm1")
+	log1p := fs.Func1("log1p")
+	log := fs.Func1("log")
+
+	// Errors in ULPs against the reference, when enabled
+	expm1ULPs := ulps1[T](opts, mp, "expm1")
+	log1pULPs := ulps1[T](opts, mp, "log1p")
+
+	beta := T(mp.IBeta)
+	one := T(1.0)
+	two := T(2.0)
+	half := T(0.5)
+	zero := T(0.0)
+
+	// Past IT*LN(BETA), EXPM1(X) and EXP(X) round to the same value
+	a := -one
+	b := one
+	c := T(float64(mp.IT) * math.Log(float64(mp.IBeta)))
+	n := opts.samples()
+
+	// Random argument accuracy tests
+	for j := 1; j <= 4; j++ {
+		lo, hi := interval(opts, j, a, b)
+		acc := randomTest(opts, mp, rng, j, n, lo, hi, func(acc *elefunt.Accumulator, rng *random.Generator, xl, del T) {
+			x := del*elefunt.Random[T](rng) + xl
+
+			var z, zz T
+			if j <= 2 {
+				// Test EXPM1(X) vs identity
+				// EXPM1(2X) = EXPM1(X)*(EXPM1(X)+2)
+				y := x * half
+				x = y + y
+				z = expm1(x)
+				expm1ULPs(acc, x, z)
+				zz = expm1(y)
+				zz = zz * (zz + two)
+			} else if j == 3 {
+				// Test LOG1P(X) vs LOG(1+X), purifying X so that 1+X is exact
+				y := x + one
+				x = y - one
+				z = log1p(x)
+				log1pULPs(acc, x, z)
+				zz = log(y)
+			} else {
+				// Test LOG1P(X) vs LOG(X)+LOG1P(1/X)
+				z = log1p(x)
+				log1pULPs(acc, x, z)
+				zz = log(x) + log1p(one/x)
+			}
+
+			acc.Add(float64(x), float64(z), float64(zz))
+		})
+
+		res := acc.Result()
+
+		if j <= 2 {
+			res.Identity = "EXPM1(X) VS EXPM1(X/2)*(EXPM1(X/2)+2)"
+		} else if j == 3 {
+			res.Identity = "LOG1P(X) VS LOG(1+X)"
+		} else {
+			res.Identity = "LOG1P(X) VS LOG(X)+LOG1P(1/X)"
+		}
+		res.WriteHeading(rep)
+
+		if j <= 2 {
+			fmt.Fprintf(rep, " EXPM1(X) WAS LARGER %6d TIMES,\n", res.Larger)
+		} else {
+			fmt.Fprintf(rep, " LOG1P(X) WAS LARGER %6d TIMES,\n", res.Larger)
+		}
+		fmt.Fprintf(rep, "             AGREED %6d TIMES, AND\n", res.Agreed)
+		fmt.Fprintf(rep, "         WAS SMALLER %6d TIMES.\n\n", res.Smaller)
+		res.WriteErrors(rep)
+		rep.AddResult(res)
+
+		switch j {
+		case 1:
+			a, b = one, c
+		case 2:
+			a, b = -half, one
+		case 3:
+			a, b = one, 16.0
+		}
+	}
+
+	// Special tests
+	fmt.Fprintln(rep, "\nSPECIAL TESTS")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY  EXPM1(-X) = -EXPM1(X)/(1+EXPM1(X))  WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X      F(-X)*(1+F(X)) + F(X)")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng)
+		y := expm1(x)
+		z := expm1(-x)*(one+y) + y
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("EXPM1(-X) = -EXPM1(X)/(1+EXPM1(X))", float64(x), float64(z))
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY  LOG1P(EXPM1(X)) = X  WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         X - G(F(X))")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng) - half
+		z := x - log1p(expm1(x))
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("LOG1P(EXPM1(X)) = X", float64(x), float64(z))
+	}
+
+	betap := T(math.Pow(float64(beta), float64(mp.IT)))
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY EXPM1(X) = X , X SMALL, WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         X - F(X)")
+
+	x := elefunt.Random[T](rng) / betap
+	for i := 1; i <= 5; i++ {
+		z := x - expm1(x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("EXPM1(X) = X, X SMALL", float64(x), float64(z))
+		x = x / beta
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY LOG1P(X) = X , X SMALL, WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         X - F(X)")
+
+	x = elefunt.Random[T](rng) / betap
+	for i := 1; i <= 5; i++ {
+		z := x - log1p(x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("LOG1P(X) = X, X SMALL", float64(x), float64(z))
+		x = x / beta
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " TEST OF SPECIAL ARGUMENTS")
+	fmt.Fprintln(rep)
+
+	y := expm1(zero)
+	fmt.Fprintf(rep, " EXPM1(0.0) = %.7E\n", y)
+	rep.AddSpecial("EXPM1(0.0)", float64(y))
+
+	y = log1p(zero)
+	fmt.Fprintf(rep, " LOG1P(0.0) = %.7E\n", y)
+	rep.AddSpecial("LOG1P(0.0)", float64(y))
+
+	x = T(mp.XMin)
+	y = expm1(x)
+	fmt.Fprintf(rep, " EXPM1(XMIN) = EXPM1(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("EXPM1(XMIN)", float64(y), float64(x))
+
+	y = log1p(x)
+	fmt.Fprintf(rep, " LOG1P(XMIN) = LOG1P(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("LOG1P(XMIN)", float64(y), float64(x))
+
+	x = T(math.Floor(math.Log(mp.XMax)))
+	y = expm1(x)
+	fmt.Fprintf(rep, " EXPM1(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("EXPM1(FLOOR(LOG(XMAX)))", float64(y), float64(x))
+
+	x = T(mp.XMax)
+	y = log1p(x)
+	fmt.Fprintf(rep, " LOG1P(XMAX) = LOG1P(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("LOG1P(XMAX)", float64(y), float64(x))
+
+	// Test of error returns
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "TEST OF ERROR RETURNS")
+	fmt.Fprintln(rep)
+
+	x = -one / T(math.Sqrt(mp.XMin))
+	fmt.Fprintf(rep, " EXPM1 WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD RETURN -1")
+	fmt.Fprintln(rep)
+	y = expm1(x)
+	fmt.Fprintf(rep, " EXPM1 RETURNED THE VALUE %.4E\n\n", y)
+	rep.AddError("EXPM1(X)", "-1", float64(y), float64(x))
+
+	x = -x
+	fmt.Fprintf(rep, " EXPM1 WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD OVERFLOW")
+	fmt.Fprintln(rep)
+	y = expm1(x)
+	fmt.Fprintf(rep, " EXPM1 RETURNED THE VALUE %.4E\n\n", y)
+	rep.AddError("EXPM1(X)", "OVERFLOW", float64(y), float64(x))
+
+	x = -two
+	fmt.Fprintf(rep, " LOG1P WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD RETURN NaN")
+	fmt.Fprintln(rep)
+	y = log1p(x)
+	fmt.Fprintf(rep, " LOG1P RETURNED THE VALUE %.4E\n\n", y)
+	rep.AddError("LOG1P(X)", "NaN", float64(y), float64(x))
+
+	x = -one
+	fmt.Fprintf(rep, " LOG1P WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD RETURN -Inf")
+	fmt.Fprintln(rep)
+	y = log1p(x)
+	fmt.Fprintf(rep, " LOG1P RETURNED THE VALUE %.4E\n\n", y)
+	rep.AddError("LOG1P(X)", "-Inf", float64(y), float64(x))
+
+	fmt.Fprintln(rep, " THIS CONCLUDES THE TESTS")
+}
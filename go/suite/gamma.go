@@ -0,0 +1,288 @@
+package suite
+
+import (
+	"fmt"
+	"math"
+
+	"golefunt/elefunt"
+	"golefunt/random"
+)
+
+// Gamma tests Gamma/Lgamma in the precision of T, recording the results in
+// rep. There is no ELEFUNT program for these functions; the tests follow
+// the methodology of W.J. Cody's tests of the special functions, checking
+// the recurrence GAMMA(X+1) = X*GAMMA(X) and the duplication formula with
+// purified arguments from near zero to near overflow.
+func Gamma[T elefunt.Real](rep *elefunt.Report, fs *elefunt.Funcs[T], opts Options) {
+	rep.Precision = elefunt.Precision[T]()
+
+	// Get machine parameters
+	mp := params[T](opts)
+	rng := opts.random()
+
+	// Functions under test
+	gamma := fs.Func1("gamma")
+	lgamma := fs.Func1("lgamma")
+	signgam := fs.Func1("signgam")
+	log := fs.Func1("log")
+	sin := fs.Func1("sin")
+
+	// Errors in ULPs against the reference, when enabled
+	gammaULPs := ulps1[T](opts, mp, "gamma")
+	lgammaULPs := ulps1[T](opts, mp, "lgamma")
+
+	beta := T(mp.IBeta)
+	one := T(1.0)
+	two := T(2.0)
+	half := T(0.5)
+	zero := T(0.0)
+	pi := T(math.Pi)
+	ln2 := T(math.Ln2)
+	lnpi2 := T(math.Log(math.Pi) / 2)
+
+	// GAMMA(X) overflows past XBIG. LGAMMA(X) does not overflow below XLBIG,
+	// with XLBIG*LOG(XLBIG) < XMAX
+	xbig := T(xbig(mp.XMax))
+	xlbig := T(mp.XMax / math.Log(mp.XMax))
+
+	a := zero
+	b := one
+	n := opts.samples()
+
+	// Random argument accuracy tests
+	for j := 1; j <= 6; j++ {
+		lo, hi := interval(opts, j, a, b)
+		acc := randomTest(opts, mp, rng, j, n, lo, hi, func(acc *elefunt.Accumulator, rng *random.Generator, xl, del T) {
+			x := del*elefunt.Random[T](rng) + xl
+
+			var z, zz T
+			if j <= 2 {
+				// Test GAMMA(X) vs GAMMA(X+1)/X, purifying X so that
+				// X+1 is exact
+				y := x + one
+				x = y - one
+				z = gamma(x)
+				gammaULPs(acc, x, z)
+				zz = gamma(y) / x
+			} else if j <= 4 {
+				// Test GAMMA(X) vs (X-1)*GAMMA(X-1), purifying X so
+				// that X-1 is exact
+				y := x - one
+				x = y + one
+				z = gamma(x)
+				gammaULPs(acc, x, z)
+				zz = y * gamma(y)
+			} else {
+				// Test LGAMMA(X) vs the duplication formula, purifying
+				// X so that X/2+1/2 is exact
+				y := x * half
+				w := y + half
+				y = w - half
+				x = y + y
+				z = lgamma(x)
+				lgammaULPs(acc, x, z)
+				zz = ((x-one)*ln2 - lnpi2) + lgamma(y) + lgamma(w)
+			}
+
+			acc.Add(float64(x), float64(z), float64(zz))
+		})
+
+		res := acc.Result()
+
+		if j <= 2 {
+			res.Identity = "GAMMA(X) VS GAMMA(X+1)/X"
+		} else if j <= 4 {
+			res.Identity = "GAMMA(X) VS (X-1)*GAMMA(X-1)"
+		} else {
+			res.Identity = "LGAMMA(X) VS (X-1)*LN(2)-LN(PI)/2+LGAMMA(X/2)+LGAMMA(X/2+1/2)"
+		}
+		res.WriteHeading(rep)
+
+		if j <= 4 {
+			fmt.Fprintf(rep, " GAMMA(X) WAS LARGER %6d TIMES,\n", res.Larger)
+		} else {
+			fmt.Fprintf(rep, " LGAMMA(X) WAS LARGER %6d TIMES,\n", res.Larger)
+		}
+		fmt.Fprintf(rep, "             AGREED %6d TIMES, AND\n", res.Agreed)
+		fmt.Fprintf(rep, "         WAS SMALLER %6d TIMES.\n\n", res.Smaller)
+		res.WriteErrors(rep)
+		rep.AddResult(res)
+
+		switch j {
+		case 1:
+			a, b = -one, zero
+		case 2:
+			a, b = two, 10.0
+		case 3:
+			a, b = xbig*half, xbig
+		case 4:
+			a, b = T(4.0), T(16.0)
+		case 5:
+			a, b = xlbig*half, xlbig
+		}
+	}
+
+	// Special tests
+	fmt.Fprintln(rep, "\nSPECIAL TESTS")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY  GAMMA(X)*GAMMA(1-X) = PI/SIN(PI*X)  WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X      F(X)*F(1-X)*SIN(PI*X)/PI - 1")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng)
+		y := one - x
+		x = one - y
+		z := gamma(x)*gamma(y)*sin(pi*x)/pi - one
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("GAMMA(X)*GAMMA(1-X) = PI/SIN(PI*X)", float64(x), float64(z))
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY  SIGNGAM(X) = SIGN(GAMMA(X))  WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X      SIGNGAM(X) - SIGN(GAMMA(X))")
+
+	for i := 1; i <= 5; i++ {
+		// GAMMA(X) is negative between -2K-1 and -2K
+		x := -T(i) + half*elefunt.Random[T](rng)
+		sign := one
+		if math.Mod(math.Floor(float64(x)), 2) != 0 {
+			sign = -one
+		}
+		z := signgam(x) - sign
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("SIGNGAM(X) = SIGN(GAMMA(X))", float64(x), float64(z))
+	}
+
+	betap := T(math.Pow(float64(beta), float64(mp.IT)))
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY LGAMMA(X) = -LN(X) , X SMALL, WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X      F(X) + LN(X)")
+
+	x := elefunt.Random[T](rng) / betap
+	for i := 1; i <= 5; i++ {
+		z := lgamma(x) + log(x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("LGAMMA(X) = -LN(X), X SMALL", float64(x), float64(z))
+		x = x / beta
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " TEST OF SPECIAL ARGUMENTS")
+	fmt.Fprintln(rep)
+
+	y := gamma(one)
+	fmt.Fprintf(rep, " GAMMA(1.0) = %.17E\n", y)
+	rep.AddSpecial("GAMMA(1.0)", float64(y))
+
+	y = gamma(half)
+	fmt.Fprintf(rep, " GAMMA(0.5) = %.17E\n", y)
+	rep.AddSpecial("GAMMA(0.5)", float64(y))
+
+	y = gamma(-half)
+	fmt.Fprintf(rep, " GAMMA(-0.5) = %.17E\n", y)
+	rep.AddSpecial("GAMMA(-0.5)", float64(y))
+
+	y = gamma(-1.5)
+	fmt.Fprintf(rep, " GAMMA(-1.5) = %.17E\n", y)
+	rep.AddSpecial("GAMMA(-1.5)", float64(y))
+
+	y = lgamma(-half)
+	fmt.Fprintf(rep, " LGAMMA(-0.5) = %.17E\n", y)
+	rep.AddSpecial("LGAMMA(-0.5)", float64(y))
+
+	y = signgam(-half)
+	fmt.Fprintf(rep, " SIGNGAM(-0.5) = %.7E\n", y)
+	rep.AddSpecial("SIGNGAM(-0.5)", float64(y))
+
+	y = lgamma(one)
+	fmt.Fprintf(rep, " LGAMMA(1.0) = %.7E\n", y)
+	rep.AddSpecial("LGAMMA(1.0)", float64(y))
+
+	y = lgamma(two)
+	fmt.Fprintf(rep, " LGAMMA(2.0) = %.7E\n", y)
+	rep.AddSpecial("LGAMMA(2.0)", float64(y))
+
+	x = T(mp.XMin)
+	y = gamma(x)
+	fmt.Fprintf(rep, " GAMMA(XMIN) = GAMMA(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("GAMMA(XMIN)", float64(y), float64(x))
+
+	x = xbig
+	y = gamma(x)
+	fmt.Fprintf(rep, " GAMMA(XBIG) = GAMMA(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("GAMMA(XBIG)", float64(y), float64(x))
+
+	x = xlbig
+	y = lgamma(x)
+	fmt.Fprintf(rep, " LGAMMA(XLBIG) = LGAMMA(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("LGAMMA(XLBIG)", float64(y), float64(x))
+
+	// Test of error returns
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "TEST OF ERROR RETURNS")
+	fmt.Fprintln(rep)
+
+	x = zero
+	fmt.Fprintf(rep, " GAMMA WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD RETURN +Inf")
+	fmt.Fprintln(rep)
+	y = gamma(x)
+	fmt.Fprintf(rep, " GAMMA RETURNED THE VALUE %v\n\n", y)
+	rep.AddError("GAMMA(X)", "+Inf", float64(y), float64(x))
+
+	x = -one
+	fmt.Fprintf(rep, " GAMMA WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD RETURN NaN")
+	fmt.Fprintln(rep)
+	y = gamma(x)
+	fmt.Fprintf(rep, " GAMMA RETURNED THE VALUE %.4E\n\n", y)
+	rep.AddError("GAMMA(X)", "NaN", float64(y), float64(x))
+
+	x = xbig + one
+	fmt.Fprintf(rep, " GAMMA WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD OVERFLOW")
+	fmt.Fprintln(rep)
+	y = gamma(x)
+	fmt.Fprintf(rep, " GAMMA RETURNED THE VALUE %.4E\n\n", y)
+	rep.AddError("GAMMA(X)", "OVERFLOW", float64(y), float64(x))
+
+	x = -two
+	fmt.Fprintf(rep, " LGAMMA WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD RETURN +Inf")
+	fmt.Fprintln(rep)
+	y = lgamma(x)
+	fmt.Fprintf(rep, " LGAMMA RETURNED THE VALUE %v\n\n", y)
+	rep.AddError("LGAMMA(X)", "+Inf", float64(y), float64(x))
+
+	x = T(mp.XMax)
+	fmt.Fprintf(rep, " LGAMMA WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD OVERFLOW")
+	fmt.Fprintln(rep)
+	y = lgamma(x)
+	fmt.Fprintf(rep, " LGAMMA RETURNED THE VALUE %.4E\n\n", y)
+	rep.AddError("LGAMMA(X)", "OVERFLOW", float64(y), float64(x))
+
+	fmt.Fprintln(rep, " THIS CONCLUDES THE TESTS")
+}
+
+// xbig returns the largest argument for which GAMMA(X) does not exceed xmax,
+// rounded down to four decimals so that it stays below in every precision.
+func xbig(xmax float64) float64 {
+	lo, hi := 1.0, 2.0
+	for l, _ := math.Lgamma(hi); l < math.Log(xmax); l, _ = math.Lgamma(hi) {
+		lo, hi = hi, 2*hi
+	}
+	for i := 0; i < 40; i++ {
+		mid := (lo + hi) / 2
+		if l, _ := math.Lgamma(mid); l < math.Log(xmax) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return math.Floor(lo*1e4) / 1e4
+}
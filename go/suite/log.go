@@ -0,0 +1,139 @@
+package suite
+
+import (
+	"fmt"
+	"math"
+
+	"golefunt/elefunt"
+	"golefunt/random"
+)
+
+// Log tests Log in the precision of T, recording the results in rep.
+// It is a port of the elefunt alog.f and dlog.f test programs by W.J. Cody.
+func Log[T elefunt.Real](rep *elefunt.Report, fs *elefunt.Funcs[T], opts Options) {
+	rep.Precision = elefunt.Precision[T]()
+
+	// Get machine parameters
+	mp := params[T](opts)
+	rng := opts.random()
+
+	// Functions under test
+	log := fs.Func1("log")
+	log10 := fs.Func1("log10")
+
+	// Errors in ULPs against the reference, when enabled
+	logULPs := ulps1[T](opts, mp, "log")
+	log10ULPs := ulps1[T](opts, mp, "log10")
+
+	one := T(1.0)
+	zero := T(0.0)
+	half := T(0.5)
+	eight := T(8.0)
+
+	// For log test: test interval is [1/sqrt(2), sqrt(2)]
+	a := one / T(math.Sqrt(2.0))
+	b := T(math.Sqrt(2.0))
+	n := opts.samples()
+
+	// Random argument accuracy tests
+	for j := 1; j <= 4; j++ {
+		lo, hi := interval(opts, j, a, b)
+		var id elefunt.Identity[T]
+		switch j {
+		case 1:
+			id = elefunt.LogScale[T](16)
+		case 2:
+			id = elefunt.LogScale[T](10)
+		case 3:
+			id = elefunt.LogSquare[T]()
+		default:
+			// Test LOG10(X) vs LOG(X)/LOG(10)
+			id = elefunt.Identity[T]{
+				Name: "LOG10(X) VS LOG(X)/LOG(10)",
+				Eval: func(f func(T) T, x T) (T, T, T, T) {
+					return x, x, log10(x), f(x) / f(10.0)
+				},
+			}
+		}
+		ulps := logULPs
+		if j == 4 {
+			ulps = log10ULPs
+		}
+		acc := randomTest(opts, mp, rng, j, n, lo, hi, func(acc *elefunt.Accumulator, rng *random.Generator, xl, del T) {
+			x, at, z, zz := id.Eval(log, del*elefunt.Random[T](rng)+xl)
+			ulps(acc, at, z)
+			acc.Add(float64(x), float64(z), float64(zz))
+		})
+
+		res := acc.Result()
+		res.Identity = id.Name
+		res.WriteHeading(rep)
+		// A negative error counts as LARGER
+		elefunt.WriteCounts(rep, "LOG(X)", res.Smaller, res.Agreed, res.Larger)
+		res.WriteErrors(rep)
+		rep.AddResult(res)
+
+		if j == 2 {
+			a = T(math.Sqrt(float64(half)))
+			b = 15.0 / 16.0
+		}
+	}
+
+	// Special tests
+	fmt.Fprintln(rep, "\nSPECIAL TESTS")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY  LOG(X) = -LOG(1/X)  WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X           F(X) + F(1/X)")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng)
+		x = x + x + 15.0/16.0
+		z := log(x) + log(one/x)
+		fmt.Fprintf(rep, "  %.7E    %.7E\n", x, z)
+		rep.AddCheck("LOG(X) = -LOG(1/X)", float64(x), float64(z))
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " TEST OF SPECIAL ARGUMENTS")
+	fmt.Fprintln(rep)
+
+	x := one
+	y := log(x)
+	fmt.Fprintf(rep, " LOG(1.0) = %.7E\n", y)
+	rep.AddSpecial("LOG(1.0)", float64(y))
+
+	x = T(mp.XMin)
+	y = log(x)
+	fmt.Fprintf(rep, " LOG(XMIN) = LOG(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("LOG(XMIN)", float64(y), float64(x))
+
+	x = T(mp.XMax)
+	y = log(x)
+	fmt.Fprintf(rep, " LOG(XMAX) = LOG(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("LOG(XMAX)", float64(y), float64(x))
+
+	// Test of error returns
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "TEST OF ERROR RETURNS")
+	fmt.Fprintln(rep)
+
+	x = -2.0
+	fmt.Fprintf(rep, " LOG WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD RETURN NaN")
+	fmt.Fprintln(rep)
+	y = log(x)
+	fmt.Fprintf(rep, " LOG RETURNED THE VALUE %.4E\n\n", y)
+	rep.AddError("LOG(X)", "NaN", float64(y), float64(x))
+
+	x = zero
+	fmt.Fprintf(rep, " LOG WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD RETURN -Inf")
+	fmt.Fprintln(rep)
+	y = log(x)
+	fmt.Fprintf(rep, " LOG RETURNED THE VALUE %v\n\n", y)
+	rep.AddError("LOG(X)", "-Inf", float64(y), float64(x))
+
+	_ = eight // unused in this version
+	fmt.Fprintln(rep, " THIS CONCLUDES THE TESTS")
+}
@@ -0,0 +1,154 @@
+package suite
+
+import (
+	"math"
+	"math/big"
+
+	"golefunt/elefunt"
+	"golefunt/machar"
+	"golefunt/oracle"
+	"golefunt/random"
+)
+
+// DefaultN is the number of random arguments drawn from each interval by Cody's programs.
+const DefaultN = 2000
+
+// Options controls how the tests are run. The zero value runs them as
+// Cody's programs do.
+type Options struct {
+	N       int  // Number of random arguments per interval, or DefaultN if zero
+	Seed    int  // Seed of the random number generator, or the ELEFUNT seed if zero
+	Dynamic bool // Determine the machine parameters with machar.MacharOf instead of the static tables
+	Oracle  bool // Measure the error in ULPs against the math/big reference
+	Prec    uint // Precision of the reference in bits, or oracle.DefaultPrec if zero
+
+	// Shards splits each random argument test into that many shards with
+	// their own random streams, run in parallel on Workers goroutines, or
+	// GOMAXPROCS of them if Workers is zero. Zero draws every argument from
+	// one stream, as Cody's programs do. The functions under test must be
+	// safe for concurrent use.
+	Shards  int
+	Workers int
+
+	// Histograms keeps the distributions of the errors of each random
+	// argument test, reported as histograms and percentiles.
+	Histograms bool
+
+	// Observe, when set along with Oracle, is called with every error in
+	// ULPs measured against the reference: the name of the function, its
+	// arguments, with y zero for a function of one argument, and the error.
+	// It must be safe for concurrent use when Shards is set.
+	Observe func(name string, x, y, ulps float64)
+
+	// Trace, when set, is called with every sample of the random argument
+	// tests: the 1-based index of the test, the arguments, with y zero for
+	// a function of one argument, and the signed relative error, or, if ulp
+	// is true, the error in ULPs measured with Oracle. It must be safe for
+	// concurrent use when Shards is set.
+	Trace func(test int, x, y, e float64, ulp bool)
+
+	// Intervals replace the intervals of Cody's random argument tests,
+	// keyed by the 1-based index of the test. The intervals of the other
+	// tests are unchanged, even where Cody derives them from a replaced one.
+	Intervals map[int]Interval
+}
+
+// Interval is the interval (A, B) the arguments of a random argument test are
+// drawn from. For power it is the interval of X.
+type Interval struct {
+	A float64 `json:"a"`
+	B float64 `json:"b"`
+}
+
+// WithIntervals returns opts with the intervals in ivs replacing those it
+// has for the same tests.
+func (opts Options) WithIntervals(ivs map[int]Interval) Options {
+	if len(ivs) == 0 {
+		return opts
+	}
+	merged := make(map[int]Interval, len(opts.Intervals)+len(ivs))
+	for j, iv := range opts.Intervals {
+		merged[j] = iv
+	}
+	for j, iv := range ivs {
+		merged[j] = iv
+	}
+	opts.Intervals = merged
+	return opts
+}
+
+// interval returns the interval of random argument test j, which is (a, b)
+// unless opts replaces it.
+func interval[T elefunt.Real](opts Options, j int, a, b T) (T, T) {
+	if iv, ok := opts.Intervals[j]; ok {
+		return T(iv.A), T(iv.B)
+	}
+	return a, b
+}
+
+// params returns the machine parameters the tests run with.
+func params[T elefunt.Real](opts Options) machar.Params {
+	if opts.Dynamic {
+		return machar.MacharOf[T]()
+	}
+	return elefunt.Params[T]()
+}
+
+func (opts Options) samples() int {
+	if opts.N == 0 {
+		return DefaultN
+	}
+	return opts.N
+}
+
+func (opts Options) random() *random.Generator {
+	return random.NewWithSeed(opts.seed())
+}
+
+func (opts Options) prec() uint {
+	if opts.Prec == 0 {
+		return oracle.DefaultPrec
+	}
+	return opts.Prec
+}
+
+// ulps1 returns a function recording in acc the error in ULPs of fx, the value
+// of the function named name at x. The function does nothing unless opts.Oracle is set.
+func ulps1[T elefunt.Real](opts Options, mp machar.Params, name string) func(acc *elefunt.Accumulator, x, fx T) {
+	f, ok := oracle.Lookup1(name)
+	if !opts.Oracle || !ok {
+		return func(*elefunt.Accumulator, T, T) {}
+	}
+	prec := opts.prec()
+	return func(acc *elefunt.Accumulator, x, fx T) {
+		if math.IsNaN(float64(x)) {
+			return
+		}
+		want := f(big.NewFloat(float64(x)), prec)
+		u := oracle.ULPs(float64(fx), want, mp)
+		acc.AddULP(float64(x), u)
+		if opts.Observe != nil {
+			opts.Observe(name, float64(x), 0, u)
+		}
+	}
+}
+
+// ulps2 is like ulps1 for a function of two arguments.
+func ulps2[T elefunt.Real](opts Options, mp machar.Params, name string) func(acc *elefunt.Accumulator, x, y, fxy T) {
+	f, ok := oracle.Lookup2(name)
+	if !opts.Oracle || !ok {
+		return func(*elefunt.Accumulator, T, T, T) {}
+	}
+	prec := opts.prec()
+	return func(acc *elefunt.Accumulator, x, y, fxy T) {
+		if math.IsNaN(float64(x)) || math.IsNaN(float64(y)) {
+			return
+		}
+		want := f(big.NewFloat(float64(x)), big.NewFloat(float64(y)), prec)
+		u := oracle.ULPs(float64(fxy), want, mp)
+		acc.AddULP2(float64(x), float64(y), u)
+		if opts.Observe != nil {
+			opts.Observe(name, float64(x), float64(y), u)
+		}
+	}
+}
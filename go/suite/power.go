@@ -0,0 +1,176 @@
+package suite
+
+import (
+	"fmt"
+
+	"golefunt/elefunt"
+	"golefunt/random"
+)
+
+// Power tests Power (X**Y) in the precision of T, recording the results in rep.
+// It is a port of the elefunt power.f and dpower.f test programs by W.J. Cody.
+func Power[T elefunt.Real](rep *elefunt.Report, fs *elefunt.Funcs[T], opts Options) {
+	rep.Precision = elefunt.Precision[T]()
+
+	// Get machine parameters
+	mp := params[T](opts)
+	rng := opts.random()
+
+	// Functions under test
+	pow := fs.Func2("pow")
+	exp := fs.Func1("exp")
+	log := fs.Func1("log")
+
+	// Errors in ULPs against the reference, when enabled
+	powULPs := ulps2[T](opts, mp, "pow")
+
+	beta := T(mp.IBeta)
+	one := T(1.0)
+	zero := T(0.0)
+	two := T(2.0)
+
+	// Test X**Y using identity: X**(2Y) = (X**Y)**2
+	a := one / beta
+	b := one
+	n := opts.samples()
+
+	// Random argument accuracy tests
+	for j := 1; j <= 4; j++ {
+		lo, hi := interval(opts, j, a, b)
+		acc := randomTest(opts, mp, rng, j, n, lo, hi, func(acc *elefunt.Accumulator, rng *random.Generator, xl, del T) {
+			x := del*elefunt.Random[T](rng) + xl
+
+			var y, z, zz, w T
+			if j <= 2 {
+				// Test X**(2Y) vs (X**Y)**2
+				y = elefunt.Random[T](rng) * 2.0
+				z = pow(x, two*y)
+				powULPs(acc, x, two*y, z)
+				zz = pow(x, y)
+				zz = zz * zz
+			} else {
+				// Test X**Y vs EXP(Y*LOG(X))
+				y = elefunt.Random[T](rng) * 2.0
+				z = pow(x, y)
+				powULPs(acc, x, y, z)
+				zz = exp(y * log(x))
+			}
+
+			w = one
+			if z != zero {
+				w = (z - zz) / z
+			}
+
+			acc.AddError2(float64(x), float64(y), float64(w))
+		})
+
+		res := acc.Result()
+
+		if j <= 2 {
+			res.Identity = "X**(2Y) VS (X**Y)**2"
+		} else {
+			res.Identity = "X**Y VS EXP(Y*LOG(X))"
+		}
+		fmt.Fprintf(rep, "\nTEST OF %s\n\n", res.Identity)
+		fmt.Fprintf(rep, "%7d RANDOM ARGUMENTS WERE TESTED FROM THE INTERVAL\n", n)
+		fmt.Fprintf(rep, "      X IN (%.4E, %.4E), Y IN (0, 2)\n\n", lo, hi)
+		fmt.Fprintf(rep, " X**Y WAS LARGER %6d TIMES,\n", res.Larger)
+		fmt.Fprintf(rep, "          AGREED %6d TIMES, AND\n", res.Agreed)
+		fmt.Fprintf(rep, "      WAS SMALLER %6d TIMES.\n\n", res.Smaller)
+		res.WriteErrors(rep)
+		rep.AddResult(res)
+
+		a = one
+		b = beta
+	}
+
+	// Special tests
+	fmt.Fprintln(rep, "\nSPECIAL TESTS")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY  X**1 = X  WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         X**1 - X")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng) * 10.0
+		z := pow(x, one) - x
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("X**1 = X", float64(x), float64(z))
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY  X**0 = 1  WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         X**0 - 1")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng) * 10.0
+		z := pow(x, zero) - one
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("X**0 = 1", float64(x), float64(z))
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " TEST OF SPECIAL ARGUMENTS")
+	fmt.Fprintln(rep)
+
+	x := one
+	y := zero
+	z := pow(x, y)
+	fmt.Fprintf(rep, " 1**0 = %.7E\n", z)
+	rep.AddSpecial("1**0", float64(z))
+
+	x = zero
+	y = one
+	z = pow(x, y)
+	fmt.Fprintf(rep, " 0**1 = %.7E\n", z)
+	rep.AddSpecial("0**1", float64(z))
+
+	x = two
+	y = two
+	z = pow(x, y)
+	fmt.Fprintf(rep, " 2**2 = %.7E (should be 4.0)\n", z)
+	rep.AddSpecial("2**2", float64(z))
+
+	x = two
+	y = 10.0
+	z = pow(x, y)
+	fmt.Fprintf(rep, " 2**10 = %.7E (should be 1024.0)\n", z)
+	rep.AddSpecial("2**10", float64(z))
+
+	x = 10.0
+	y = two
+	z = pow(x, y)
+	fmt.Fprintf(rep, " 10**2 = %.7E (should be 100.0)\n", z)
+	rep.AddSpecial("10**2", float64(z))
+
+	// Test of error returns
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "TEST OF ERROR RETURNS")
+	fmt.Fprintln(rep)
+
+	x = zero
+	y = zero
+	fmt.Fprintf(rep, " 0**0 WILL BE COMPUTED\n")
+	z = pow(x, y)
+	fmt.Fprintf(rep, " 0**0 = %v\n\n", z)
+	rep.AddError("0**0", "1.0", float64(z), float64(x), float64(y))
+
+	x = -two
+	y = 3.5
+	fmt.Fprintf(rep, " (-2)**3.5 WILL BE COMPUTED\n")
+	fmt.Fprintln(rep, " THIS SHOULD RETURN NaN")
+	z = pow(x, y)
+	fmt.Fprintf(rep, " (-2)**3.5 = %v\n\n", z)
+	rep.AddError("(-2)**3.5", "NaN", float64(z), float64(x), float64(y))
+
+	x = T(mp.XMax)
+	y = two
+	fmt.Fprintf(rep, " XMAX**2 WILL BE COMPUTED\n")
+	fmt.Fprintln(rep, " THIS SHOULD OVERFLOW")
+	z = pow(x, y)
+	fmt.Fprintf(rep, " XMAX**2 = %v\n\n", z)
+	rep.AddError("XMAX**2", "OVERFLOW", float64(z), float64(x), float64(y))
+
+	fmt.Fprintln(rep, " THIS CONCLUDES THE TESTS")
+}
@@ -0,0 +1,105 @@
+package suite
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"golefunt/elefunt"
+	"golefunt/machar"
+	"golefunt/random"
+)
+
+// randomTest runs random argument test j, which draws n arguments from
+// (lo, hi) divided into n subintervals of width del. body records in acc
+// the sample drawn from the subinterval starting at xl, drawing the random
+// numbers it needs from rng.
+//
+// Unless opts.Shards is set, the samples are drawn in order from rng as in
+// Cody's programs. Otherwise the subintervals are split into that many
+// shards, each drawing from its own random stream derived from the seed, j
+// and the shard, and the shards run on opts.Workers goroutines. Their
+// statistics are merged in shard order, so the results depend on the seed
+// and the number of shards but not on the number of workers or GOMAXPROCS.
+func randomTest[T elefunt.Real](opts Options, mp machar.Params, rng *random.Generator, j, n int, lo, hi T,
+	body func(acc *elefunt.Accumulator, rng *random.Generator, xl, del T)) *elefunt.Accumulator {
+	newAccumulator := func() *elefunt.Accumulator {
+		acc := elefunt.NewAccumulator(mp, float64(lo), float64(hi))
+		if opts.Histograms {
+			acc.KeepHistograms()
+		}
+		if opts.Trace != nil {
+			acc.Trace(
+				func(x, y, w float64) { opts.Trace(j, x, y, w, false) },
+				func(x, y, u float64) { opts.Trace(j, x, y, u, true) })
+		}
+		return acc
+	}
+	acc := newAccumulator()
+	del := (hi - lo) / T(n)
+	if opts.Shards <= 0 {
+		xl := lo
+		for i := 1; i <= n; i++ {
+			body(acc, rng, xl, del)
+			xl = xl + del
+		}
+		return acc
+	}
+
+	// Shard s draws the samples first(s) to first(s+1)-1. Its subintervals
+	// start where the sequential loop would have stepped to.
+	shards := min(opts.Shards, n)
+	first := func(s int) int { return s * n / shards }
+	starts := make([]T, shards)
+	xl := lo
+	for s, i := 0, 0; s < shards; s++ {
+		for ; i < first(s); i++ {
+			xl = xl + del
+		}
+		starts[s] = xl
+	}
+
+	accs := make([]*elefunt.Accumulator, shards)
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for g := 0; g < min(opts.workers(), shards); g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				s := int(next.Add(1) - 1)
+				if s >= shards {
+					return
+				}
+				a := newAccumulator()
+				r := random.NewStream(opts.seed(), uint64(j)<<32|uint64(s))
+				xl := starts[s]
+				for i := first(s); i < first(s+1); i++ {
+					body(a, r, xl, del)
+					xl = xl + del
+				}
+				accs[s] = a
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, a := range accs {
+		acc.Merge(a)
+	}
+	return acc
+}
+
+func (opts Options) workers() int {
+	if opts.Workers <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return opts.Workers
+}
+
+func (opts Options) seed() int {
+	if opts.Seed == 0 {
+		return random.DefaultSeed
+	}
+	return opts.Seed
+}
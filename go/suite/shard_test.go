@@ -0,0 +1,60 @@
+package suite_test
+
+import (
+	"bytes"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"golefunt/elefunt"
+	"golefunt/suite"
+)
+
+// runSharded runs the named test program split into shards on workers
+// goroutines and returns its report.
+func runSharded(t *testing.T, name string, shards, workers int) *elefunt.Report {
+	t.Helper()
+	test, ok := suite.Lookup(name)
+	if !ok {
+		t.Fatalf("no test program %q", name)
+	}
+	rep := elefunt.NewReport(name, "", "")
+	test.Run(rep, elefunt.PrecisionDouble, suite.Options{
+		N: 500, Oracle: true, Prec: 128, Histograms: true, Shards: shards, Workers: workers,
+	})
+	return rep
+}
+
+func TestShardsIndependentOfWorkers(t *testing.T) {
+	// Let the workers run in parallel even on one CPU.
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+
+	// exp is univariate and power bivariate.
+	for _, name := range []string{"exp", "power"} {
+		want := runSharded(t, name, 7, 1)
+		got := runSharded(t, name, 7, 8)
+		if len(got.Tests) != len(want.Tests) || len(want.Tests) == 0 {
+			t.Fatalf("%s: %d tests with 8 workers, %d with 1", name, len(got.Tests), len(want.Tests))
+		}
+		for i, w := range want.Tests {
+			g := got.Tests[i]
+			if g.Larger != w.Larger || g.Agreed != w.Agreed || g.Smaller != w.Smaller {
+				t.Errorf("%s test %d: counts %d %d %d with 8 workers, %d %d %d with 1",
+					name, i+1, g.Larger, g.Agreed, g.Smaller, w.Larger, w.Agreed, w.Smaller)
+			}
+			if g.MaxError != w.MaxError || g.MaxX != w.MaxX || g.MaxY != w.MaxY || g.RMSError != w.RMSError {
+				t.Errorf("%s test %d: R6 %g at (%g, %g), R7 %g with 8 workers; R6 %g at (%g, %g), R7 %g with 1",
+					name, i+1, g.MaxError, g.MaxX, g.MaxY, g.RMSError, w.MaxError, w.MaxX, w.MaxY, w.RMSError)
+			}
+			if !reflect.DeepEqual(g, w) {
+				t.Errorf("%s test %d: result with 8 workers\n%+v\nwith 1\n%+v", name, i+1, g, w)
+			}
+		}
+		var gb, wb bytes.Buffer
+		got.Encode(&gb, elefunt.FormatText)
+		want.Encode(&wb, elefunt.FormatText)
+		if !bytes.Equal(gb.Bytes(), wb.Bytes()) {
+			t.Errorf("%s: printout with 8 workers differs from that with 1", name)
+		}
+	}
+}
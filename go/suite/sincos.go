@@ -0,0 +1,153 @@
+package suite
+
+import (
+	"fmt"
+	"math"
+
+	"golefunt/elefunt"
+	"golefunt/random"
+)
+
+// SinCos tests Sin/Cos in the precision of T, recording the results in rep.
+// It is a port of the elefunt sin.f and dsin.f test programs by W.J. Cody.
+func SinCos[T elefunt.Real](rep *elefunt.Report, fs *elefunt.Funcs[T], opts Options) {
+	rep.Precision = elefunt.Precision[T]()
+
+	// Get machine parameters
+	mp := params[T](opts)
+	rng := opts.random()
+
+	// Functions under test
+	sin := fs.Func1("sin")
+	cos := fs.Func1("cos")
+
+	// Errors in ULPs against the reference, when enabled
+	sinULPs := ulps1[T](opts, mp, "sin")
+	cosULPs := ulps1[T](opts, mp, "cos")
+
+	beta := T(mp.IBeta)
+	one := T(1.0)
+	zero := T(0.0)
+	a := zero
+	b := T(math.Pi / 2.0) // 1.570796327
+	c := b
+	n := opts.samples()
+
+	// Random argument accuracy tests
+	for j := 1; j <= 3; j++ {
+		lo, hi := interval(opts, j, a, b)
+		id, f, fx, ulps := elefunt.SinTriple[T](), sin, "SIN(X)", sinULPs
+		if j == 3 {
+			id, f, fx, ulps = elefunt.CosTriple[T](), cos, "COS(X)", cosULPs
+		}
+		acc := randomTest(opts, mp, rng, j, n, lo, hi, func(acc *elefunt.Accumulator, rng *random.Generator, xl, del T) {
+			x, at, z, zz := id.Eval(f, del*elefunt.Random[T](rng)+xl)
+			ulps(acc, at, z)
+			w := one
+			if z != zero {
+				w = (z - zz) / z
+			}
+			acc.AddError(float64(x), float64(w))
+		})
+
+		res := acc.Result()
+		res.Identity = id.Name
+		res.WriteHeading(rep)
+		elefunt.WriteCounts(rep, fx, res.Larger, res.Agreed, res.Smaller)
+
+		res.WriteErrors(rep)
+		rep.AddResult(res)
+
+		a = T(6.0 * math.Pi) // 18.84955592
+		if j == 2 {
+			a = b + c
+		}
+		b = a + c
+	}
+
+	// Special tests
+	fmt.Fprintln(rep, "\nSPECIAL TESTS")
+	fmt.Fprintln(rep)
+
+	c = one / T(math.Pow(float64(beta), float64(mp.IT/2)))
+	z := (sin(a+c) - sin(a-c)) / (c + c)
+	fmt.Fprintf(rep, " IF %.6E IS NOT ALMOST 1.0,    SIN HAS THE WRONG PERIOD.\n\n", z)
+	rep.AddSpecial("(SIN(A+C)-SIN(A-C))/(C+C)", float64(z), float64(a), float64(c))
+
+	fmt.Fprintln(rep, " THE IDENTITY   SIN(-X) = -SIN(X)   WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         F(X) + F(-X)")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng) * a
+		z := sin(x) + sin(-x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("SIN(-X) = -SIN(X)", float64(x), float64(z))
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY SIN(X) = X , X SMALL, WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         X - F(X)")
+
+	betap := T(math.Pow(float64(beta), float64(mp.IT)))
+	x := elefunt.Random[T](rng) / betap
+
+	for i := 1; i <= 5; i++ {
+		z := x - sin(x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("SIN(X) = X, X SMALL", float64(x), float64(z))
+		x = x / beta
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY   COS(-X) = COS(X)   WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         F(X) - F(-X)")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng) * a
+		z := cos(x) - cos(-x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("COS(-X) = COS(X)", float64(x), float64(z))
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " TEST OF UNDERFLOW FOR VERY SMALL ARGUMENT.")
+	expon := float64(mp.MinExp) * 0.75
+	x = T(math.Pow(float64(beta), expon))
+	y := sin(x)
+	fmt.Fprintf(rep, "\n      SIN(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("SIN(BETA**(0.75*MINEXP))", float64(y), float64(x))
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE FOLLOWING THREE LINES ILLUSTRATE THE LOSS IN SIGNIFICANCE")
+	fmt.Fprintln(rep, " FOR LARGE ARGUMENTS.  THE ARGUMENTS ARE CONSECUTIVE.")
+
+	z = T(math.Sqrt(float64(betap)))
+	x = z * (one - T(mp.EpsNeg))
+	y = sin(x)
+	fmt.Fprintf(rep, "\n      SIN(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("SIN(SQRT(BETAP)*(1-EPSNEG))", float64(y), float64(x))
+	y = sin(z)
+	fmt.Fprintf(rep, "\n      SIN(%.6E) = %.6E\n", z, y)
+	rep.AddSpecial("SIN(SQRT(BETAP))", float64(y), float64(z))
+	x = z * (one + T(mp.Eps))
+	y = sin(x)
+	fmt.Fprintf(rep, "\n      SIN(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("SIN(SQRT(BETAP)*(1+EPS))", float64(y), float64(x))
+
+	// Test of error returns
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "TEST OF ERROR RETURNS")
+	fmt.Fprintln(rep)
+	x = betap
+	fmt.Fprintf(rep, " SIN WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD NOT TRIGGER AN ERROR IN GO (NO ARGRED)")
+	fmt.Fprintln(rep)
+	y = sin(x)
+	fmt.Fprintf(rep, " SIN RETURNED THE VALUE %.4E\n\n", y)
+	rep.AddError("SIN(BETAP)", "NO ERROR", float64(y), float64(x))
+
+	fmt.Fprintln(rep, " THIS CONCLUDES THE TESTS")
+}
@@ -0,0 +1,164 @@
+package suite
+
+import (
+	"fmt"
+	"math"
+
+	"golefunt/elefunt"
+	"golefunt/random"
+)
+
+// Sinh tests Sinh/Cosh in the precision of T, recording the results in rep.
+// It is a port of the elefunt sinh.f and dsinh.f test programs by W.J. Cody.
+func Sinh[T elefunt.Real](rep *elefunt.Report, fs *elefunt.Funcs[T], opts Options) {
+	rep.Precision = elefunt.Precision[T]()
+
+	// Get machine parameters
+	mp := params[T](opts)
+	rng := opts.random()
+
+	// Functions under test
+	sinh := fs.Func1("sinh")
+	cosh := fs.Func1("cosh")
+
+	// Errors in ULPs against the reference, when enabled
+	sinhULPs := ulps1[T](opts, mp, "sinh")
+	coshULPs := ulps1[T](opts, mp, "cosh")
+
+	beta := T(mp.IBeta)
+	one := T(1.0)
+	zero := T(0.0)
+	three := T(3.0)
+
+	a := zero
+	b := T(0.5)
+	n := opts.samples()
+
+	// Random argument accuracy tests
+	for j := 1; j <= 4; j++ {
+		lo, hi := interval(opts, j, a, b)
+		acc := randomTest(opts, mp, rng, j, n, lo, hi, func(acc *elefunt.Accumulator, rng *random.Generator, xl, del T) {
+			x := del*elefunt.Random[T](rng) + xl
+
+			var z, zz, w T
+			if j <= 2 {
+				// Test SINH(X) vs identity
+				// SINH(3X) = SINH(X)*(3+4*SINH(X)^2)
+				y := x / three
+				z = sinh(x)
+				sinhULPs(acc, x, z)
+				zz = sinh(y)
+				w = one
+				if z != zero {
+					computed := zz * (three + 4.0*zz*zz)
+					w = (z - computed) / z
+				}
+			} else {
+				// Test COSH(X) vs identity
+				// COSH(3X) = COSH(X)*(4*COSH(X)^2-3)
+				y := x / three
+				z = cosh(x)
+				coshULPs(acc, x, z)
+				zz = cosh(y)
+				w = one
+				if z != zero {
+					computed := zz * (4.0*zz*zz - three)
+					w = (z - computed) / z
+				}
+			}
+
+			acc.AddError(float64(x), float64(w))
+		})
+
+		res := acc.Result()
+
+		if j <= 2 {
+			res.Identity = "SINH(X) VS 3*SINH(X/3)+4*SINH(X/3)**3"
+		} else {
+			res.Identity = "COSH(X) VS 4*COSH(X/3)**3-3*COSH(X/3)"
+		}
+		res.WriteHeading(rep)
+
+		if j <= 2 {
+			fmt.Fprintf(rep, " SINH(X) WAS LARGER %6d TIMES,\n", res.Larger)
+		} else {
+			fmt.Fprintf(rep, " COSH(X) WAS LARGER %6d TIMES,\n", res.Larger)
+		}
+		fmt.Fprintf(rep, "            AGREED %6d TIMES, AND\n", res.Agreed)
+		fmt.Fprintf(rep, "        WAS SMALLER %6d TIMES.\n\n", res.Smaller)
+		res.WriteErrors(rep)
+		rep.AddResult(res)
+
+		a = 3.0
+		b = T(math.Log(mp.XMax) - math.Log(3.0))
+	}
+
+	// Special tests
+	fmt.Fprintln(rep, "\nSPECIAL TESTS")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY   SINH(-X) = -SINH(X)   WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         F(X) + F(-X)")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng) * 5.0
+		z := sinh(x) + sinh(-x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("SINH(-X) = -SINH(X)", float64(x), float64(z))
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY SINH(X) = X , X SMALL, WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         X - F(X)")
+
+	betap := T(math.Pow(float64(beta), float64(mp.IT)))
+	x := elefunt.Random[T](rng) / betap
+
+	for i := 1; i <= 5; i++ {
+		z := x - sinh(x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("SINH(X) = X, X SMALL", float64(x), float64(z))
+		x = x / beta
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY   COSH(-X) = COSH(X)   WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         F(X) - F(-X)")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng) * 5.0
+		z := cosh(x) - cosh(-x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("COSH(-X) = COSH(X)", float64(x), float64(z))
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " TEST OF SPECIAL ARGUMENTS")
+	fmt.Fprintln(rep)
+
+	x = zero
+	y := sinh(x)
+	fmt.Fprintf(rep, " SINH(0.0) = %.7E\n", y)
+	rep.AddSpecial("SINH(0.0)", float64(y))
+
+	y = cosh(zero)
+	fmt.Fprintf(rep, " COSH(0.0) = %.17E (should be 1.0)\n", y)
+	rep.AddSpecial("COSH(0.0)", float64(y))
+
+	// Test of error returns
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "TEST OF ERROR RETURNS")
+	fmt.Fprintln(rep)
+
+	x = T(math.Log(mp.XMax) + 2.0)
+	fmt.Fprintf(rep, " SINH WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD OVERFLOW")
+	fmt.Fprintln(rep)
+	y = sinh(x)
+	fmt.Fprintf(rep, " SINH RETURNED THE VALUE %v\n\n", y)
+	rep.AddError("SINH(LOG(XMAX)+2)", "OVERFLOW", float64(y), float64(x))
+
+	fmt.Fprintln(rep, " THIS CONCLUDES THE TESTS")
+}
@@ -0,0 +1,127 @@
+package suite
+
+import (
+	"fmt"
+
+	"golefunt/elefunt"
+	"golefunt/random"
+)
+
+// Sqrt tests Sqrt in the precision of T, recording the results in rep.
+// It is a port of the elefunt sqrt.f and dsqrt.f test programs by W.J. Cody.
+func Sqrt[T elefunt.Real](rep *elefunt.Report, fs *elefunt.Funcs[T], opts Options) {
+	rep.Precision = elefunt.Precision[T]()
+
+	// Get machine parameters
+	mp := params[T](opts)
+	rng := opts.random()
+
+	// Functions under test
+	sqrt := fs.Func1("sqrt")
+
+	// Errors in ULPs against the reference, when enabled
+	sqrtULPs := ulps1[T](opts, mp, "sqrt")
+
+	beta := T(mp.IBeta)
+	one := T(1.0)
+	zero := T(0.0)
+
+	a := one / beta
+	b := one
+	n := opts.samples()
+
+	// Random argument accuracy tests
+	for j := 1; j <= 2; j++ {
+		lo, hi := interval(opts, j, a, b)
+		acc := randomTest(opts, mp, rng, j, n, lo, hi, func(acc *elefunt.Accumulator, rng *random.Generator, xl, del T) {
+			x := del*elefunt.Random[T](rng) + xl
+
+			// Test SQRT(X) vs X/SQRT(X)
+			y := sqrt(x)
+			sqrtULPs(acc, x, y)
+			z := x / y
+			w := one
+			if y != zero {
+				w = (y - z) / y
+			}
+
+			acc.AddError(float64(x), float64(w))
+		})
+
+		res := acc.Result()
+
+		res.Identity = "SQRT(X) VS X/SQRT(X)"
+		res.WriteHeading(rep)
+		elefunt.WriteCounts(rep, "SQRT(X)", res.Larger, res.Agreed, res.Smaller)
+		res.WriteErrors(rep)
+		rep.AddResult(res)
+
+		a = one
+		b = beta
+	}
+
+	// Special tests
+	fmt.Fprintln(rep, "\nSPECIAL TESTS")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY  SQRT(X)*SQRT(X) = X  WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         F(X)*F(X) - X")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng)
+		y := sqrt(x)
+		z := y*y - x
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("SQRT(X)*SQRT(X) = X", float64(x), float64(z))
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " TEST OF SPECIAL ARGUMENTS")
+	fmt.Fprintln(rep)
+
+	x := T(mp.XMin)
+	y := sqrt(x)
+	fmt.Fprintf(rep, " SQRT(XMIN) = SQRT(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("SQRT(XMIN)", float64(y), float64(x))
+
+	x = one - T(mp.EpsNeg)
+	y = sqrt(x)
+	fmt.Fprintf(rep, " SQRT(1-EPSNEG) = SQRT(%.17E) = %.17E\n", x, y)
+	rep.AddSpecial("SQRT(1-EPSNEG)", float64(y), float64(x))
+
+	x = one
+	y = sqrt(x)
+	fmt.Fprintf(rep, " SQRT(1.0) = %.17E\n", y)
+	rep.AddSpecial("SQRT(1.0)", float64(y))
+
+	x = one + T(mp.Eps)
+	y = sqrt(x)
+	fmt.Fprintf(rep, " SQRT(1+EPS) = SQRT(%.17E) = %.17E\n", x, y)
+	rep.AddSpecial("SQRT(1+EPS)", float64(y), float64(x))
+
+	x = T(mp.XMax)
+	y = sqrt(x)
+	fmt.Fprintf(rep, " SQRT(XMAX) = SQRT(%.6E) = %.6E\n", x, y)
+	rep.AddSpecial("SQRT(XMAX)", float64(y), float64(x))
+
+	// Test of error returns
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "TEST OF ERROR RETURNS")
+	fmt.Fprintln(rep)
+
+	x = zero
+	fmt.Fprintf(rep, " SQRT WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	y = sqrt(x)
+	fmt.Fprintf(rep, " SQRT RETURNED THE VALUE %.4E\n\n", y)
+	rep.AddError("SQRT(0.0)", "0.0", float64(y), float64(x))
+
+	x = -one
+	fmt.Fprintf(rep, " SQRT WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD RETURN NaN")
+	fmt.Fprintln(rep)
+	y = sqrt(x)
+	fmt.Fprintf(rep, " SQRT RETURNED THE VALUE %v\n\n", y)
+	rep.AddError("SQRT(-1.0)", "NaN", float64(y), float64(x))
+
+	fmt.Fprintln(rep, " THIS CONCLUDES THE TESTS")
+}
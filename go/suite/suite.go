@@ -0,0 +1,5 @@
+// Package suite holds the ELEFUNT test programs as library code.
+// Each test looks up the functions it exercises in an elefunt.Funcs set,
+// so the same identity tests can be run against implementations other
+// than the math package.
+package suite
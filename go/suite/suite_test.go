@@ -0,0 +1,173 @@
+package suite_test
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golefunt/elefunt"
+	"golefunt/suite"
+)
+
+// run runs the named test program and returns its report.
+func run(t *testing.T, name, precision string, opts suite.Options) *elefunt.Report {
+	t.Helper()
+	test, ok := suite.Lookup(name)
+	if !ok {
+		t.Fatalf("no test program %q", name)
+	}
+	rep := elefunt.NewReport(name, "", "")
+	test.Run(rep, precision, opts)
+	return rep
+}
+
+func TestShapes(t *testing.T) {
+	rep := run(t, "power", elefunt.PrecisionDouble, suite.Options{N: 200})
+	bivariate := false
+	for _, r := range rep.Tests {
+		bivariate = bivariate || r.Bivariate
+	}
+	if !bivariate {
+		t.Errorf("power has no bivariate test")
+	}
+	if rep := run(t, "tan", elefunt.PrecisionDouble, suite.Options{N: 200}); len(rep.Checks) == 0 || len(rep.Specials) != 0 {
+		t.Errorf("tan has %d checks and %d special arguments, want some and none", len(rep.Checks), len(rep.Specials))
+	}
+}
+
+func TestRandomTests(t *testing.T) {
+	for _, test := range suite.Tests() {
+		rep := run(t, test.Name, elefunt.PrecisionDouble, suite.Options{N: 1})
+		if len(rep.Tests) != test.RandomTests {
+			t.Errorf("%s: %d random argument tests, the table says %d", test.Name, len(rep.Tests), test.RandomTests)
+		}
+	}
+}
+
+func TestLargerSmaller(t *testing.T) {
+	larger := regexp.MustCompile(`WAS LARGER\s+(\d+)`)
+	smaller := regexp.MustCompile(`WAS SMALLER\s+(\d+)`)
+	for _, name := range []string{"exp", "log", "sqrt"} {
+		rep := run(t, name, elefunt.PrecisionDouble, suite.Options{N: 500})
+		var b strings.Builder
+		rep.Encode(&b, elefunt.FormatText)
+		ls, ss := larger.FindAllStringSubmatch(b.String(), -1), smaller.FindAllStringSubmatch(b.String(), -1)
+		if len(rep.Tests) == 0 || len(ls) != len(rep.Tests) || len(ss) != len(rep.Tests) {
+			t.Fatalf("%s: %d LARGER and %d SMALLER lines for %d tests", name, len(ls), len(ss), len(rep.Tests))
+		}
+		// The exp and log programs print the counts reversed.
+		reversed := name == "exp" || name == "log"
+		for i, r := range rep.Tests {
+			l, s := r.Larger, r.Smaller
+			if reversed {
+				l, s = s, l
+			}
+			if ls[i][1] != strconv.Itoa(l) || ss[i][1] != strconv.Itoa(s) {
+				t.Errorf("%s test %d: printed LARGER %s, SMALLER %s, fields larger %d, smaller %d",
+					name, i+1, ls[i][1], ss[i][1], r.Larger, r.Smaller)
+			}
+		}
+	}
+}
+
+func TestSingle(t *testing.T) {
+	for _, name := range []string{"exp", "sqrt", "tanh"} {
+		rep := run(t, name, elefunt.PrecisionSingle, suite.Options{N: 2000})
+		if len(rep.Tests) == 0 {
+			t.Fatalf("%s: no random argument tests", name)
+		}
+		worst := 0.0
+		for i, r := range rep.Tests {
+			if r.IBeta != 2 || r.IT != 24 {
+				t.Errorf("%s test %d: %d base %d digits, want 24 base 2", name, i+1, r.IT, r.IBeta)
+			}
+			if loss := r.MaxErrorLoss(); loss > 3 {
+				t.Errorf("%s test %d: lost %.2f base 2 digits, want at most 3", name, i+1, loss)
+			}
+			worst = math.Max(worst, r.MaxError)
+		}
+		// Errors of a few float32 ULPs show the results were rounded to float32.
+		if worst < 0x1p-26 {
+			t.Errorf("%s: maximum relative error %g, want one of float32 size", name, worst)
+		}
+	}
+}
+
+func TestReplacement(t *testing.T) {
+	// An exp with float32 accuracy loses about 29 of the 53 digits.
+	fs := elefunt.MathFuncs[float64]()
+	fs.Register1("exp", func(x float64) float64 { return float64(float32(math.Exp(x))) })
+	rep := elefunt.NewReport("exp", "", "")
+	suite.Exp(rep, fs, suite.Options{N: 2000})
+	if len(rep.Tests) == 0 {
+		t.Fatal("no random argument tests")
+	}
+	for i, r := range rep.Tests {
+		if loss := r.MaxErrorLoss(); loss < 20 {
+			t.Errorf("test %d: lost %.2f base 2 digits, want at least 20", i+1, loss)
+		}
+	}
+	if want := run(t, "exp", elefunt.PrecisionDouble, suite.Options{N: 2000}); want.Tests[0].MaxErrorLoss() > 3 {
+		t.Errorf("math.Exp lost %.2f base 2 digits, want at most 3", want.Tests[0].MaxErrorLoss())
+	}
+}
+
+// smoke runs the named test program in both precisions and checks that it
+// records its random argument tests, special identity, special argument
+// and error return tests, and that the math package loses at most loss[i]
+// digits in the random argument test i+1 in double precision, and 3 in
+// single precision.
+func smoke(t *testing.T, name string, loss []float64) {
+	t.Helper()
+	test, ok := suite.Lookup(name)
+	if !ok {
+		t.Fatalf("no test program %q", name)
+	}
+	if len(loss) != test.RandomTests {
+		t.Fatalf("%s: %d digit loss bounds for %d random argument tests", name, len(loss), test.RandomTests)
+	}
+	for _, precision := range []string{elefunt.PrecisionDouble, elefunt.PrecisionSingle} {
+		rep := run(t, name, precision, suite.Options{N: 2000})
+		if len(rep.Tests) != test.RandomTests {
+			t.Errorf("%s %s: %d random argument tests, want %d", name, precision, len(rep.Tests), test.RandomTests)
+		}
+		if len(rep.Checks) == 0 || len(rep.Specials) == 0 || len(rep.Errors) == 0 {
+			t.Errorf("%s %s: %d special identity, %d special argument and %d error return tests, want some of each",
+				name, precision, len(rep.Checks), len(rep.Specials), len(rep.Errors))
+		}
+		for i, r := range rep.Tests {
+			max := 3.0
+			if precision == elefunt.PrecisionDouble && i < len(loss) {
+				max = loss[i]
+			}
+			if r.N != 2000 || r.MaxErrorLoss() > max {
+				t.Errorf("%s %s test %d: %d arguments, lost %.2f digits, want 2000 and at most %g",
+					name, precision, i+1, r.N, r.MaxErrorLoss(), max)
+			}
+		}
+	}
+}
+
+func TestExpm1(t *testing.T) {
+	smoke(t, "expm1", []float64{3, 3, 3, 3})
+}
+
+func TestCbrt(t *testing.T) {
+	smoke(t, "cbrt", []float64{3, 3, 3, 3, 3, 3})
+}
+
+func TestAsinh(t *testing.T) {
+	smoke(t, "asinh", []float64{3, 3, 3, 3, 3})
+}
+
+func TestGamma(t *testing.T) {
+	// math.Gamma loses about 7 digits for large arguments.
+	smoke(t, "gamma", []float64{3, 3, 3, 8, 3, 3})
+}
+
+func TestErf(t *testing.T) {
+	// math.Erfcinv loses about 19 digits for small ERFC(X).
+	smoke(t, "erf", []float64{3, 3, 3, 20, 3, 3})
+}
@@ -0,0 +1,131 @@
+package suite
+
+import (
+	"fmt"
+	"math"
+
+	"golefunt/elefunt"
+	"golefunt/random"
+)
+
+// Tan tests Tan in the precision of T, recording the results in rep.
+// It is a port of the elefunt tan.f and dtan.f test programs by W.J. Cody.
+func Tan[T elefunt.Real](rep *elefunt.Report, fs *elefunt.Funcs[T], opts Options) {
+	rep.Precision = elefunt.Precision[T]()
+
+	// Get machine parameters
+	mp := params[T](opts)
+	rng := opts.random()
+
+	// Functions under test
+	tan := fs.Func1("tan")
+
+	// Errors in ULPs against the reference, when enabled
+	tanULPs := ulps1[T](opts, mp, "tan")
+
+	beta := T(mp.IBeta)
+	one := T(1.0)
+	zero := T(0.0)
+	three := T(3.0)
+	a := zero
+	b := T(math.Pi / 4.0) // 0.785398163
+	n := opts.samples()
+
+	// Random argument accuracy tests
+	for j := 1; j <= 4; j++ {
+		lo, hi := interval(opts, j, a, b)
+		acc := randomTest(opts, mp, rng, j, n, lo, hi, func(acc *elefunt.Accumulator, rng *random.Generator, xl, del T) {
+			x := del*elefunt.Random[T](rng) + xl
+			y := x / three
+			y = (x + y) - x
+			x = three * y
+
+			var z, zz, w T
+			if j <= 2 {
+				// Test TAN(X) vs TAN(X/3) identity
+				z = tan(x)
+				tanULPs(acc, x, z)
+				zz = tan(y)
+				// TAN(3Y) = TAN(Y)*(3-TAN(Y)^2)/(1-3*TAN(Y)^2)
+				w = one
+				if z != zero {
+					zz2 := zz * zz
+					computed := zz * (three - zz2) / (one - three*zz2)
+					w = (z - computed) / z
+				}
+			} else {
+				// Test COT(X) = 1/TAN(X)
+				z = tan(x)
+				tanULPs(acc, x, z)
+				if z != zero {
+					zz = one / z
+					cotx := one / tan(x)
+					w = (zz - cotx) / zz
+				} else {
+					w = one
+				}
+			}
+
+			acc.AddError(float64(x), float64(w))
+		})
+
+		res := acc.Result()
+
+		if j <= 2 {
+			res.Identity = "TAN(X) VS TAN(X/3) IDENTITY"
+		} else {
+			res.Identity = "COT(X) = 1/TAN(X)"
+		}
+		res.WriteHeading(rep)
+		elefunt.WriteCounts(rep, "TAN(X)", res.Larger, res.Agreed, res.Smaller)
+		res.WriteErrors(rep)
+		rep.AddResult(res)
+
+		a = T(6.0 * math.Pi)
+		b = a + T(math.Pi/4.0)
+	}
+
+	// Special tests
+	fmt.Fprintln(rep, "\nSPECIAL TESTS")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY   TAN(-X) = -TAN(X)   WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         F(X) + F(-X)")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng) * a
+		z := tan(x) + tan(-x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("TAN(-X) = -TAN(X)", float64(x), float64(z))
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY TAN(X) = X , X SMALL, WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         X - F(X)")
+
+	betap := T(math.Pow(float64(beta), float64(mp.IT)))
+	x := elefunt.Random[T](rng) / betap
+
+	for i := 1; i <= 5; i++ {
+		z := x - tan(x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("TAN(X) = X, X SMALL", float64(x), float64(z))
+		x = x / beta
+	}
+
+	// Test of error returns
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "TEST OF ERROR RETURNS")
+	fmt.Fprintln(rep)
+
+	x = T(math.Pi / 2.0)
+	fmt.Fprintf(rep, " TAN WILL BE CALLED WITH THE ARGUMENT %.16E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD NOT CAUSE AN ERROR (TAN(PI/2) is large but finite in IEEE)")
+	fmt.Fprintln(rep)
+	y := tan(x)
+	fmt.Fprintf(rep, " TAN RETURNED THE VALUE %.4E\n\n", y)
+	rep.AddError("TAN(PI/2)", "LARGE", float64(y), float64(x))
+
+	fmt.Fprintln(rep, " THIS CONCLUDES THE TESTS")
+}
@@ -0,0 +1,137 @@
+package suite
+
+import (
+	"fmt"
+	"math"
+
+	"golefunt/elefunt"
+	"golefunt/random"
+)
+
+// Tanh tests Tanh in the precision of T, recording the results in rep.
+// It is a port of the elefunt tanh.f and dtanh.f test programs by W.J. Cody.
+func Tanh[T elefunt.Real](rep *elefunt.Report, fs *elefunt.Funcs[T], opts Options) {
+	rep.Precision = elefunt.Precision[T]()
+
+	// Get machine parameters
+	mp := params[T](opts)
+	rng := opts.random()
+
+	// Functions under test
+	tanh := fs.Func1("tanh")
+
+	// Errors in ULPs against the reference, when enabled
+	tanhULPs := ulps1[T](opts, mp, "tanh")
+
+	beta := T(mp.IBeta)
+	one := T(1.0)
+	zero := T(0.0)
+	two := T(2.0)
+
+	a := zero
+	b := T(0.5)
+	n := opts.samples()
+
+	// Random argument accuracy tests
+	for j := 1; j <= 3; j++ {
+		lo, hi := interval(opts, j, a, b)
+		acc := randomTest(opts, mp, rng, j, n, lo, hi, func(acc *elefunt.Accumulator, rng *random.Generator, xl, del T) {
+			x := del*elefunt.Random[T](rng) + xl
+
+			// Test TANH(X) using identity
+			// TANH(2X) = 2*TANH(X)/(1+TANH(X)^2)
+			y := x / two
+			z := tanh(x)
+			tanhULPs(acc, x, z)
+			zz := tanh(y)
+			var w T
+			if z != zero {
+				computed := two * zz / (one + zz*zz)
+				w = (z - computed) / z
+			} else {
+				w = one
+			}
+
+			acc.AddError(float64(x), float64(w))
+		})
+
+		res := acc.Result()
+
+		res.Identity = "TANH(X) VS 2*TANH(X/2)/(1+TANH(X/2)**2)"
+		res.WriteHeading(rep)
+		elefunt.WriteCounts(rep, "TANH(X)", res.Larger, res.Agreed, res.Smaller)
+		res.WriteErrors(rep)
+		rep.AddResult(res)
+
+		a = 0.5
+		b = 5.0
+		if j == 2 {
+			a = 5.0
+			b = 20.0
+		}
+	}
+
+	// Special tests
+	fmt.Fprintln(rep, "\nSPECIAL TESTS")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY   TANH(-X) = -TANH(X)   WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         F(X) + F(-X)")
+
+	for i := 1; i <= 5; i++ {
+		x := elefunt.Random[T](rng) * 5.0
+		z := tanh(x) + tanh(-x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("TANH(-X) = -TANH(X)", float64(x), float64(z))
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " THE IDENTITY TANH(X) = X , X SMALL, WILL BE TESTED.")
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "        X         X - F(X)")
+
+	betap := T(math.Pow(float64(beta), float64(mp.IT)))
+	x := elefunt.Random[T](rng) / betap
+
+	for i := 1; i <= 5; i++ {
+		z := x - tanh(x)
+		fmt.Fprintf(rep, "  %.7E  %.7E\n", x, z)
+		rep.AddCheck("TANH(X) = X, X SMALL", float64(x), float64(z))
+		x = x / beta
+	}
+
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, " TEST OF SPECIAL ARGUMENTS")
+	fmt.Fprintln(rep)
+
+	x = zero
+	y := tanh(x)
+	fmt.Fprintf(rep, " TANH(0.0) = %.7E\n", y)
+	rep.AddSpecial("TANH(0.0)", float64(y))
+
+	// TANH should approach ±1 for large arguments
+	x = 20.0
+	y = tanh(x)
+	fmt.Fprintf(rep, " TANH(20.0) = %.17E (should be very close to 1.0)\n", y)
+	rep.AddSpecial("TANH(20.0)", float64(y))
+
+	x = -20.0
+	y = tanh(x)
+	fmt.Fprintf(rep, " TANH(-20.0) = %.17E (should be very close to -1.0)\n", y)
+	rep.AddSpecial("TANH(-20.0)", float64(y))
+
+	// Test of error returns
+	fmt.Fprintln(rep)
+	fmt.Fprintln(rep, "TEST OF ERROR RETURNS")
+	fmt.Fprintln(rep)
+
+	x = T(mp.XMax)
+	fmt.Fprintf(rep, " TANH WILL BE CALLED WITH THE ARGUMENT %.4E\n", x)
+	fmt.Fprintln(rep, " THIS SHOULD RETURN 1.0 (NO OVERFLOW)")
+	fmt.Fprintln(rep)
+	y = tanh(x)
+	fmt.Fprintf(rep, " TANH RETURNED THE VALUE %.17E\n\n", y)
+	rep.AddError("TANH(XMAX)", "1.0", float64(y), float64(x))
+
+	fmt.Fprintln(rep, " THIS CONCLUDES THE TESTS")
+}
@@ -0,0 +1,56 @@
+package suite
+
+import (
+	"golefunt/elefunt"
+)
+
+// Test is a test program that can be run in either precision.
+type Test struct {
+	Name        string // Name of the test, such as "sincos"
+	Description string // Functions tested, such as "Sin/Cos"
+	RandomTests int    // Number of random argument tests, whose intervals Options.Intervals can replace
+	Single      func(*elefunt.Report, *elefunt.Funcs[float32], Options)
+	Double      func(*elefunt.Report, *elefunt.Funcs[float64], Options)
+}
+
+var tests = []Test{
+	{"sincos", "Sin/Cos", 3, SinCos[float32], SinCos[float64]},
+	{"exp", "Exp", 3, Exp[float32], Exp[float64]},
+	{"log", "Log", 4, Log[float32], Log[float64]},
+	{"tan", "Tan", 4, Tan[float32], Tan[float64]},
+	{"sqrt", "Sqrt", 2, Sqrt[float32], Sqrt[float64]},
+	{"asin", "Asin/Acos", 4, Asin[float32], Asin[float64]},
+	{"atan", "Atan/Atan2", 4, Atan[float32], Atan[float64]},
+	{"sinh", "Sinh/Cosh", 4, Sinh[float32], Sinh[float64]},
+	{"tanh", "Tanh", 3, Tanh[float32], Tanh[float64]},
+	{"power", "Power (X**Y)", 4, Power[float32], Power[float64]},
+	{"expm1", "Expm1/Log1p", 4, Expm1[float32], Expm1[float64]},
+	{"cbrt", "Cbrt/Hypot", 6, Cbrt[float32], Cbrt[float64]},
+	{"asinh", "Asinh/Acosh/Atanh", 5, Asinh[float32], Asinh[float64]},
+	{"gamma", "Gamma/Lgamma", 6, Gamma[float32], Gamma[float64]},
+	{"erf", "Erf/Erfc/Erfinv/Erfcinv", 6, Erf[float32], Erf[float64]},
+}
+
+// Tests returns all tests in the order the ELEFUNT package runs them.
+func Tests() []Test {
+	return append([]Test(nil), tests...)
+}
+
+// Lookup returns the test named name.
+func Lookup(name string) (Test, bool) {
+	for _, t := range tests {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Test{}, false
+}
+
+// Run runs t against the math package functions in the named precision.
+func (t Test) Run(rep *elefunt.Report, precision string, opts Options) {
+	if precision == elefunt.PrecisionSingle {
+		t.Single(rep, elefunt.MathFuncs[float32](), opts)
+	} else {
+		t.Double(rep, elefunt.MathFuncs[float64](), opts)
+	}
+}
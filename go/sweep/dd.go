@@ -0,0 +1,106 @@
+package sweep
+
+import (
+	"math"
+	"math/big"
+)
+
+// dd is a double-double number, the unevaluated sum hi+lo of two float64
+// values with |lo| at most half an ULP of hi. It carries about 106 bits, so
+// a few operations on it leave a result far more accurate than float64.
+type dd struct {
+	hi, lo float64
+}
+
+// twoSum returns a+b exactly as a dd.
+func twoSum(a, b float64) dd {
+	s := a + b
+	bb := s - a
+	return dd{s, (a - (s - bb)) + (b - bb)}
+}
+
+// quickTwoSum returns a+b exactly as a dd, given |a| >= |b| or a zero.
+func quickTwoSum(a, b float64) dd {
+	s := a + b
+	return dd{s, b - (s - a)}
+}
+
+// twoProd returns a*b exactly as a dd.
+func twoProd(a, b float64) dd {
+	p := a * b
+	return dd{p, math.FMA(a, b, -p)}
+}
+
+// ddOf returns x rounded to a dd.
+func ddOf(x *big.Float) dd {
+	hi, _ := x.Float64()
+	r := new(big.Float).SetPrec(x.Prec()).Sub(x, big.NewFloat(hi))
+	lo, _ := r.Float64()
+	return dd{hi, lo}
+}
+
+func (a dd) float() float64 {
+	return a.hi + a.lo
+}
+
+func (a dd) neg() dd {
+	return dd{-a.hi, -a.lo}
+}
+
+func (a dd) add(b dd) dd {
+	s := twoSum(a.hi, b.hi)
+	t := twoSum(a.lo, b.lo)
+	s = quickTwoSum(s.hi, s.lo+t.hi)
+	return quickTwoSum(s.hi, s.lo+t.lo)
+}
+
+func (a dd) sub(b dd) dd {
+	return a.add(b.neg())
+}
+
+func (a dd) addf(b float64) dd {
+	s := twoSum(a.hi, b)
+	return quickTwoSum(s.hi, s.lo+a.lo)
+}
+
+func (a dd) mul(b dd) dd {
+	p := twoProd(a.hi, b.hi)
+	return quickTwoSum(p.hi, p.lo+(a.hi*b.lo+a.lo*b.hi))
+}
+
+func (a dd) mulf(b float64) dd {
+	p := twoProd(a.hi, b)
+	return quickTwoSum(p.hi, p.lo+a.lo*b)
+}
+
+func (a dd) div(b dd) dd {
+	// q1 + (a - q1*b)/b, with the remainder exact to about 2**-106 |a|.
+	q1 := a.hi / b.hi
+	p := twoProd(q1, b.hi)
+	r := twoSum(a.hi, -p.hi)
+	q2 := (r.hi + (r.lo - p.lo + a.lo - q1*b.lo)) / b.hi
+	return quickTwoSum(q1, q2)
+}
+
+func (a dd) divf(b float64) dd {
+	return a.div(dd{b, 0})
+}
+
+// sqrt returns the square root of a, or that of a.hi if a is not positive.
+func (a dd) sqrt() dd {
+	if a.hi <= 0 {
+		return dd{math.Sqrt(a.hi), 0}
+	}
+	// One Newton step from the float64 square root doubles its precision.
+	y := math.Sqrt(a.hi)
+	r := a.sub(twoProd(y, y))
+	return quickTwoSum(y, r.hi/(2*y))
+}
+
+func (a dd) ldexp(k int) dd {
+	if k > -1000 && k < 1000 {
+		s := math.Float64frombits(uint64(1023+k) << 52)
+		return dd{a.hi * s, a.lo * s}
+	}
+	return dd{math.Ldexp(a.hi, k), math.Ldexp(a.lo, k)}
+}
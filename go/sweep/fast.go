@@ -0,0 +1,836 @@
+package sweep
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+	"sync"
+
+	"golefunt/oracle"
+)
+
+// The float64 references below compute in double-double arithmetic,
+// independently of the math package, so that they decide the correctly
+// rounded float32 result of almost every argument without math/big. Their
+// error is well below the 2**-20 float32 ULPs of boundary. They return NaN
+// where the result is NaN or infinite, leaving those to the math/big
+// reference, and huge, of the sign of the result, where it overflows.
+
+// huge stands for a result beyond the float32 range.
+const huge = math.MaxFloat64
+
+var fast = map[string]func(float64) float64{
+	"exp":     fastExp,
+	"log":     fastLog,
+	"log10":   fastLog10,
+	"expm1":   fastExpm1,
+	"log1p":   fastLog1p,
+	"sqrt":    fastSqrt,
+	"cbrt":    fastCbrt,
+	"sin":     fastSin,
+	"cos":     fastCos,
+	"tan":     fastTan,
+	"asin":    fastAsin,
+	"acos":    fastAcos,
+	"atan":    fastAtan,
+	"sinh":    fastSinh,
+	"cosh":    fastCosh,
+	"tanh":    fastTanh,
+	"asinh":   fastAsinh,
+	"acosh":   fastAcosh,
+	"atanh":   fastAtanh,
+	"gamma":   fastGamma,
+	"lgamma":  fastLgamma,
+	"erf":     fastErf,
+	"erfc":    fastErfc,
+	"erfinv":  fastErfinv,
+	"erfcinv": fastErfcinv,
+}
+
+// constants holds the constants of the float64 references, computed once
+// with the oracle.
+type constants struct {
+	pi, ln2, ln10, logPi, halfLog2Pi, twoOverSqrtPi, euler dd
+	exps                                                   [64]dd // 2**(j/64)
+	logs                                                   [65]dd // log(1 + j/64)
+	atans                                                  [9]dd  // atan(j/8)
+	invFact                                                [9]dd  // 1/n!
+	erfinv                                                 [6]dd  // Coefficients of the Maclaurin series of erfinv(2x/sqrt(pi))
+	twoOverPi                                              [6]uint64
+}
+
+// euler is Euler's constant, which the oracle does not provide.
+const euler = "0.57721566490153286060651209008240243104215933593992"
+
+var consts = sync.OnceValue(func() *constants {
+	const prec = 256
+	c := &constants{}
+	pi := oracle.Pi(prec)
+	c.pi = ddOf(pi)
+	ln2 := oracle.Ln2(prec)
+	c.ln2 = ddOf(ln2)
+	c.ln10 = ddOf(oracle.Log(big.NewFloat(10), prec))
+	c.logPi = ddOf(oracle.Log(pi, prec))
+	twoPi := new(big.Float).SetPrec(prec).Mul(pi, big.NewFloat(2))
+	c.halfLog2Pi = ddOf(oracle.Log(twoPi, prec)).ldexp(-1)
+	sqrtPi := oracle.Sqrt(pi, prec)
+	c.twoOverSqrtPi = ddOf(new(big.Float).SetPrec(prec).Quo(big.NewFloat(2), sqrtPi))
+	gamma, _ := new(big.Float).SetPrec(prec).SetString(euler)
+	c.euler = ddOf(gamma)
+	for j := range c.exps {
+		t := new(big.Float).SetPrec(prec).Mul(ln2, big.NewFloat(float64(j)/64))
+		c.exps[j] = ddOf(oracle.Exp(t, prec))
+	}
+	for j := range c.logs {
+		c.logs[j] = ddOf(oracle.Log(big.NewFloat(1+float64(j)/64), prec))
+	}
+	for j := range c.atans {
+		c.atans[j] = ddOf(oracle.Atan(big.NewFloat(float64(j)/8), prec))
+	}
+	f := 1.0
+	for n := range c.invFact {
+		if n > 0 {
+			f *= float64(n)
+		}
+		c.invFact[n] = dd{1, 0}.divf(f)
+	}
+	for k, r := range [][2]float64{
+		{1, 1}, {1, 3}, {7, 30}, {127, 630}, {4369, 22680}, {34807, 178200},
+	} {
+		c.erfinv[k] = dd{r[0], 0}.divf(r[1])
+	}
+	// The bits of 2/pi after its binary point, preceded by 64 zero bits.
+	t := new(big.Float).SetPrec(2*prec).Quo(big.NewFloat(2), oracle.Pi(2*prec))
+	t.SetMantExp(t, 64*len(c.twoOverPi)-64)
+	n, _ := t.Int(nil)
+	for i := len(c.twoOverPi) - 1; i >= 0; i-- {
+		c.twoOverPi[i] = n.Uint64()
+		n.Rsh(n, 64)
+	}
+	return c
+})
+
+// finite reports whether x is neither infinite nor NaN.
+func finite(x float64) bool {
+	return !math.IsNaN(x) && !math.IsInf(x, 0)
+}
+
+// withSign returns y with the sign of x.
+func withSign(y, x float64) float64 {
+	if x < 0 {
+		return -y
+	}
+	return y
+}
+
+// The kernels below sum the leading terms of their series in double-double
+// and the rest in float64, whose rounding errors are then far below the
+// result's. Their relative error is about 2**-70.
+
+// expm1Poly returns e**r - 1 for |r| <= ln(2)/128.
+func expm1Poly(r dd) dd {
+	x := r.hi
+	tail := x * x * x * (1.0/6 + x*(1.0/24+x*(1.0/120+x*(1.0/720+x*(1.0/5040)))))
+	return r.add(r.mul(r).ldexp(-1)).addf(tail)
+}
+
+// expDD returns e**a for |a| < 709.
+func expDD(a dd) dd {
+	c := consts()
+	// e**a = 2**n 2**(j/64) e**r with k = 64n + j and |r| <= ln(2)/128
+	k := math.Round(a.hi * (64 / math.Ln2))
+	r := a.sub(c.ln2.mulf(k / 64))
+	t := c.exps[int(k)&63]
+	return t.add(t.mul(expm1Poly(r))).ldexp(int(k) >> 6)
+}
+
+// expm1DD returns e**a - 1 for |a| < 709.
+func expm1DD(a dd) dd {
+	if math.Abs(a.hi) <= math.Ln2/128 {
+		return expm1Poly(a)
+	}
+	// e**a is at least 1 + ln(2)/128, so the subtraction loses 8 bits.
+	return expDD(a).addf(-1)
+}
+
+// atanh2 returns 2 atanh(s) for |s| <= 2**-8.
+func atanh2(s dd) dd {
+	x := s.hi
+	x2 := x * x
+	tail := x * x2 * x2 * (2.0/5 + x2*(2.0/7+x2*(2.0/9)))
+	s3 := s.mul(s).mul(s).ldexp(1).divf(3)
+	return s.ldexp(1).add(s3).addf(tail)
+}
+
+// logDD returns the natural logarithm of a > 0.
+func logDD(a dd) dd {
+	c := consts()
+	_, e := math.Frexp(a.hi)
+	a = a.ldexp(1 - e)
+	// log(a) = log(1 + j/64) + 2 atanh(s) with |s| <= 2**-8
+	j := math.Round((a.hi - 1) * 64)
+	m := 1 + j/64
+	s := a.addf(-m).div(a.addf(m))
+	return c.logs[int(j)].add(atanh2(s)).add(c.ln2.mulf(float64(e - 1)))
+}
+
+// log1pDD returns log(1 + t) for t > -1.
+func log1pDD(t dd) dd {
+	if math.Abs(t.hi) < 0x1p-8 {
+		return atanh2(t.div(t.addf(2)))
+	}
+	return logDD(t.addf(1))
+}
+
+func fastExp(x float64) float64 {
+	switch {
+	case !finite(x):
+		return math.NaN()
+	case x > 100:
+		return huge
+	case x < -800:
+		return 0
+	}
+	return expDD(dd{x, 0}).float()
+}
+
+func fastExpm1(x float64) float64 {
+	switch {
+	case !finite(x):
+		return math.NaN()
+	case x == 0:
+		return x
+	case x > 100:
+		return huge
+	case x < -50:
+		return -1
+	}
+	return expm1DD(dd{x, 0}).float()
+}
+
+func fastLog(x float64) float64 {
+	if !finite(x) || x <= 0 {
+		return math.NaN()
+	}
+	return logDD(dd{x, 0}).float()
+}
+
+func fastLog10(x float64) float64 {
+	if !finite(x) || x <= 0 {
+		return math.NaN()
+	}
+	return logDD(dd{x, 0}).div(consts().ln10).float()
+}
+
+func fastLog1p(x float64) float64 {
+	switch {
+	case !finite(x) || x <= -1:
+		return math.NaN()
+	case x == 0:
+		return x
+	}
+	return log1pDD(dd{x, 0}).float()
+}
+
+func fastSqrt(x float64) float64 {
+	switch {
+	case !finite(x) || x < 0:
+		return math.NaN()
+	case x == 0:
+		return x
+	}
+	return dd{x, 0}.sqrt().float()
+}
+
+func fastCbrt(x float64) float64 {
+	switch {
+	case !finite(x):
+		return math.NaN()
+	case x == 0:
+		return x
+	}
+	// cbrt(m * 2**3k) = cbrt(m) * 2**k with m in [1/2, 4)
+	m, e := math.Frexp(math.Abs(x))
+	r := (e%3 + 3) % 3
+	m = math.Ldexp(m, r)
+	k := (e - r) / 3
+	// Newton's method in float64, then one step in double-double.
+	y := 1.0
+	for i := 0; i < 6; i++ {
+		y = (2*y + m/(y*y)) / 3
+	}
+	y3 := twoProd(y, y).mulf(y)
+	d := y3.addf(-m).hi / (3 * y * y)
+	return withSign(quickTwoSum(y, -d).ldexp(k).float(), x)
+}
+
+// reduce returns r and q with |x| = r + q*pi/2 modulo 2*pi and |r| <= pi/4,
+// for a float32 value x.
+func reduce(x float64) (dd, int) {
+	c := consts()
+	x = math.Abs(x)
+	if x <= math.Pi/4 {
+		return dd{x, 0}, 0
+	}
+	// x = m * 2**e with m an integer of 24 bits. The bits of 2/pi of
+	// weight 2**-i with i < e-1 contribute multiples of 4 to x*2/pi, so
+	// from the 192 bits starting at the one of weight 2**(1-e),
+	// x*2/pi = m*w*2**-190 modulo 4.
+	b := math.Float32bits(float32(x))
+	m := uint64(b&(1<<23-1) | 1<<23)
+	e := int(b>>23) - 127 - 23
+	start := e + 62
+	i, s := start/64, uint(start%64)
+	var w [3]uint64
+	for k := range w {
+		w[k] = c.twoOverPi[i+k]<<s | c.twoOverPi[i+k+1]>>(64-s)
+	}
+	h2, p3 := bits.Mul64(m, w[2])
+	h1, l1 := bits.Mul64(m, w[1])
+	_, l0 := bits.Mul64(m, w[0])
+	p2, carry := bits.Add64(l1, h2, 0)
+	p1, _ := bits.Add64(l0, h1, carry)
+	// Bits 190 and 191 of the product give the quadrant, the 190 below
+	// them the fraction f, taken as f-1 if f is at least 1/2.
+	q := int(p1 >> 62)
+	p1 &= 1<<62 - 1
+	neg := p1>>61 != 0
+	if neg {
+		var borrow uint64
+		p3, borrow = bits.Sub64(0, p3, 0)
+		p2, borrow = bits.Sub64(0, p2, borrow)
+		p1, _ = bits.Sub64(1<<62, p1, borrow)
+		q = (q + 1) & 3
+	}
+	var f dd
+	for k, p := range []uint64{p3, p2, p1} {
+		f = f.add(dd{math.Ldexp(float64(p&(1<<32-1)), 64*k-190), 0})
+		f = f.add(dd{math.Ldexp(float64(p>>32), 64*k+32-190), 0})
+	}
+	r := f.mul(c.pi).ldexp(-1)
+	if neg {
+		r = r.neg()
+	}
+	return r, q
+}
+
+// sinKernel returns sin(r) for |r| <= pi/4.
+func sinKernel(r dd) dd {
+	c := consts()
+	x2 := r.hi * r.hi
+	tail := 1.0/362880 + x2*(-1.0/39916800+x2*(1.0/6227020800+x2*(-1.0/1307674368000+
+		x2*(1.0/355687428096000+x2*(-1.0/121645100408832000)))))
+	r2 := r.mul(r)
+	p := r2.mulf(tail).sub(c.invFact[7])
+	p = p.mul(r2).add(c.invFact[5])
+	p = p.mul(r2).sub(c.invFact[3])
+	return r.add(r.mul(r2).mul(p))
+}
+
+// cosKernel returns cos(r) for |r| <= pi/4.
+func cosKernel(r dd) dd {
+	c := consts()
+	x2 := r.hi * r.hi
+	tail := -1.0/3628800 + x2*(1.0/479001600+x2*(-1.0/87178291200+x2*(1.0/20922789888000+
+		x2*(-1.0/6402373705728000+x2*(1.0/2432902008176640000)))))
+	r2 := r.mul(r)
+	p := r2.mulf(tail).add(c.invFact[8])
+	p = p.mul(r2).sub(c.invFact[6])
+	p = p.mul(r2).add(c.invFact[4])
+	p = p.mul(r2).sub(c.invFact[2])
+	return r2.mul(p).addf(1)
+}
+
+func fastSin(x float64) float64 {
+	switch {
+	case !finite(x):
+		return math.NaN()
+	case x == 0:
+		return x
+	}
+	r, q := reduce(x)
+	var s dd
+	switch q {
+	case 0:
+		s = sinKernel(r)
+	case 1:
+		s = cosKernel(r)
+	case 2:
+		s = sinKernel(r).neg()
+	case 3:
+		s = cosKernel(r).neg()
+	}
+	return withSign(s.float(), x)
+}
+
+func fastCos(x float64) float64 {
+	if !finite(x) {
+		return math.NaN()
+	}
+	r, q := reduce(x)
+	var s dd
+	switch q {
+	case 0:
+		s = cosKernel(r)
+	case 1:
+		s = sinKernel(r).neg()
+	case 2:
+		s = cosKernel(r).neg()
+	case 3:
+		s = sinKernel(r)
+	}
+	return s.float()
+}
+
+func fastTan(x float64) float64 {
+	switch {
+	case !finite(x):
+		return math.NaN()
+	case x == 0:
+		return x
+	}
+	r, q := reduce(x)
+	s, c := sinKernel(r), cosKernel(r)
+	var t dd
+	if q%2 == 0 {
+		t = s.div(c)
+	} else {
+		t = c.div(s).neg()
+	}
+	return withSign(t.float(), x)
+}
+
+// atanDD returns atan(t) for t >= 0.
+func atanDD(t dd) dd {
+	c := consts()
+	if t.hi > 1 {
+		return c.pi.ldexp(-1).sub(atanDD(dd{1, 0}.div(t)))
+	}
+	// atan(t) = atan(j/8) + atan(u) with |u| <= 1/16
+	j := math.Round(t.hi * 8)
+	m := j / 8
+	u := t.addf(-m).div(t.mulf(m).addf(1))
+	x := u.hi
+	x2 := x * x
+	tail := x * x2 * x2 * (1.0/5 + x2*(-1.0/7+x2*(1.0/9+x2*(-1.0/11+x2*(1.0/13+x2*(-1.0/15+x2*(1.0/17)))))))
+	u3 := u.mul(u).mul(u).divf(-3)
+	return c.atans[int(j)].add(u.add(u3).addf(tail))
+}
+
+func fastAtan(x float64) float64 {
+	switch {
+	case !finite(x):
+		return math.NaN()
+	case x == 0:
+		return x
+	}
+	return withSign(atanDD(dd{math.Abs(x), 0}).float(), x)
+}
+
+func fastAsin(x float64) float64 {
+	a := math.Abs(x)
+	switch {
+	case !(a <= 1):
+		return math.NaN()
+	case x == 0:
+		return x
+	case a == 1:
+		return withSign(consts().pi.ldexp(-1).float(), x)
+	}
+	// asin(x) = atan(x/sqrt((1-x)(1+x)))
+	t := dd{a, 0}.div(twoProd(1-a, 1+a).sqrt())
+	return withSign(atanDD(t).float(), x)
+}
+
+func fastAcos(x float64) float64 {
+	switch {
+	case !(math.Abs(x) <= 1):
+		return math.NaN()
+	case x == -1:
+		return consts().pi.float()
+	}
+	// acos(x) = 2 atan(sqrt((1-x)/(1+x)))
+	t := dd{1 - x, 0}.divf(1 + x).sqrt()
+	return atanDD(t).ldexp(1).float()
+}
+
+func fastSinh(x float64) float64 {
+	a := math.Abs(x)
+	switch {
+	case !finite(x):
+		return math.NaN()
+	case x == 0:
+		return x
+	case a > 100:
+		return withSign(huge, x)
+	}
+	// sinh(a) = (E + E/(E+1))/2 with E = e**a - 1
+	e := expm1DD(dd{a, 0})
+	return withSign(e.add(e.div(e.addf(1))).ldexp(-1).float(), x)
+}
+
+func fastCosh(x float64) float64 {
+	a := math.Abs(x)
+	switch {
+	case !finite(x):
+		return math.NaN()
+	case a > 100:
+		return huge
+	}
+	e := expDD(dd{a, 0})
+	return e.add(dd{1, 0}.div(e)).ldexp(-1).float()
+}
+
+func fastTanh(x float64) float64 {
+	a := math.Abs(x)
+	switch {
+	case !finite(x):
+		return math.NaN()
+	case x == 0:
+		return x
+	case a > 20:
+		return withSign(1, x)
+	}
+	// tanh(a) = E/(E+2) with E = e**2a - 1
+	e := expm1DD(dd{2 * a, 0})
+	return withSign(e.div(e.addf(2)).float(), x)
+}
+
+func fastAsinh(x float64) float64 {
+	a := math.Abs(x)
+	switch {
+	case !finite(x):
+		return math.NaN()
+	case x == 0:
+		return x
+	}
+	// asinh(a) = log1p(a + a**2/(1 + sqrt(1 + a**2)))
+	a2 := twoProd(a, a)
+	t := a2.div(a2.addf(1).sqrt().addf(1)).addf(a)
+	return withSign(log1pDD(t).float(), x)
+}
+
+func fastAcosh(x float64) float64 {
+	switch {
+	case !finite(x) || x < 1:
+		return math.NaN()
+	case x == 1:
+		return 0
+	}
+	// acosh(x) = log1p(x-1 + sqrt((x-1)(x+1)))
+	d := twoSum(x, -1)
+	t := d.add(d.mul(twoSum(x, 1)).sqrt())
+	return log1pDD(t).float()
+}
+
+func fastAtanh(x float64) float64 {
+	a := math.Abs(x)
+	switch {
+	case !(a < 1):
+		return math.NaN()
+	case x == 0:
+		return x
+	}
+	// atanh(a) = log1p(2a/(1-a))/2
+	t := dd{2 * a, 0}.divf(1 - a)
+	return withSign(log1pDD(t).ldexp(-1).float(), x)
+}
+
+// lgammaDD returns log(Gamma(x)) for x > 0.
+func lgammaDD(x float64) dd {
+	c := consts()
+	// log Gamma(x) = log Gamma(z) - log(x(x+1)...(z-1)) for z = x+n >= 16,
+	// where the Stirling series converges fast.
+	z, p := dd{x, 0}, dd{1, 0}
+	for z.hi < 16 {
+		p = p.mul(z)
+		z = z.addf(1)
+	}
+	l := z.addf(-0.5).mul(logDD(z)).sub(z).add(c.halfLog2Pi)
+	zi := dd{1, 0}.div(z)
+	// 1/12z - 1/360z**3 + ..., the terms B(2k)/(2k(2k-1)z**(2k-1))
+	y := zi.hi * zi.hi
+	tail := y * y * (1.0/1260 + y*(-1.0/1680+y*(1.0/1188+y*(-691.0/360360+y*(1.0/156+
+		y*(-3617.0/122400+y*(43867.0/244188+y*(-174611.0/125400))))))))
+	s := zi.mul(zi).divf(-30).addf(1).divf(12).addf(tail).mul(zi)
+	l = l.add(s)
+	if p.hi != 1 {
+		l = l.sub(logDD(p))
+	}
+	return l
+}
+
+// sinPi returns sin(pi*x) for a float32 value x.
+func sinPi(x float64) dd {
+	c := consts()
+	// r = x modulo 2 in [-1, 1], then sin(pi*r) = sin(pi*(1-r)).
+	r := x - 2*math.Round(x/2)
+	switch {
+	case r > 0.5:
+		r = 1 - r
+	case r < -0.5:
+		r = -1 - r
+	}
+	if math.Abs(r) <= 0.25 {
+		return sinKernel(c.pi.mulf(r))
+	}
+	s := cosKernel(c.pi.mulf(0.5 - math.Abs(r)))
+	if r < 0 {
+		s = s.neg()
+	}
+	return s
+}
+
+// lgammaNeg returns log|Gamma(x)| for a float32 value -2**23 < x < 0 that is
+// not an integer, and the sign of Gamma(x).
+func lgammaNeg(x float64) (dd, float64) {
+	// Gamma(x) Gamma(1-x) = pi/sin(pi*x)
+	s := sinPi(x)
+	sign := 1.0
+	if s.hi < 0 {
+		s, sign = s.neg(), -1
+	}
+	return consts().logPi.sub(logDD(s)).sub(lgammaDD(1 - x)), sign
+}
+
+func fastGamma(x float64) float64 {
+	switch {
+	case !finite(x) || x == 0 || x < 0 && x == math.Floor(x) || x < -1<<20:
+		return math.NaN()
+	case x > 36:
+		return huge
+	case math.Abs(x) <= 0x1p-20:
+		// Gamma(x) = 1/x - gamma + (gamma**2/2 + pi**2/12) x + O(x**2)
+		c := consts()
+		c1 := c.euler.hi*c.euler.hi/2 + c.pi.hi*c.pi.hi/12
+		return dd{1, 0}.divf(x).sub(c.euler).addf(c1 * x).float()
+	case x > 0:
+		return expDD(lgammaDD(x)).float()
+	case x < -50:
+		// Below every float32 value
+		return math.Copysign(0, sinPi(x).hi)
+	}
+	l, sign := lgammaNeg(x)
+	return sign * expDD(l).float()
+}
+
+func fastLgamma(x float64) float64 {
+	switch {
+	case !finite(x) || x <= 0 && x == math.Floor(x):
+		return math.NaN()
+	case x == 1 || x == 2:
+		return 0
+	case math.Abs(x) <= 0x1p-20:
+		// log|Gamma(x)| = -log|x| - gamma x + (pi**2/12) x**2 + O(x**3)
+		c := consts()
+		return logDD(dd{math.Abs(x), 0}).neg().sub(c.euler.mulf(x)).addf(c.pi.hi * c.pi.hi / 12 * x * x).float()
+	case x > 0:
+		return lgammaDD(x).float()
+	}
+	l, _ := lgammaNeg(x)
+	return l.float()
+}
+
+// erfSeries returns erf(x) for 0 <= x < 2 by the series
+// erf(x) = 2x/sqrt(pi) e**-x**2 sum (2x**2)**n / (1*3*...*(2n+1)).
+func erfSeries(x dd) dd {
+	x2 := x.mul(x)
+	t := x2.ldexp(1)
+	sum, term := dd{1, 0}, dd{1, 0}
+	n := 1.0
+	for ; term.hi > 0x1p-16*sum.hi; n++ {
+		term = term.mul(t).divf(2*n + 1)
+		sum = sum.add(term)
+	}
+	// The remaining terms are below 2**-16 of the sum, so they can be
+	// summed in float64.
+	var tail float64
+	for f := term.hi; f > 0x1p-64*sum.hi; n++ {
+		f *= t.hi / (2*n + 1)
+		tail += f
+	}
+	sum = sum.addf(tail)
+	return sum.mul(x).mul(consts().twoOverSqrtPi).mul(expDD(x2.neg()))
+}
+
+// erfcFraction returns erfc(x) for x >= 2 by the continued fraction
+// erfc(x) = e**-x**2/sqrt(pi) / (x + (1/2)/(x + 1/(x + (3/2)/(x + ...)))).
+func erfcFraction(x dd) dd {
+	// The last steps, in which the error of the tail of the fraction
+	// does not yet shrink much, are taken in double-double.
+	x2 := x.mul(x)
+	n := int(400/x2.hi) + 12
+	f := x.hi
+	for k := n; k > 10; k-- {
+		f = x.hi + float64(k)/2/f
+	}
+	fd := dd{f, 0}
+	for k := min(n, 10); k >= 1; k-- {
+		fd = x.add(dd{float64(k) / 2, 0}.div(fd))
+	}
+	return consts().twoOverSqrtPi.ldexp(-1).mul(expDD(x2.neg())).div(fd)
+}
+
+// erfDD returns erf(x) for 0 <= x < 6.
+func erfDD(x dd) dd {
+	if x.hi < 2 {
+		return erfSeries(x)
+	}
+	return erfcFraction(x).neg().addf(1)
+}
+
+// erfcDD returns erfc(x) for 0 <= x < 26.
+func erfcDD(x dd) dd {
+	if x.hi < 2 {
+		return erfSeries(x).neg().addf(1)
+	}
+	return erfcFraction(x)
+}
+
+func fastErf(x float64) float64 {
+	a := math.Abs(x)
+	switch {
+	case !finite(x):
+		return math.NaN()
+	case x == 0:
+		return x
+	case a >= 6:
+		return withSign(1, x)
+	}
+	return withSign(erfDD(dd{a, 0}).float(), x)
+}
+
+func fastErfc(x float64) float64 {
+	switch {
+	case !finite(x):
+		return math.NaN()
+	case x > 12:
+		return 0
+	case x < -6:
+		return 2
+	case x < 0:
+		return erfDD(dd{-x, 0}).addf(1).float()
+	}
+	return erfcDD(dd{x, 0}).float()
+}
+
+// erfinvGuess returns erfinv(y) for 0 < y < 1 to about 24 bits, given
+// 1-y and 1+y. Where 1-y is a float32 value it uses the approximation of
+// Giles, "Approximating the erfinv function", GPU Computing Gems (2011);
+// below, the asymptotic series of erfc.
+func erfinvGuess(y, oneMinusY, onePlusY float64) float64 {
+	w := -logDD(twoProd(oneMinusY, onePlusY)).float()
+	var p float64
+	switch {
+	case w < 5:
+		w -= 2.5
+		p = 2.81022636e-08
+		for _, c := range []float64{3.43273939e-07, -3.5233877e-06, -4.39150654e-06, 0.00021858087,
+			-0.00125372503, -0.00417768164, 0.246640727, 1.50140941} {
+			p = c + p*w
+		}
+	case w < 16:
+		w = math.Sqrt(w) - 3
+		p = -0.000200214257
+		for _, c := range []float64{0.000100950558, 0.00134934322, -0.00367342844, 0.00573950773,
+			-0.0076224613, 0.00943887047, 1.00167406, 2.83297682} {
+			p = c + p*w
+		}
+	default:
+		// erfc(x) = e**-x**2/(x sqrt(pi)) s(x) with the asymptotic series
+		// s(x) = 1 - 1/2x**2 + 1*3/(2x**2)**2 - ..., summed to its least
+		// term, so x**2 = -log(1-y) - log(x sqrt(pi)/s(x)).
+		l := -logDD(dd{oneMinusY, 0}).float()
+		x := math.Sqrt(l)
+		for i := 0; i < 16; i++ {
+			v := 1 / (2 * x * x)
+			s, term := 1.0, 1.0
+			for k := 1.0; ; k++ {
+				next := -term * (2*k - 1) * v
+				if math.Abs(next) >= math.Abs(term) || math.Abs(next) < 0x1p-60 {
+					break
+				}
+				term = next
+				s += term
+			}
+			prev := x
+			x = math.Sqrt(l - logDD(dd{x * math.SqrtPi / s, 0}).float())
+			if math.Abs(x-prev) <= 0x1p-40*x {
+				break
+			}
+		}
+		return x
+	}
+	return p * y
+}
+
+// erfinvDD returns the x > 0 with erf(x) = y, or with erfc(x) = y if upper
+// is set, for 0 < y <= 1/2, by Halley's method from the guess x.
+func erfinvDD(x float64, y float64, upper bool) dd {
+	c := consts()
+	xd := dd{x, 0}
+	for i := 0; i < 8; i++ {
+		// The derivative of erf is d = 2/sqrt(pi) e**-x**2, and its own
+		// derivative -2x d.
+		d := c.twoOverSqrtPi.mul(expDD(xd.mul(xd).neg()))
+		var u dd
+		if upper {
+			u = erfcDD(xd).addf(-y).div(d).neg()
+		} else {
+			u = erfDD(xd).addf(-y).div(d)
+		}
+		dx := u.div(u.mul(xd).addf(1))
+		xd = xd.sub(dx)
+		// The error is now about dx**3.
+		if math.Abs(dx.hi) <= 0x1p-24*xd.hi {
+			break
+		}
+	}
+	return xd
+}
+
+// erfinvSeries returns erfinv(y) for |y| <= 2**-6 by its Maclaurin series.
+func erfinvSeries(y float64) dd {
+	c := consts()
+	z := dd{y, 0}.div(c.twoOverSqrtPi)
+	z2 := z.mul(z)
+	var s dd
+	for k := len(c.erfinv) - 1; k >= 0; k-- {
+		s = s.mul(z2).add(c.erfinv[k])
+	}
+	return s.mul(z)
+}
+
+func fastErfinv(y float64) float64 {
+	a := math.Abs(y)
+	switch {
+	case !(a < 1):
+		return math.NaN()
+	case y == 0:
+		return y
+	case a <= 0x1p-6:
+		return erfinvSeries(y).float()
+	case a <= 0.5:
+		return withSign(erfinvDD(erfinvGuess(a, 1-a, 1+a), a, false).float(), y)
+	}
+	return withSign(erfinvDD(erfinvGuess(a, 1-a, 1+a), 1-a, true).float(), y)
+}
+
+func fastErfcinv(z float64) float64 {
+	switch {
+	case !(z > 0 && z < 2):
+		return math.NaN()
+	case z == 1:
+		return 0
+	case z > 1:
+		return -fastErfcinv(2 - z)
+	}
+	y := 1 - z
+	switch {
+	case y <= 0x1p-6:
+		return erfinvSeries(y).float()
+	case y <= 0.5:
+		return erfinvDD(erfinvGuess(y, z, 2-z), y, false).float()
+	}
+	return erfinvDD(erfinvGuess(y, z, 2-z), z, true).float()
+}
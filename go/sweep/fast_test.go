@@ -0,0 +1,61 @@
+package sweep
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"golefunt/elefunt"
+	"golefunt/oracle"
+)
+
+// fastArgs returns float32 arguments spread over every binade, and
+// densely over the ranges where the references change method.
+func fastArgs() []float32 {
+	r := rand.New(rand.NewSource(1))
+	var xs []float32
+	for i := 0; i < 2000; i++ {
+		xs = append(xs, math.Float32frombits(r.Uint32()))
+	}
+	for _, iv := range [][2]float64{{-1, 1}, {0, 2}, {-12, 12}, {-60, 40}, {0.99, 1.01}, {1.99, 2.01}, {-3, -2}} {
+		for i := 0; i < 400; i++ {
+			xs = append(xs, float32(iv[0]+(iv[1]-iv[0])*r.Float64()))
+		}
+	}
+	for _, x := range []float32{1, 2, -1, 0.5, -0.5, 1e-30, 1 - 0x1p-24, 1 + 0x1p-23, 0x1p-149, math.MaxFloat32} {
+		xs = append(xs, x, -x, math.Nextafter32(x, 0), math.Nextafter32(x, 3*x))
+	}
+	return xs
+}
+
+func TestFast(t *testing.T) {
+	xs := fastArgs()
+	for name, f := range fast {
+		exact, _ := oracle.Lookup1(name)
+		worst, at := 0.0, float32(0)
+		for _, x := range xs {
+			w := f(float64(x))
+			if !decides(w) {
+				continue
+			}
+			want := exact(big.NewFloat(float64(x)), 128)
+			if got, ok := elefunt.RoundBig[float32](want), float32(w); !same(got, ok) {
+				t.Errorf("%s(%g) = %g, the reference rounds to %g", name, x, w, got)
+				continue
+			}
+			if want == nil || want.IsInf() || math.Abs(w) > math.MaxFloat32 {
+				continue
+			}
+			d := new(big.Float).Sub(want, big.NewFloat(w))
+			e, _ := d.Float64()
+			if e = math.Abs(e) / ulp(w); e > worst {
+				worst, at = e, x
+			}
+		}
+		t.Logf("%s: %g float32 ULPs at %g", name, worst, at)
+		if worst > boundary/16 {
+			t.Errorf("%s: error %g float32 ULPs at %g, want at most %g", name, worst, at, boundary/16)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package sweep
+
+import (
+	"math"
+	"math/big"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"golefunt/elefunt"
+	"golefunt/oracle"
+	"golefunt/random"
+)
+
+// GridOptions controls a sweep of a function of two arguments over the
+// rectangle (XA, XB) by (YA, YB), divided into NX by NY cells.
+type GridOptions struct {
+	XA, XB  float64
+	YA, YB  float64
+	NX, NY  int
+	Samples int  // Arguments per cell: 1 for the center of the cell, more for random arguments within it
+	Seed    int  // Seed of the random arguments, or the ELEFUNT seed if zero
+	Workers int  // Number of goroutines, or runtime.NumCPU() if zero
+	Prec    uint // Precision of the reference in bits, or oracle.DefaultPrec if zero
+}
+
+// Cell is the summary of the errors in one cell of a grid.
+type Cell struct {
+	N       int           `json:"n"`
+	MaxULP  elefunt.Float `json:"max_ulp"`
+	MeanULP elefunt.Float `json:"mean_ulp"`
+	X       elefunt.Float `json:"x"` // Arguments of the maximum error
+	Y       elefunt.Float `json:"y"`
+}
+
+// Grid holds the errors of a function of two arguments over a rectangle.
+type Grid struct {
+	Function  string        `json:"function,omitempty"`
+	Precision string        `json:"precision"`
+	XA        elefunt.Float `json:"xa"`
+	XB        elefunt.Float `json:"xb"`
+	YA        elefunt.Float `json:"ya"`
+	YB        elefunt.Float `json:"yb"`
+	NX        int           `json:"nx"`
+	NY        int           `json:"ny"`
+	Cells     []Cell        `json:"cells"` // Cells[j*NX+i] is the i-th cell from XA in the j-th row from YA
+}
+
+// Region is a cell of a grid and its bounds.
+type Region struct {
+	Cell
+	I  int           `json:"i"`
+	J  int           `json:"j"`
+	XA elefunt.Float `json:"xa"`
+	XB elefunt.Float `json:"xb"`
+	YA elefunt.Float `json:"ya"`
+	YB elefunt.Float `json:"yb"`
+}
+
+// Grid2 evaluates f, a function of two arguments in the precision of T,
+// over the grid described by opts and compares the results with exact.
+func Grid2[T elefunt.Real](f func(T, T) T, exact oracle.Func2, opts GridOptions) *Grid {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	prec := opts.Prec
+	if prec == 0 {
+		prec = oracle.DefaultPrec
+	}
+	seed := opts.Seed
+	if seed == 0 {
+		seed = random.DefaultSeed
+	}
+	samples := max(opts.Samples, 1)
+	mp := elefunt.Params[T]()
+
+	g := &Grid{
+		Precision: elefunt.Precision[T](),
+		XA:        elefunt.Float(opts.XA),
+		XB:        elefunt.Float(opts.XB),
+		YA:        elefunt.Float(opts.YA),
+		YB:        elefunt.Float(opts.YB),
+		NX:        opts.NX,
+		NY:        opts.NY,
+		Cells:     make([]Cell, opts.NX*opts.NY),
+	}
+	dx := (opts.XB - opts.XA) / float64(opts.NX)
+	dy := (opts.YB - opts.YA) / float64(opts.NY)
+
+	// Each cell has its own random stream, so the results do not depend
+	// on which goroutine evaluates it.
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				c := int(next.Add(1) - 1)
+				if c >= len(g.Cells) {
+					return
+				}
+				i, j := c%opts.NX, c/opts.NX
+				rng := random.NewStream(seed, uint64(c))
+				cell := &g.Cells[c]
+				sum := 0.0
+				for s := 0; s < samples; s++ {
+					u, v := 0.5, 0.5
+					if samples > 1 {
+						u, v = rng.Float64(), rng.Float64()
+					}
+					x := T(opts.XA + (float64(i)+u)*dx)
+					y := T(opts.YA + (float64(j)+v)*dy)
+					want := exact(big.NewFloat(float64(x)), big.NewFloat(float64(y)), prec)
+					e := oracle.ULPs(float64(f(x, y)), want, mp)
+					if cell.N == 0 || e > float64(cell.MaxULP) {
+						cell.MaxULP = elefunt.Float(e)
+						cell.X, cell.Y = elefunt.Float(x), elefunt.Float(y)
+					}
+					cell.N++
+					sum += e
+				}
+				cell.MeanULP = elefunt.Float(sum / float64(cell.N))
+			}
+		}()
+	}
+	wg.Wait()
+	return g
+}
+
+// Region returns cell i, j of g with its bounds.
+func (g *Grid) Region(i, j int) Region {
+	dx := float64(g.XB-g.XA) / float64(g.NX)
+	dy := float64(g.YB-g.YA) / float64(g.NY)
+	return Region{
+		Cell: g.Cells[j*g.NX+i],
+		I:    i,
+		J:    j,
+		XA:   g.XA + elefunt.Float(float64(i)*dx),
+		XB:   g.XA + elefunt.Float(float64(i+1)*dx),
+		YA:   g.YA + elefunt.Float(float64(j)*dy),
+		YB:   g.YA + elefunt.Float(float64(j+1)*dy),
+	}
+}
+
+// Worst returns the k cells with the largest maximum errors, largest first.
+func (g *Grid) Worst(k int) []Region {
+	rs := make([]Region, 0, len(g.Cells))
+	for c := range g.Cells {
+		rs = append(rs, g.Region(c%g.NX, c/g.NX))
+	}
+	sort.SliceStable(rs, func(a, b int) bool {
+		if rs[a].MaxULP != rs[b].MaxULP {
+			return rs[a].MaxULP > rs[b].MaxULP
+		}
+		return rs[a].MeanULP > rs[b].MeanULP
+	})
+	return rs[:min(k, len(rs))]
+}
+
+// Summary returns the number of arguments evaluated over the whole grid,
+// and the maximum and mean of their errors.
+func (g *Grid) Summary() (n int, maxULP, meanULP float64) {
+	sum := 0.0
+	for _, c := range g.Cells {
+		n += c.N
+		maxULP = math.Max(maxULP, float64(c.MaxULP))
+		sum += float64(c.MeanULP) * float64(c.N)
+	}
+	if n > 0 {
+		meanULP = sum / float64(n)
+	}
+	return n, maxULP, meanULP
+}
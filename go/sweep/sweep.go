@@ -0,0 +1,293 @@
+// Package sweep tests float32 functions exhaustively.
+//
+// There are fewer than 2**32 float32 values, so instead of drawing random
+// arguments as Cody's programs do, a sweep evaluates the function at every
+// representable argument in an interval and compares each result with a
+// double-double reference, and with a math/big one near rounding boundaries.
+// The work is sharded across goroutines; a sweep of all float32 arguments of
+// a function takes minutes on a machine with several CPUs.
+//
+// Functions of two arguments have too many arguments to test them all, so
+// Grid2 instead divides a rectangle of arguments into cells and records the
+// largest error in each, to show where in the plane a function degrades.
+package sweep
+
+import (
+	"math"
+	"math/big"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"golefunt/elefunt"
+	"golefunt/machar"
+	"golefunt/oracle"
+)
+
+// DefaultWorst is the number of worst arguments kept when Options leaves Worst zero.
+const DefaultWorst = 10
+
+// chunk is the number of consecutive arguments a goroutine takes at a time.
+const chunk = 1 << 16
+
+// boundary is the distance, in float32 ULPs, from a rounding boundary within
+// which a float64 reference value cannot be trusted to round correctly and
+// the math/big reference at full precision decides. It is wide enough for
+// float64 functions that are off by a few hundred of their own ULPs.
+const boundary = 1.0 / (1 << 20)
+
+// fastPrec is the precision in bits at which the math/big reference is
+// first evaluated where the float64 reference does not decide. Rounded to
+// float64 it is accurate to about 2**-29 float32 ULPs, well within boundary.
+const fastPrec = 64
+
+// Reference computes the correct value of a function. Exact is a math/big
+// implementation. Fast, if set, is a float64 implementation used instead of
+// Exact for results that are finite float32 values away from a rounding
+// boundary; it must not be derived from the function under test, or the
+// sweep can only find errors of its rounding to float32. Exact is evaluated
+// at a low precision first where Fast does not decide the correctly rounded
+// result, and at full precision only where that does not decide either.
+type Reference struct {
+	Fast  func(float64) float64
+	Exact oracle.Func1
+}
+
+// Lookup returns the reference for the function named name, such as "exp":
+// the oracle function of that name, and a double-double implementation of
+// this package, independent of the math package whose functions rounded to
+// float32 are those of elefunt.MathFuncs.
+func Lookup(name string) (Reference, bool) {
+	exact, ok := oracle.Lookup1(name)
+	if !ok {
+		return Reference{}, false
+	}
+	return Reference{Fast: fast[name], Exact: exact}, true
+}
+
+// Options controls a sweep. Lo and Hi bound the arguments; use -Inf and +Inf
+// to test every float32 value except NaN.
+type Options struct {
+	Lo, Hi  float32 // Closed interval of arguments
+	Workers int     // Number of goroutines, or runtime.NumCPU() if zero
+	Worst   int     // Number of worst arguments kept, or DefaultWorst if zero
+	Oracle  bool    // Compare every result with the math/big reference at full precision
+	Prec    uint    // Precision of the math/big reference in bits, or oracle.DefaultPrec if zero
+}
+
+// Point is the result of the function at one argument.
+type Point struct {
+	X    elefunt.Float `json:"x"`
+	Got  elefunt.Float `json:"got"`  // Value of the function under test
+	Want elefunt.Float `json:"want"` // Reference value rounded to float32
+	ULP  elefunt.Float `json:"ulp"`  // Error in float32 ULPs
+}
+
+// Result is the summary of a sweep.
+type Result struct {
+	Function  string        `json:"function,omitempty"`
+	Lo        elefunt.Float `json:"lo"`
+	Hi        elefunt.Float `json:"hi"`
+	N         uint64        `json:"n"`         // Number of arguments tested
+	Incorrect uint64        `json:"incorrect"` // Number of results other than the float32 nearest the reference
+	MaxULP    float64       `json:"max_ulp"`
+	MeanULP   float64       `json:"mean_ulp"`
+	Worst     []Point       `json:"worst"` // Arguments with the largest errors, largest first
+}
+
+// worse reports whether p belongs before q among the worst arguments.
+func worse(p, q Point) bool {
+	if p.ULP != q.ULP {
+		return p.ULP > q.ULP
+	}
+	return p.X < q.X
+}
+
+// worst holds the k points with the largest errors.
+type worst struct {
+	k   int
+	pts []Point
+}
+
+func (w *worst) add(p Point) {
+	if len(w.pts) == w.k && !worse(p, w.pts[len(w.pts)-1]) {
+		return
+	}
+	i := sort.Search(len(w.pts), func(i int) bool { return worse(p, w.pts[i]) })
+	if len(w.pts) < w.k {
+		w.pts = append(w.pts, Point{})
+	}
+	copy(w.pts[i+1:], w.pts[i:])
+	w.pts[i] = p
+}
+
+// Float32 evaluates f at every float32 argument in [opts.Lo, opts.Hi]
+// except NaN and compares the results with ref.
+func Float32(f func(float32) float32, ref Reference, opts Options) Result {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	k := opts.Worst
+	if k <= 0 {
+		k = DefaultWorst
+	}
+	prec := opts.Prec
+	if prec == 0 {
+		prec = oracle.DefaultPrec
+	}
+	mp := machar.Float32()
+
+	res := Result{Lo: elefunt.Float(opts.Lo), Hi: elefunt.Float(opts.Hi)}
+	first, last := elefunt.Ordinal(opts.Lo), elefunt.Ordinal(opts.Hi)
+	if first > last {
+		return res
+	}
+	chunks := int((last-first)/chunk + 1)
+	// Sums per chunk, added in order at the end so the mean does not
+	// depend on the scheduling of the goroutines.
+	sums := make([]float64, chunks)
+
+	var next atomic.Int64
+	var mu sync.Mutex
+	all := worst{k: k}
+	var wg sync.WaitGroup
+	for g := 0; g < workers; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var n, incorrect uint64
+			maxULP := 0.0
+			w := worst{k: k}
+			for {
+				c := next.Add(1) - 1
+				if c >= int64(chunks) {
+					break
+				}
+				lo := first + c*chunk
+				hi := min(lo+chunk-1, last)
+				sum := 0.0
+				for kx := lo; kx <= hi; kx++ {
+					x := elefunt.FromOrdinal[float32](kx)
+					got := f(x)
+					want, u := check(got, x, ref, opts.Oracle, prec, mp)
+					if !same(got, want) {
+						incorrect++
+					}
+					sum += u
+					maxULP = math.Max(maxULP, u)
+					w.add(Point{elefunt.Float(x), elefunt.Float(got), elefunt.Float(want), elefunt.Float(u)})
+				}
+				n += uint64(hi - lo + 1)
+				sums[c] = sum
+			}
+			mu.Lock()
+			res.N += n
+			res.Incorrect += incorrect
+			res.MaxULP = math.Max(res.MaxULP, maxULP)
+			for _, p := range w.pts {
+				all.add(p)
+			}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	sum := 0.0
+	for _, s := range sums {
+		sum += s
+	}
+	res.MeanULP = sum / float64(res.N)
+	res.Worst = all.pts
+	return res
+}
+
+// same reports whether the float32 results a and b are identical, treating
+// all NaNs as equal.
+func same(a, b float32) bool {
+	if a != a || b != b {
+		return a != a && b != b
+	}
+	return a == b && math.Signbit(float64(a)) == math.Signbit(float64(b))
+}
+
+// check returns the reference value at x rounded to float32 and the error
+// of got in float32 ULPs.
+func check(got, x float32, ref Reference, exact bool, prec uint, mp machar.Params) (float32, float64) {
+	xd := float64(x)
+	if !exact {
+		// A float64 value of the reference decides unless it is near a
+		// rounding boundary or not a finite value; one of the math/big
+		// reference beyond the range of float64 overflows float32 too.
+		if ref.Fast != nil {
+			if w := ref.Fast(xd); decides(w) {
+				return float32(w), ulps(got, w)
+			}
+		}
+		if want := ref.Exact(big.NewFloat(xd), fastPrec); want != nil {
+			if w, _ := want.Float64(); decides(w) || math.IsInf(w, 0) {
+				return float32(w), ulps(got, w)
+			}
+		}
+	}
+	want := ref.Exact(big.NewFloat(xd), prec)
+	return elefunt.RoundBig[float32](want), oracle.ULPs(float64(got), want, mp)
+}
+
+// overflow is the midpoint between the largest float32 value and 2**128,
+// from which values round to infinity.
+const overflow = math.MaxFloat32 + 0x1p103
+
+// decides reports whether w, a value of the reference accurate to a few
+// float64 ULPs, is known to round to the same float32 value as the correct
+// result: it is finite and not near a rounding boundary.
+func decides(w float64) bool {
+	switch {
+	case math.IsNaN(w) || math.IsInf(w, 0):
+		return false
+	case math.Abs(w) > math.MaxFloat32:
+		return math.Abs(math.Abs(w)-overflow) >= ulp(math.MaxFloat32)*boundary
+	}
+	return !nearBoundary(w)
+}
+
+// nearBoundary reports whether rounding w, a finite float32 value, to
+// float32 could round the correct value the other way.
+func nearBoundary(w float64) bool {
+	u := ulp(w)
+	d := math.Abs(w - float64(float32(w)))
+	return math.Abs(d-u/2) < u*boundary
+}
+
+// ulp returns the spacing of float32 values at w.
+func ulp(w float64) float64 {
+	// Below the smallest normal float32 the spacing stays that of 2**-126
+	_, e := math.Frexp(w)
+	if w == 0 || e < -125 {
+		e = -125
+	}
+	return math.Ldexp(1, e-24)
+}
+
+// ulps returns the error of got against w in float32 ULPs, following the
+// conventions of oracle.ULPs.
+func ulps(got float32, w float64) float64 {
+	g := float64(got)
+	switch {
+	case math.IsNaN(w) && math.IsNaN(g):
+		return 0
+	case math.IsNaN(w) || math.IsNaN(g):
+		return math.Inf(1)
+	case math.IsInf(g, 0):
+		// An infinite result is exact if the reference rounds to it: it has the
+		// same sign and reaches overflow.
+		if (g > 0) == (w > 0) && math.Abs(w) >= overflow {
+			return 0
+		}
+		return math.Inf(1)
+	case math.IsInf(w, 0):
+		return math.Inf(1)
+	}
+	return math.Abs(g-w) / ulp(w)
+}
@@ -0,0 +1,107 @@
+package sweep
+
+import (
+	"math"
+	"testing"
+
+	"golefunt/elefunt"
+)
+
+func lookup(t *testing.T, name string) Reference {
+	t.Helper()
+	ref, ok := Lookup(name)
+	if !ok {
+		t.Fatalf("no reference for %q", name)
+	}
+	return ref
+}
+
+func TestFloat32(t *testing.T) {
+	exp := func(x float32) float32 { return float32(math.Exp(float64(x))) }
+	// One float32 ULP too large everywhere.
+	up := func(x float32) float32 { return math.Nextafter32(exp(x), float32(math.Inf(1))) }
+	// math.Erfcinv overflows for arguments this small; the true value is about 8.
+	inf := func(float32) float32 { return float32(math.Inf(1)) }
+
+	tests := []struct {
+		desc      string
+		name      string
+		f         func(float32) float32
+		lo, hi    float32
+		incorrect bool    // Every result is incorrect, or none is
+		maxULP    float64 // Largest maximum error; it is within 1 ULP of this
+	}{
+		{"exp", "exp", exp, 1, 1.001, false, 0.5},
+		{"exp+1", "exp", up, 1, 1.001, true, 1.5},
+		{"erfcinv", "erfcinv", inf, 1e-30, 1.00001e-30, true, math.Inf(1)},
+		// Results from 88.72284 on overflow
+		{"exp overflow", "exp", exp, 88.72, 88.73, false, 0.5},
+	}
+	for _, tt := range tests {
+		for _, useOracle := range []bool{false, true} {
+			res := Float32(tt.f, lookup(t, tt.name), Options{Lo: tt.lo, Hi: tt.hi, Oracle: useOracle})
+			if n := elefunt.Ordinal(tt.hi) - elefunt.Ordinal(tt.lo) + 1; res.N != uint64(n) {
+				t.Errorf("%s, oracle %v: tested %d arguments, want %d", tt.desc, useOracle, res.N, n)
+			}
+			want := uint64(0)
+			if tt.incorrect {
+				want = res.N
+			}
+			if res.Incorrect != want {
+				t.Errorf("%s, oracle %v: %d of %d results incorrect, want %d", tt.desc, useOracle, res.Incorrect, res.N, want)
+			}
+			if res.MaxULP > tt.maxULP || res.MaxULP < tt.maxULP-1 {
+				t.Errorf("%s, oracle %v: maximum error %g ULPs, want %g to %g", tt.desc, useOracle, res.MaxULP, tt.maxULP-1, tt.maxULP)
+			}
+		}
+	}
+}
+
+func TestDecides(t *testing.T) {
+	one := float64(math.Nextafter32(1, 2)) - 1 // float32 ULP of 1
+	tests := []struct {
+		w    float64
+		want bool
+	}{
+		{1, true},
+		{1 + one/2, false},              // Halfway between two float32 values
+		{1 + one/2 + one/(1<<10), true}, // Well clear of it
+		{math.MaxFloat32, true},
+		{overflow, false},
+		{overflow * (1 + 0x1p-40), true},
+		{math.MaxFloat64, true},
+		{math.Inf(1), false},
+		{math.NaN(), false},
+	}
+	for _, tt := range tests {
+		if got := decides(tt.w); got != tt.want {
+			t.Errorf("decides(%g) = %v, want %v", tt.w, got, tt.want)
+		}
+	}
+}
+
+func TestULPs(t *testing.T) {
+	inf := float32(math.Inf(1))
+	below := (math.MaxFloat32 + overflow) / 2 // Rounds to MaxFloat32
+	tests := []struct {
+		got  float32
+		w    float64
+		want float64
+	}{
+		{1, 1, 0},
+		{math.MaxFloat32, below, 0.25},
+		{inf, below, math.Inf(1)},
+		{inf, overflow, 0},
+		{-inf, -overflow, 0},
+		{-inf, overflow, math.Inf(1)},
+		{inf, math.Inf(1), 0},
+		{math.MaxFloat32, math.Inf(1), math.Inf(1)},
+		{float32(math.NaN()), math.NaN(), 0},
+		{1, math.NaN(), math.Inf(1)},
+	}
+	for _, tt := range tests {
+		if got := ulps(tt.got, tt.w); got != tt.want {
+			t.Errorf("ulps(%g, %g) = %g, want %g", tt.got, tt.w, got, tt.want)
+		}
+	}
+}